@@ -13,15 +13,20 @@ import (
 	"github.com/alexedwards/argon2id"
 	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/danielbukowski/recipe-app-backend/internal/auth"
+	"github.com/danielbukowski/recipe-app-backend/internal/blobstore"
 	"github.com/danielbukowski/recipe-app-backend/internal/cache"
 	"github.com/danielbukowski/recipe-app-backend/internal/config"
+	"github.com/danielbukowski/recipe-app-backend/internal/cookbook"
 	"github.com/danielbukowski/recipe-app-backend/internal/healthcheck"
+	"github.com/danielbukowski/recipe-app-backend/internal/mailer"
 	passwordHasher "github.com/danielbukowski/recipe-app-backend/internal/password-hasher"
 	"github.com/danielbukowski/recipe-app-backend/internal/recipe"
 	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/throttle"
 
 	"github.com/danielbukowski/recipe-app-backend/internal/user"
 	"github.com/danielbukowski/recipe-app-backend/internal/validator"
+	"github.com/danielbukowski/recipe-app-backend/internal/webauthn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -88,6 +93,13 @@ func main() {
 	e := echo.New()
 	e.Validator = validator.New()
 
+	// Without a trusted proxy the X-Forwarded-For header is set by clients, who could change their IP address at will.
+	if cfg.TrustProxyHeaders {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	} else {
+		e.IPExtractor = echo.ExtractIPDirect()
+	}
+
 	isDev := cfg.AppEnv == "development"
 
 	if isDev {
@@ -99,12 +111,6 @@ func main() {
 
 	sessionStorage := session.NewSessionStorage(mcache)
 
-	e.Use(session.Middleware(sessionStorage, sessionCookieName, func(c echo.Context) bool {
-		return strings.HasPrefix(c.Path(), "/api/v1/auth/")
-	}))
-
-	e.Use(middleware.Recover())
-
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	healthcheckHandler := healthcheck.NewHandler()
@@ -112,10 +118,40 @@ func main() {
 
 	cacheStorage := cache.New(mcache)
 
-	recipeService := recipe.NewService(logger, dbpool)
+	var blobStore blobstore.Store
+
+	switch cfg.BlobStorage {
+	case "s3":
+		blobStore = blobstore.NewS3(blobstore.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PublicURL:       cfg.BlobPublicURL,
+		}, &http.Client{Timeout: 30 * time.Second})
+	default:
+		// The API serves the images itself when they are stored on its disk.
+		publicURL := cfg.BlobPublicURL
+		if publicURL == "" {
+			publicURL = fmt.Sprintf("http://%s:%s/uploads", cfg.DomainName, cfg.HTTPServerPort)
+		}
+
+		blobStore = blobstore.NewLocal(cfg.BlobLocalDir, publicURL)
+		e.Static("/uploads", cfg.BlobLocalDir)
+	}
+
+	recipeService := recipe.NewService(logger, dbpool, blobStore, cfg.TrashRetention)
 	recipeHandler := recipe.NewHandler(logger, cacheStorage, recipeService)
 	recipeHandler.RegisterRoutes(e)
 
+	go recipeService.RunTrashPurge(ctx, cfg.TrashPurgeInterval)
+	go recipeService.RunPublishScheduler(ctx, cfg.PublishSchedulerInterval)
+
+	cookbookService := cookbook.NewService(logger, dbpool)
+	cookbookHandler := cookbook.NewHandler(logger, cookbookService)
+	cookbookHandler.RegisterRoutes(e)
+
 	passwordHasher := passwordHasher.New(&argon2id.Params{
 		Memory:      cfg.ArgonMemory,
 		Iterations:  cfg.ArgonIterations,
@@ -124,11 +160,110 @@ func main() {
 		KeyLength:   cfg.ArgonKeyLength,
 	})
 
-	userService := user.NewService(logger, passwordHasher, dbpool)
+	appURL := cfg.AppURL
+	if appURL == "" {
+		appURL = fmt.Sprintf("http://%s:%s", cfg.DomainName, cfg.HTTPServerPort)
+	}
+
+	var mailSender mailer.Mailer
+
+	switch cfg.Mailer {
+	case "smtp":
+		mailSender = mailer.NewSMTP(mailer.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+		})
+	default:
+		mailSender = mailer.NewFile(cfg.MailerFileDir, cfg.MailFrom)
+	}
+
+	relyingParty := webauthn.RelyingParty{
+		ID:      cfg.WebAuthnRPID,
+		Name:    cfg.WebAuthnRPName,
+		Origins: cfg.WebAuthnOrigins,
+	}
 
-	authHandler := auth.NewHandler(logger, userService, sessionStorage, isDev, sessionCookieName)
+	if relyingParty.ID == "" {
+		relyingParty.ID = cfg.DomainName
+	}
+
+	if len(relyingParty.Origins) == 0 {
+		relyingParty.Origins = []string{strings.TrimSuffix(appURL, "/")}
+	}
+
+	userService, err := user.NewService(logger, passwordHasher, dbpool, mailSender, user.EmailConfig{
+		AppURL:                     appURL,
+		PasswordResetTokenTTL:      cfg.PasswordResetTokenTTL,
+		VerificationTokenTTL:       cfg.EmailVerificationTokenTTL,
+		VerificationResendInterval: cfg.EmailVerificationResendInterval,
+	}, relyingParty)
+	if err != nil {
+		panic(errors.Join(errors.New("failed to create the user service"), err))
+	}
+
+	// The session middleware needs the user service, and echo runs it for the routes registered above as well.
+	e.Use(session.Middleware(sessionStorage, userService, sessionCookieName, func(c echo.Context) bool {
+		return strings.HasPrefix(c.Path(), "/api/v1/auth/")
+	}))
+
+	e.Use(middleware.Recover())
+
+	var throttleStore throttle.Store
+
+	switch cfg.SignInThrottleStore {
+	case "memory":
+		throttleStore = throttle.NewMemory()
+	default:
+		throttleStore = throttle.NewMemcached(mcache)
+	}
+
+	accountPolicy := throttle.Policy{
+		FreeAttempts:    cfg.SignInAccountFreeAttempts,
+		BaseDelay:       cfg.SignInBackoffBase,
+		MaxDelay:        cfg.SignInBackoffMax,
+		LockoutAttempts: cfg.SignInAccountLockoutAttempts,
+		LockoutDuration: cfg.SignInAccountLockout,
+		Window:          cfg.SignInThrottleWindow,
+	}
+
+	accountLimiter := throttle.New(throttleStore, "account", accountPolicy)
+
+	ipLimiter := throttle.New(throttleStore, "ip", throttle.Policy{
+		FreeAttempts:    cfg.SignInIPFreeAttempts,
+		BaseDelay:       cfg.SignInBackoffBase,
+		MaxDelay:        cfg.SignInBackoffMax,
+		LockoutAttempts: cfg.SignInIPLockoutAttempts,
+		LockoutDuration: cfg.SignInIPLockout,
+		Window:          cfg.SignInThrottleWindow,
+	})
+
+	// Every password reset counts, so the limit is the number of resets after which the rest are locked out.
+	resetEmailLimiter := throttle.New(throttleStore, "password_reset_email", throttle.Policy{
+		LockoutAttempts: cfg.PasswordResetEmailLimit,
+		LockoutDuration: cfg.PasswordResetWindow,
+		Window:          cfg.PasswordResetWindow,
+	})
+
+	resetIPLimiter := throttle.New(throttleStore, "password_reset_ip", throttle.Policy{
+		LockoutAttempts: cfg.PasswordResetIPLimit,
+		LockoutDuration: cfg.PasswordResetWindow,
+		Window:          cfg.PasswordResetWindow,
+	})
+
+	go userService.RunPasswordResets(ctx)
+
+	authHandler := auth.NewHandler(logger, userService, sessionStorage, isDev, sessionCookieName, accountLimiter, ipLimiter, resetEmailLimiter, resetIPLimiter)
 	authHandler.RegisterRoutes(e)
 
+	// Codes to turn off two-factor authentication are guessed like passwords, so they are throttled like sign-ins of an account.
+	twoFactorLimiter := throttle.New(throttleStore, "two_factor_disable", accountPolicy)
+
+	userHandler := user.NewHandler(logger, userService, sessionStorage, twoFactorLimiter)
+	userHandler.RegisterRoutes(e)
+
 	errorLog, err := zap.NewStdLogAt(logger, zapcore.ErrorLevel)
 	if err != nil {
 		panic(errors.Join(errors.New("failed to create a logger to http errors"), err))
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE recipes
+    ADD COLUMN user_id UUID REFERENCES users(user_id) ON DELETE CASCADE;
+
+-- Recipes were not owned by anyone before, so they are given to an account nobody can sign in to.
+-- They stay readable, but nobody can edit or delete them.
+INSERT INTO users(user_id, email, password)
+SELECT '00000000-0000-0000-0000-000000000000', 'former-recipes@example.invalid', '!'
+WHERE EXISTS (SELECT 1 FROM recipes WHERE user_id IS NULL);
+
+UPDATE recipes SET user_id = '00000000-0000-0000-0000-000000000000' WHERE user_id IS NULL;
+
+ALTER TABLE recipes
+    ALTER COLUMN user_id SET NOT NULL;
+
+CREATE INDEX idx_recipes_user_id ON recipes(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_recipes_user_id;
+ALTER TABLE recipes DROP COLUMN user_id;
+DELETE FROM users WHERE user_id = '00000000-0000-0000-0000-000000000000';
+-- +goose StatementEnd
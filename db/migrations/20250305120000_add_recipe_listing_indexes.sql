@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_recipes_created_at ON recipes(created_at DESC, recipe_id DESC);
+CREATE INDEX idx_recipes_updated_at ON recipes(updated_at DESC, recipe_id DESC);
+CREATE INDEX idx_recipes_title ON recipes(title, recipe_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_recipes_title;
+DROP INDEX idx_recipes_updated_at;
+DROP INDEX idx_recipes_created_at;
+-- +goose StatementEnd
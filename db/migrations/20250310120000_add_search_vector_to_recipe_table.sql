@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE recipes
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', content), 'B')
+    ) STORED;
+
+CREATE INDEX idx_recipes_search_vector ON recipes USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_recipes_search_vector;
+ALTER TABLE recipes DROP COLUMN search_vector;
+-- +goose StatementEnd
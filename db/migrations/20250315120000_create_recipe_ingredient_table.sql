@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE recipe_ingredients(
+    recipe_id UUID NOT NULL REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    quantity DOUBLE PRECISION,
+    unit TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (recipe_id, position)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE recipe_ingredients;
+-- +goose StatementEnd
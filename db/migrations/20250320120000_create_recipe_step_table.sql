@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE recipe_steps(
+    step_id UUID PRIMARY KEY,
+    recipe_id UUID NOT NULL REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    instruction TEXT NOT NULL,
+    duration_minutes INTEGER,
+    temperature INTEGER,
+    temperature_unit TEXT CHECK (temperature_unit IN ('C', 'F')),
+    CHECK ((temperature IS NULL) = (temperature_unit IS NULL)),
+    -- Deferred, so positions of steps can be shifted within a transaction.
+    UNIQUE (recipe_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE recipe_steps;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE recipes
+    ADD COLUMN servings INTEGER NOT NULL DEFAULT 1 CHECK (servings > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipes DROP COLUMN servings;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN measurement_system TEXT NULL CHECK (measurement_system IN ('metric', 'imperial'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN measurement_system;
+-- +goose StatementEnd
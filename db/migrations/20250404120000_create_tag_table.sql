@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tags(
+    tag_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- Names are stored lowercase with single spaces, so "Gluten  Free" and "gluten free" are the same tag.
+    name TEXT NOT NULL UNIQUE CHECK (name <> '' AND name = lower(regexp_replace(btrim(name), '\s+', ' ', 'g'))),
+    category TEXT NOT NULL DEFAULT 'other' CHECK (category IN ('cuisine', 'meal_type', 'diet', 'other'))
+);
+
+CREATE TABLE recipe_tags(
+    recipe_id UUID NOT NULL REFERENCES recipes ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags ON DELETE CASCADE,
+    PRIMARY KEY (recipe_id, tag_id)
+);
+
+CREATE INDEX idx_recipe_tags_tag_id ON recipe_tags(tag_id);
+
+INSERT INTO tags (name, category) VALUES
+    ('american', 'cuisine'),
+    ('chinese', 'cuisine'),
+    ('french', 'cuisine'),
+    ('greek', 'cuisine'),
+    ('indian', 'cuisine'),
+    ('italian', 'cuisine'),
+    ('japanese', 'cuisine'),
+    ('korean', 'cuisine'),
+    ('mexican', 'cuisine'),
+    ('middle eastern', 'cuisine'),
+    ('polish', 'cuisine'),
+    ('spanish', 'cuisine'),
+    ('thai', 'cuisine'),
+    ('vietnamese', 'cuisine'),
+    ('breakfast', 'meal_type'),
+    ('lunch', 'meal_type'),
+    ('dinner', 'meal_type'),
+    ('appetizer', 'meal_type'),
+    ('side dish', 'meal_type'),
+    ('dessert', 'meal_type'),
+    ('snack', 'meal_type'),
+    ('drink', 'meal_type'),
+    ('vegetarian', 'diet'),
+    ('vegan', 'diet'),
+    ('gluten free', 'diet'),
+    ('dairy free', 'diet'),
+    ('nut free', 'diet'),
+    ('low carb', 'diet'),
+    ('keto', 'diet'),
+    ('halal', 'diet'),
+    ('kosher', 'diet');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE recipe_tags;
+DROP TABLE tags;
+-- +goose StatementEnd
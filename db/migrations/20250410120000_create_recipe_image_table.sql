@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE recipe_images(
+    image_id UUID PRIMARY KEY,
+    recipe_id UUID NOT NULL REFERENCES recipes ON DELETE CASCADE,
+    object_key TEXT NOT NULL UNIQUE,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_recipe_images_recipe_id ON recipe_images(recipe_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE recipe_images;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE recipe_reviews(
+    recipe_id UUID NOT NULL REFERENCES recipes ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (recipe_id, user_id)
+);
+
+CREATE INDEX idx_recipe_reviews_recipe_id_created_at ON recipe_reviews(recipe_id, created_at DESC, user_id DESC);
+
+-- The aggregate of ratings is kept on recipes, so reading a recipe does not scan its reviews.
+ALTER TABLE recipes
+    ADD COLUMN rating_sum INTEGER NOT NULL DEFAULT 0 CHECK (rating_sum >= 0),
+    ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0 CHECK (rating_count >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipes
+    DROP COLUMN rating_count,
+    DROP COLUMN rating_sum;
+
+DROP TABLE recipe_reviews;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE favorites(
+    user_id UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+    recipe_id UUID NOT NULL REFERENCES recipes ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, recipe_id)
+);
+
+CREATE INDEX idx_favorites_user_id_created_at ON favorites(user_id, created_at DESC, recipe_id DESC);
+CREATE INDEX idx_favorites_recipe_id ON favorites(recipe_id);
+
+-- The count is kept on recipes, so listing recipes sorted by it does not count favorites of every recipe.
+ALTER TABLE recipes ADD COLUMN favorite_count INTEGER NOT NULL DEFAULT 0 CHECK (favorite_count >= 0);
+
+CREATE INDEX idx_recipes_favorite_count ON recipes(favorite_count DESC, recipe_id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_recipes_favorite_count;
+
+ALTER TABLE recipes DROP COLUMN favorite_count;
+
+DROP TABLE favorites;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE cookbooks(
+    cookbook_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_cookbooks_user_id_created_at ON cookbooks(user_id, created_at DESC, cookbook_id DESC);
+
+CREATE TABLE cookbook_recipes(
+    cookbook_id UUID NOT NULL REFERENCES cookbooks ON DELETE CASCADE,
+    recipe_id UUID NOT NULL REFERENCES recipes ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position > 0),
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (cookbook_id, recipe_id),
+    -- Deferred, so positions of recipes can be shifted within a transaction.
+    UNIQUE (cookbook_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE INDEX idx_cookbook_recipes_recipe_id ON cookbook_recipes(recipe_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE cookbook_recipes;
+DROP TABLE cookbooks;
+-- +goose StatementEnd
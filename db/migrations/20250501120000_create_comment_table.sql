@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE comments(
+    comment_id UUID PRIMARY KEY,
+    recipe_id UUID NOT NULL REFERENCES recipes ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+    -- Replies point to a top-level comment, so threads are one level deep.
+    parent_id UUID REFERENCES comments ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    -- Deleted comments are kept, so their replies stay visible.
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX idx_comments_recipe_id_created_at ON comments(recipe_id, created_at DESC, comment_id DESC) WHERE parent_id IS NULL;
+CREATE INDEX idx_comments_parent_id_created_at ON comments(parent_id, created_at, comment_id) WHERE parent_id IS NOT NULL;
+
+-- Mentions are stored to notify the mentioned users. The user is NULL for handles which do not match any user.
+CREATE TABLE comment_mentions(
+    comment_id UUID NOT NULL REFERENCES comments ON DELETE CASCADE,
+    handle TEXT NOT NULL,
+    user_id UUID REFERENCES users ON DELETE SET NULL,
+    PRIMARY KEY (comment_id, handle)
+);
+
+CREATE INDEX idx_comment_mentions_user_id ON comment_mentions(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE comment_mentions;
+DROP TABLE comments;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Revisions are snapshots of the state of a recipe saved by its creation and by every update, numbered from 1 for every recipe.
+CREATE TABLE recipe_revisions(
+    recipe_id UUID NOT NULL REFERENCES recipes ON DELETE CASCADE,
+    revision_number INTEGER NOT NULL CHECK (revision_number > 0),
+    title TEXT NOT NULL,
+    content TEXT NOT NULL,
+    servings INTEGER NOT NULL,
+    ingredients JSONB NOT NULL,
+    tags TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (recipe_id, revision_number)
+);
+
+CREATE FUNCTION reject_recipe_revision_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'recipe revisions cannot be modified';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_recipe_revisions_immutable
+    BEFORE UPDATE ON recipe_revisions
+    FOR EACH ROW EXECUTE FUNCTION reject_recipe_revision_update();
+
+-- Existing recipes start their history from their current state.
+INSERT INTO recipe_revisions (recipe_id, revision_number, title, content, servings, ingredients, tags, created_at)
+SELECT r.recipe_id, 1, r.title, r.content, r.servings,
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object('quantity', i.quantity, 'unit', i.unit, 'name', i.name, 'note', i.note) ORDER BY i.position)
+        FROM recipe_ingredients i
+        WHERE i.recipe_id = r.recipe_id
+    ), '[]'),
+    COALESCE((
+        SELECT array_agg(t.name ORDER BY t.name)
+        FROM recipe_tags rt
+        JOIN tags t ON t.tag_id = rt.tag_id
+        WHERE rt.recipe_id = r.recipe_id
+    ), '{}'),
+    r.updated_at
+FROM recipes r;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE recipe_revisions;
+DROP FUNCTION reject_recipe_revision_update;
+-- +goose StatementEnd
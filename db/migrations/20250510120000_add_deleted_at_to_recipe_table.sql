@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deleted recipes stay in the trash of their owners until they are restored or purged after the retention period.
+ALTER TABLE recipes ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_recipes_user_id_deleted_at ON recipes(user_id, deleted_at DESC, recipe_id DESC) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_recipes_deleted_at ON recipes(deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_recipes_deleted_at;
+DROP INDEX idx_recipes_user_id_deleted_at;
+
+ALTER TABLE recipes DROP COLUMN deleted_at;
+-- +goose StatementEnd
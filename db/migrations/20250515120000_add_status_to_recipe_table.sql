@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Existing recipes were visible to everyone, so they are published. New recipes start as drafts.
+ALTER TABLE recipes ADD COLUMN status TEXT NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'scheduled', 'published'));
+ALTER TABLE recipes ALTER COLUMN status SET DEFAULT 'draft';
+
+-- publish_at is when a scheduled recipe is going to be published, or when a published recipe was published.
+ALTER TABLE recipes ADD COLUMN publish_at TIMESTAMP;
+UPDATE recipes SET publish_at = created_at;
+ALTER TABLE recipes ADD CONSTRAINT chk_recipes_publish_at CHECK (status = 'draft' OR publish_at IS NOT NULL);
+
+CREATE INDEX idx_recipes_scheduled_publish_at ON recipes(publish_at) WHERE status = 'scheduled';
+CREATE INDEX idx_recipes_user_id_updated_at ON recipes(user_id, updated_at DESC, recipe_id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_recipes_user_id_updated_at;
+DROP INDEX idx_recipes_scheduled_publish_at;
+
+ALTER TABLE recipes DROP CONSTRAINT chk_recipes_publish_at;
+ALTER TABLE recipes DROP COLUMN publish_at;
+ALTER TABLE recipes DROP COLUMN status;
+-- +goose StatementEnd
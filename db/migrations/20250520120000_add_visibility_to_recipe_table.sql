@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Private recipes are visible only to their owner. Unlisted recipes can be read by anyone with their ID,
+-- but only public recipes are listed and searched.
+ALTER TABLE recipes ADD COLUMN visibility TEXT NOT NULL DEFAULT 'public' CHECK (visibility IN ('private', 'unlisted', 'public'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipes DROP COLUMN visibility;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Share links grant read access to one recipe without an account. Only a hash of the token is stored,
+-- so the token is known only to the owner who created the link.
+CREATE TABLE recipe_share_links(
+    share_link_id UUID PRIMARY KEY,
+    recipe_id UUID NOT NULL REFERENCES recipes ON DELETE CASCADE,
+    token_hash BYTEA NOT NULL UNIQUE,
+    -- Links without an expiration time are valid until they are revoked.
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    view_count INT NOT NULL DEFAULT 0,
+    last_accessed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_recipe_share_links_recipe_id_created_at ON recipe_share_links(recipe_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE recipe_share_links;
+-- +goose StatementEnd
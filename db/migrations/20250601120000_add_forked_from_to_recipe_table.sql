@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A fork keeps the ID, title and author of the original recipe, so the attribution stays
+-- even after the original is deleted and purged. That is why forked_from is not a foreign key.
+ALTER TABLE recipes ADD COLUMN forked_from UUID;
+ALTER TABLE recipes ADD COLUMN forked_from_user_id UUID REFERENCES users ON DELETE SET NULL;
+ALTER TABLE recipes ADD COLUMN forked_from_title TEXT;
+
+CREATE INDEX idx_recipes_forked_from_created_at ON recipes(forked_from, created_at DESC, recipe_id DESC) WHERE forked_from IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_recipes_forked_from_created_at;
+
+ALTER TABLE recipes DROP COLUMN forked_from_title;
+ALTER TABLE recipes DROP COLUMN forked_from_user_id;
+ALTER TABLE recipes DROP COLUMN forked_from;
+-- +goose StatementEnd
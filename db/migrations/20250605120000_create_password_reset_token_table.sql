@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Only a hash of the token is stored, so the token is known only to the owner of the mailbox it was sent to.
+CREATE TABLE password_reset_tokens(
+    token_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+    token_hash BYTEA NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens(user_id) WHERE used_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE password_reset_tokens;
+-- +goose StatementEnd
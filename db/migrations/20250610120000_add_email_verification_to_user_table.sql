@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Accounts created before emails were verified keep working, so their emails are treated as verified.
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP;
+UPDATE users SET email_verified_at = created_at;
+
+-- Only a hash of the token is stored, so the token is known only to the owner of the mailbox it was sent to.
+CREATE TABLE email_verification_tokens(
+    token_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+    token_hash BYTEA NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_email_verification_tokens_user_id_created_at ON email_verification_tokens(user_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE email_verification_tokens;
+
+ALTER TABLE users DROP COLUMN email_verified_at;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- totp_secret is set on enrollment, and two-factor authentication is turned on once a code confirms it.
+-- totp_last_counter is the period of the last used code, so a code cannot be used twice.
+ALTER TABLE users ADD COLUMN totp_secret TEXT;
+ALTER TABLE users ADD COLUMN totp_enabled_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN totp_last_counter BIGINT;
+
+-- Only hashes of the recovery codes are stored, the codes are shown to the user once.
+CREATE TABLE two_factor_recovery_codes(
+    user_id UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+    code_hash BYTEA NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, code_hash)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE two_factor_recovery_codes;
+
+ALTER TABLE users DROP COLUMN totp_last_counter;
+ALTER TABLE users DROP COLUMN totp_enabled_at;
+ALTER TABLE users DROP COLUMN totp_secret;
+-- +goose StatementEnd
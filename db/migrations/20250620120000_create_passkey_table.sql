@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- credential_id is the ID of the passkey chosen by the authenticator, and public_key is its COSE encoded public key.
+-- sign_count is the signature counter of the last sign-in, which reveals cloned authenticators.
+CREATE TABLE passkeys(
+    passkey_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+    credential_id BYTEA NOT NULL UNIQUE,
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    aaguid BYTEA NOT NULL,
+    transports TEXT[] NOT NULL DEFAULT '{}',
+    name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP
+);
+
+CREATE INDEX idx_passkeys_user_id ON passkeys(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE passkeys;
+-- +goose StatementEnd
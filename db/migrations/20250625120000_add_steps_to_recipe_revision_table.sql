@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Revisions saved before steps and visibility were kept have them NULL, so reverting to them keeps the current steps.
+ALTER TABLE recipe_revisions ADD COLUMN steps JSONB;
+ALTER TABLE recipe_revisions ADD COLUMN visibility TEXT CHECK (visibility IN ('private', 'unlisted', 'public'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipe_revisions DROP COLUMN visibility;
+ALTER TABLE recipe_revisions DROP COLUMN steps;
+-- +goose StatementEnd
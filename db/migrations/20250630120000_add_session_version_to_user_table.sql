@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- session_version is copied into every session of the user and is raised when the password changes,
+-- so older sessions are rejected even when memcached has lost track of them.
+-- Sessions created before this column have no version, which is the same as the default.
+ALTER TABLE users ADD COLUMN session_version INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN session_version;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- name: CreateComment :exec
+INSERT INTO comments (
+    comment_id,
+    recipe_id,
+    user_id,
+    parent_id,
+    body
+) VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetCommentById :one
+SELECT * FROM comments
+    WHERE comment_id = $1 AND recipe_id = $2;
+
+-- name: UpdateCommentBody :execrows
+UPDATE comments
+    SET body = $2, updated_at = NOW()
+    WHERE comment_id = $1 AND deleted_at IS NULL;
+
+-- name: SoftDeleteComment :execrows
+UPDATE comments
+    SET body = '', deleted_at = NOW()
+    WHERE comment_id = $1 AND deleted_at IS NULL;
+
+-- name: CreateCommentMentions :exec
+INSERT INTO comment_mentions (comment_id, handle, user_id)
+SELECT sqlc.arg(comment_id)::uuid, handles.handle, u.user_id
+    FROM unnest(sqlc.arg(handles)::text[]) AS handles(handle)
+    LEFT JOIN users u ON lower(u.email) = handles.handle
+ON CONFLICT (comment_id, handle) DO NOTHING;
+
+-- name: DeleteCommentMentions :exec
+DELETE FROM comment_mentions
+    WHERE comment_id = $1;
+
+-- name: ListRecipeComments :many
+SELECT c.comment_id, c.user_id, c.body, c.created_at, c.updated_at, c.deleted_at,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.comment_id AND r.deleted_at IS NULL)::int AS reply_count
+    FROM comments c
+    WHERE c.recipe_id = $1 AND c.parent_id IS NULL
+    AND (c.deleted_at IS NULL OR EXISTS (
+        SELECT 1 FROM comments r WHERE r.parent_id = c.comment_id AND r.deleted_at IS NULL
+    ))
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (c.created_at, c.comment_id) < (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_id)::uuid))
+    ORDER BY c.created_at DESC, c.comment_id DESC
+    LIMIT sqlc.arg(row_limit);
+
+-- name: ListCommentReplies :many
+SELECT comment_id, user_id, body, created_at, updated_at FROM comments
+    WHERE parent_id = $1 AND deleted_at IS NULL
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (created_at, comment_id) > (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_id)::uuid))
+    ORDER BY created_at, comment_id
+    LIMIT sqlc.arg(row_limit);
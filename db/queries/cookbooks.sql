@@ -0,0 +1,87 @@
+-- name: CreateCookbook :exec
+INSERT INTO cookbooks (
+    cookbook_id,
+    user_id,
+    name,
+    description,
+    is_public
+) VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetCookbookById :one
+SELECT * FROM cookbooks
+    WHERE cookbook_id = $1;
+
+-- name: UpdateCookbook :execrows
+UPDATE cookbooks
+    SET name = $2, description = $3, is_public = $4, updated_at = NOW()
+    WHERE cookbook_id = $1;
+
+-- name: DeleteCookbook :exec
+DELETE FROM cookbooks
+    WHERE cookbook_id = $1;
+
+-- name: ListCookbooksByUserId :many
+SELECT c.cookbook_id, c.user_id, c.name, c.description, c.is_public, c.created_at, c.updated_at,
+    (SELECT COUNT(*) FROM cookbook_recipes cr
+        JOIN recipes r ON r.recipe_id = cr.recipe_id
+        WHERE cr.cookbook_id = c.cookbook_id AND r.deleted_at IS NULL
+        AND ((r.status = 'published' AND r.visibility <> 'private') OR (sqlc.arg(include_private)::boolean AND r.user_id = c.user_id)))::int AS recipe_count
+    FROM cookbooks c
+    WHERE c.user_id = $1
+    AND (sqlc.arg(include_private)::boolean OR c.is_public)
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (c.created_at, c.cookbook_id) < (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_id)::uuid))
+    ORDER BY c.created_at DESC, c.cookbook_id DESC
+    LIMIT sqlc.arg(row_limit);
+
+-- name: LockCookbook :one
+SELECT cookbook_id FROM cookbooks
+    WHERE cookbook_id = $1
+    FOR UPDATE;
+
+-- name: GetCookbookRecipes :many
+SELECT cr.position, cr.recipe_id, r.user_id, r.title, cr.added_at FROM cookbook_recipes cr
+    JOIN recipes r ON r.recipe_id = cr.recipe_id
+    WHERE cr.cookbook_id = $1 AND r.deleted_at IS NULL
+    AND ((r.status = 'published' AND r.visibility <> 'private') OR r.user_id = sqlc.narg(viewer_id)::uuid)
+    ORDER BY cr.position;
+
+-- name: CountCookbookRecipes :one
+SELECT count(*) FROM cookbook_recipes
+    WHERE cookbook_id = $1;
+
+-- name: CreateCookbookRecipe :execrows
+INSERT INTO cookbook_recipes (
+    cookbook_id,
+    recipe_id,
+    position
+)
+SELECT c.cookbook_id, r.recipe_id, $3 FROM recipes r
+    JOIN cookbooks c ON c.cookbook_id = $1
+    WHERE r.recipe_id = $2 AND r.deleted_at IS NULL
+    AND ((r.status = 'published' AND r.visibility <> 'private') OR r.user_id = c.user_id);
+
+-- name: ShiftCookbookRecipesDown :exec
+UPDATE cookbook_recipes
+    SET position = position + 1
+    WHERE cookbook_id = $1 AND position >= $2;
+
+-- name: ShiftCookbookRecipesUp :exec
+UPDATE cookbook_recipes
+    SET position = position - 1
+    WHERE cookbook_id = $1 AND position > $2;
+
+-- name: DeleteCookbookRecipe :one
+DELETE FROM cookbook_recipes
+    WHERE cookbook_id = $1 AND recipe_id = $2
+RETURNING position;
+
+-- name: DeleteRecipeFromCookbooks :exec
+WITH deleted_entries AS (
+    DELETE FROM cookbook_recipes
+        WHERE recipe_id = $1
+    RETURNING cookbook_id, position
+)
+UPDATE cookbook_recipes cr
+    SET position = cr.position - 1
+    FROM deleted_entries d
+    WHERE cr.cookbook_id = d.cookbook_id AND cr.position > d.position;
@@ -0,0 +1,31 @@
+-- name: CreateEmailVerificationToken :exec
+INSERT INTO email_verification_tokens (
+    token_id,
+    user_id,
+    token_hash,
+    expires_at
+) VALUES ($1, $2, $3, $4);
+
+-- name: CreateEmailVerificationTokenIfIdle :execrows
+INSERT INTO email_verification_tokens (
+    token_id,
+    user_id,
+    token_hash,
+    expires_at
+)
+SELECT $1, $2, $3, $4
+    WHERE NOT EXISTS (
+        SELECT 1 FROM email_verification_tokens
+        WHERE user_id = $2 AND created_at > sqlc.arg(created_after)
+    );
+
+-- name: UseEmailVerificationToken :one
+UPDATE email_verification_tokens
+    SET used_at = NOW()
+    WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+RETURNING user_id;
+
+-- name: InvalidateEmailVerificationTokens :exec
+UPDATE email_verification_tokens
+    SET used_at = NOW()
+    WHERE user_id = $1 AND used_at IS NULL;
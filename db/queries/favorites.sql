@@ -0,0 +1,30 @@
+-- name: CreateFavorite :execrows
+INSERT INTO favorites (
+    user_id,
+    recipe_id
+) VALUES ($1, $2)
+ON CONFLICT (user_id, recipe_id) DO NOTHING;
+
+-- name: DeleteFavorite :execrows
+DELETE FROM favorites
+    WHERE user_id = $1 AND recipe_id = $2;
+
+-- name: UpdateRecipeFavoriteCount :exec
+UPDATE recipes
+    SET favorite_count = favorite_count + sqlc.arg(favorite_count_delta)::int
+    WHERE recipe_id = $1;
+
+-- name: IsFavoriteRecipe :one
+SELECT EXISTS (
+    SELECT 1 FROM favorites
+        WHERE user_id = $1 AND recipe_id = $2
+);
+
+-- name: ListFavoriteRecipes :many
+SELECT r.recipe_id, r.user_id, r.title, r.favorite_count, r.created_at, r.updated_at, f.created_at AS favorited_at FROM favorites f
+    JOIN recipes r ON r.recipe_id = f.recipe_id
+    WHERE f.user_id = $1 AND r.deleted_at IS NULL
+    AND ((r.status = 'published' AND r.visibility <> 'private') OR r.user_id = f.user_id)
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (f.created_at, f.recipe_id) < (sqlc.narg(cursor_favorited_at)::timestamp, sqlc.narg(cursor_id)::uuid))
+    ORDER BY f.created_at DESC, f.recipe_id DESC
+    LIMIT sqlc.arg(row_limit);
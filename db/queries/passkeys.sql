@@ -0,0 +1,33 @@
+-- name: CreatePasskey :exec
+INSERT INTO passkeys (
+    passkey_id,
+    user_id,
+    credential_id,
+    public_key,
+    sign_count,
+    aaguid,
+    transports,
+    name
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ListPasskeysByUserId :many
+SELECT passkey_id, name, transports, created_at, last_used_at FROM passkeys
+    WHERE user_id = $1
+    ORDER BY created_at;
+
+-- name: ListPasskeyCredentialIdsByUserId :many
+SELECT credential_id FROM passkeys
+    WHERE user_id = $1;
+
+-- name: GetPasskeyByCredentialId :one
+SELECT passkey_id, user_id, public_key, sign_count FROM passkeys
+    WHERE credential_id = $1 LIMIT 1;
+
+-- name: UsePasskey :execrows
+UPDATE passkeys
+    SET sign_count = $2, last_used_at = NOW()
+    WHERE passkey_id = $1 AND (sign_count < $2 OR $2 = 0);
+
+-- name: DeletePasskey :execrows
+DELETE FROM passkeys
+    WHERE passkey_id = $1 AND user_id = $2;
@@ -0,0 +1,17 @@
+-- name: CreateRecipeImage :exec
+INSERT INTO recipe_images (
+    image_id,
+    recipe_id,
+    object_key,
+    content_type,
+    size_bytes
+) VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetRecipeImagesByRecipeId :many
+SELECT * FROM recipe_images
+    WHERE recipe_id = $1
+    ORDER BY created_at, image_id;
+
+-- name: CountRecipeImages :one
+SELECT COUNT(*) FROM recipe_images
+    WHERE recipe_id = $1;
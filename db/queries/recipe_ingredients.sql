@@ -0,0 +1,23 @@
+-- name: CreateRecipeIngredient :exec
+INSERT INTO recipe_ingredients (
+    recipe_id,
+    position,
+    quantity,
+    unit,
+    name,
+    note
+) VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: CopyRecipeIngredients :exec
+INSERT INTO recipe_ingredients (recipe_id, position, quantity, unit, name, note)
+    SELECT sqlc.arg(to_recipe_id), position, quantity, unit, name, note FROM recipe_ingredients
+    WHERE recipe_id = sqlc.arg(from_recipe_id);
+
+-- name: GetRecipeIngredientsByRecipeId :many
+SELECT * FROM recipe_ingredients
+    WHERE recipe_id = $1
+    ORDER BY position;
+
+-- name: DeleteRecipeIngredientsByRecipeId :exec
+DELETE FROM recipe_ingredients
+    WHERE recipe_id = $1;
@@ -0,0 +1,33 @@
+-- name: LockRecipeForReview :one
+SELECT recipe_id FROM recipes
+    WHERE recipe_id = $1 AND deleted_at IS NULL
+    FOR UPDATE;
+
+-- name: GetRecipeReviewRating :one
+SELECT rating FROM recipe_reviews
+    WHERE recipe_id = $1 AND user_id = $2;
+
+-- name: UpsertRecipeReview :exec
+INSERT INTO recipe_reviews (
+    recipe_id,
+    user_id,
+    rating,
+    body
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (recipe_id, user_id) DO UPDATE
+    SET rating = EXCLUDED.rating,
+    body = EXCLUDED.body,
+    updated_at = NOW();
+
+-- name: UpdateRecipeRatingAggregate :exec
+UPDATE recipes
+    SET rating_sum = rating_sum + sqlc.arg(rating_sum_delta)::int,
+    rating_count = rating_count + sqlc.arg(rating_count_delta)::int
+    WHERE recipe_id = $1;
+
+-- name: ListRecipeReviews :many
+SELECT user_id, rating, body, created_at, updated_at FROM recipe_reviews
+    WHERE recipe_id = $1
+    AND (sqlc.narg(cursor_user_id)::uuid IS NULL OR (created_at, user_id) < (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_user_id)::uuid))
+    ORDER BY created_at DESC, user_id DESC
+    LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,45 @@
+-- name: CreateRecipeRevision :exec
+INSERT INTO recipe_revisions (
+    recipe_id,
+    revision_number,
+    title,
+    content,
+    servings,
+    ingredients,
+    tags,
+    steps,
+    visibility
+)
+SELECT r.recipe_id,
+    COALESCE((SELECT MAX(rr.revision_number) FROM recipe_revisions rr WHERE rr.recipe_id = r.recipe_id), 0) + 1,
+    r.title, r.content, r.servings,
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object('quantity', i.quantity, 'unit', i.unit, 'name', i.name, 'note', i.note) ORDER BY i.position)
+        FROM recipe_ingredients i
+        WHERE i.recipe_id = r.recipe_id
+    ), '[]'),
+    COALESCE((
+        SELECT array_agg(t.name ORDER BY t.name)
+        FROM recipe_tags rt
+        JOIN tags t ON t.tag_id = rt.tag_id
+        WHERE rt.recipe_id = r.recipe_id
+    ), '{}'),
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object('instruction', s.instruction, 'duration_minutes', s.duration_minutes, 'temperature', s.temperature, 'temperature_unit', COALESCE(s.temperature_unit, '')) ORDER BY s.position)
+        FROM recipe_steps s
+        WHERE s.recipe_id = r.recipe_id
+    ), '[]'),
+    r.visibility
+FROM recipes r
+WHERE r.recipe_id = $1;
+
+-- name: GetRecipeRevision :one
+SELECT * FROM recipe_revisions
+    WHERE recipe_id = $1 AND revision_number = $2;
+
+-- name: ListRecipeRevisions :many
+SELECT revision_number, title, created_at FROM recipe_revisions
+    WHERE recipe_id = $1
+    AND (sqlc.narg(cursor_revision_number)::int IS NULL OR revision_number < sqlc.narg(cursor_revision_number)::int)
+    ORDER BY revision_number DESC
+    LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,26 @@
+-- name: CreateRecipeShareLink :exec
+INSERT INTO recipe_share_links (
+    share_link_id,
+    recipe_id,
+    token_hash,
+    expires_at
+) VALUES ($1, $2, $3, $4);
+
+-- name: ListRecipeShareLinks :many
+SELECT * FROM recipe_share_links
+    WHERE recipe_id = $1
+    ORDER BY created_at DESC;
+
+-- name: RevokeRecipeShareLink :execrows
+UPDATE recipe_share_links
+    SET revoked_at = COALESCE(revoked_at, NOW())
+    WHERE share_link_id = $1 AND recipe_id = $2;
+
+-- name: UseRecipeShareLink :one
+UPDATE recipe_share_links l
+    SET view_count = l.view_count + 1, last_accessed_at = NOW()
+    FROM recipes r
+    WHERE l.token_hash = $1
+    AND l.revoked_at IS NULL AND (l.expires_at IS NULL OR l.expires_at > NOW())
+    AND r.recipe_id = l.recipe_id AND r.deleted_at IS NULL
+RETURNING l.recipe_id;
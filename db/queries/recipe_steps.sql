@@ -0,0 +1,43 @@
+-- name: CreateRecipeStep :exec
+INSERT INTO recipe_steps (
+    step_id,
+    recipe_id,
+    position,
+    instruction,
+    duration_minutes,
+    temperature,
+    temperature_unit
+) VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetRecipeStepsByRecipeId :many
+SELECT * FROM recipe_steps
+    WHERE recipe_id = $1
+    ORDER BY position;
+
+-- name: CountRecipeSteps :one
+SELECT count(*) FROM recipe_steps
+    WHERE recipe_id = $1;
+
+-- name: ShiftRecipeStepsDown :exec
+UPDATE recipe_steps
+    SET position = position + 1
+    WHERE recipe_id = $1 AND position >= $2;
+
+-- name: ShiftRecipeStepsUp :exec
+UPDATE recipe_steps
+    SET position = position - 1
+    WHERE recipe_id = $1 AND position > $2;
+
+-- name: UpdateRecipeStepPosition :exec
+UPDATE recipe_steps
+    SET position = $3
+    WHERE step_id = $1 AND recipe_id = $2;
+
+-- name: DeleteRecipeStep :one
+DELETE FROM recipe_steps
+    WHERE step_id = $1 AND recipe_id = $2
+RETURNING position;
+
+-- name: DeleteRecipeStepsByRecipeId :exec
+DELETE FROM recipe_steps
+    WHERE recipe_id = $1;
@@ -2,21 +2,180 @@
 INSERT INTO recipes (
     recipe_id, 
     title, 
-    content
-) VALUES ($1, $2, $3)
+    content,
+    user_id,
+    servings,
+    visibility
+) VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING recipe_id;
 
 -- name: GetRecipeById :one
-SELECT * FROM recipes
-    WHERE recipe_id = $1 LIMIT 1;
+SELECT recipe_id, user_id, title, content, servings, status, publish_at, visibility, rating_sum, rating_count, favorite_count,
+    forked_from, forked_from_user_id, forked_from_title,
+    (SELECT COUNT(*) FROM recipes f WHERE f.forked_from = recipes.recipe_id AND f.deleted_at IS NULL)::int AS fork_count,
+    created_at, updated_at FROM recipes
+    WHERE recipe_id = $1 AND deleted_at IS NULL LIMIT 1;
 
--- name: UpdateRecipeById :exec
+-- name: ForkRecipe :one
+INSERT INTO recipes (
+    recipe_id,
+    title,
+    content,
+    user_id,
+    servings,
+    visibility,
+    forked_from,
+    forked_from_user_id,
+    forked_from_title
+)
+SELECT sqlc.arg(fork_id), title, content, sqlc.arg(user_id), servings, visibility, recipe_id, user_id, title FROM recipes
+    WHERE recipe_id = sqlc.arg(recipe_id) AND deleted_at IS NULL
+RETURNING recipe_id;
+
+-- name: ListRecipeForks :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at FROM recipes
+    WHERE forked_from = $1 AND deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (created_at, recipe_id) < (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_id)::uuid))
+    ORDER BY created_at DESC, recipe_id DESC
+    LIMIT sqlc.arg(row_limit);
+
+-- name: UpdateRecipeById :execrows
+UPDATE recipes
+    SET title = $3, content = $4, servings = $5, visibility = sqlc.arg(visibility), updated_at = sqlc.arg(new_updated_at)
+    WHERE recipe_id = $1 AND updated_at = $2 AND deleted_at IS NULL;
+
+-- name: TouchRecipe :execrows
+UPDATE recipes
+    SET updated_at = sqlc.arg(new_updated_at)
+    WHERE recipe_id = $1 AND updated_at = $2 AND deleted_at IS NULL;
+
+-- name: SoftDeleteRecipeById :execrows
+UPDATE recipes
+    SET deleted_at = NOW()
+    WHERE recipe_id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreRecipeById :execrows
+UPDATE recipes
+    SET deleted_at = NULL
+    WHERE recipe_id = $1 AND user_id = $2 AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedRecipes :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at, deleted_at FROM recipes
+    WHERE user_id = $1 AND deleted_at IS NOT NULL
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (deleted_at, recipe_id) < (sqlc.narg(cursor_deleted_at)::timestamp, sqlc.narg(cursor_id)::uuid))
+    ORDER BY deleted_at DESC, recipe_id DESC
+    LIMIT sqlc.arg(row_limit);
+
+-- name: ListPurgeableRecipes :many
+SELECT recipe_id FROM recipes
+    WHERE deleted_at < sqlc.arg(deleted_before)::timestamp
+    ORDER BY deleted_at
+    LIMIT sqlc.arg(row_limit)
+    FOR UPDATE SKIP LOCKED;
+
+-- name: PublishRecipe :execrows
+UPDATE recipes
+    SET status = sqlc.arg(status), publish_at = sqlc.arg(publish_at)
+    WHERE recipe_id = $1 AND deleted_at IS NULL AND status <> 'published';
+
+-- name: PublishScheduledRecipes :execrows
 UPDATE recipes
-    SET title = $3, content = $4, updated_at = sqlc.arg(new_updated_at)
-    WHERE recipe_id = $1 AND updated_at = $2;
+    SET status = 'published'
+    WHERE status = 'scheduled' AND publish_at <= NOW();
 
+-- name: ListRecipesByUserId :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at, status, publish_at, visibility FROM recipes
+    WHERE user_id = $1 AND deleted_at IS NULL
+    AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (updated_at, recipe_id) < (sqlc.narg(cursor_updated_at)::timestamp, sqlc.narg(cursor_id)::uuid))
+    ORDER BY updated_at DESC, recipe_id DESC
+    LIMIT sqlc.arg(row_limit);
 
 -- name: DeleteRecipeById :exec
 DELETE FROM recipes 
     WHERE recipe_id = $1;
 
+
+
+-- name: ListRecipesByCreatedAt :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at FROM recipes
+    WHERE deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+    AND (sqlc.narg(author_id)::uuid IS NULL OR user_id = sqlc.narg(author_id)::uuid)
+    AND (sqlc.narg(created_after)::timestamp IS NULL OR created_at >= sqlc.narg(created_after)::timestamp)
+    AND (sqlc.narg(created_before)::timestamp IS NULL OR created_at < sqlc.narg(created_before)::timestamp)
+    AND (COALESCE(cardinality(sqlc.arg(tags)::text[]), 0) = 0 OR (
+        SELECT COUNT(*) FROM recipe_tags rt
+            JOIN tags t ON t.tag_id = rt.tag_id
+            WHERE rt.recipe_id = recipes.recipe_id AND t.name = ANY(sqlc.arg(tags)::text[])
+    ) >= CASE WHEN sqlc.arg(match_all_tags)::boolean THEN cardinality(sqlc.arg(tags)::text[]) ELSE 1 END)
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (created_at, recipe_id) < (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_id)::uuid))
+    ORDER BY created_at DESC, recipe_id DESC
+    LIMIT sqlc.arg(row_limit);
+
+-- name: ListRecipesByUpdatedAt :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at FROM recipes
+    WHERE deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+    AND (sqlc.narg(author_id)::uuid IS NULL OR user_id = sqlc.narg(author_id)::uuid)
+    AND (sqlc.narg(created_after)::timestamp IS NULL OR created_at >= sqlc.narg(created_after)::timestamp)
+    AND (sqlc.narg(created_before)::timestamp IS NULL OR created_at < sqlc.narg(created_before)::timestamp)
+    AND (COALESCE(cardinality(sqlc.arg(tags)::text[]), 0) = 0 OR (
+        SELECT COUNT(*) FROM recipe_tags rt
+            JOIN tags t ON t.tag_id = rt.tag_id
+            WHERE rt.recipe_id = recipes.recipe_id AND t.name = ANY(sqlc.arg(tags)::text[])
+    ) >= CASE WHEN sqlc.arg(match_all_tags)::boolean THEN cardinality(sqlc.arg(tags)::text[]) ELSE 1 END)
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (updated_at, recipe_id) < (sqlc.narg(cursor_updated_at)::timestamp, sqlc.narg(cursor_id)::uuid))
+    ORDER BY updated_at DESC, recipe_id DESC
+    LIMIT sqlc.arg(row_limit);
+
+-- name: ListRecipesByTitle :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at FROM recipes
+    WHERE deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+    AND (sqlc.narg(author_id)::uuid IS NULL OR user_id = sqlc.narg(author_id)::uuid)
+    AND (sqlc.narg(created_after)::timestamp IS NULL OR created_at >= sqlc.narg(created_after)::timestamp)
+    AND (sqlc.narg(created_before)::timestamp IS NULL OR created_at < sqlc.narg(created_before)::timestamp)
+    AND (COALESCE(cardinality(sqlc.arg(tags)::text[]), 0) = 0 OR (
+        SELECT COUNT(*) FROM recipe_tags rt
+            JOIN tags t ON t.tag_id = rt.tag_id
+            WHERE rt.recipe_id = recipes.recipe_id AND t.name = ANY(sqlc.arg(tags)::text[])
+    ) >= CASE WHEN sqlc.arg(match_all_tags)::boolean THEN cardinality(sqlc.arg(tags)::text[]) ELSE 1 END)
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (title, recipe_id) > (sqlc.narg(cursor_title)::text, sqlc.narg(cursor_id)::uuid))
+    ORDER BY title ASC, recipe_id ASC
+    LIMIT sqlc.arg(row_limit);
+
+
+-- name: ListRecipesByFavoriteCount :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at FROM recipes
+    WHERE deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+    AND (sqlc.narg(author_id)::uuid IS NULL OR user_id = sqlc.narg(author_id)::uuid)
+    AND (sqlc.narg(created_after)::timestamp IS NULL OR created_at >= sqlc.narg(created_after)::timestamp)
+    AND (sqlc.narg(created_before)::timestamp IS NULL OR created_at < sqlc.narg(created_before)::timestamp)
+    AND (COALESCE(cardinality(sqlc.arg(tags)::text[]), 0) = 0 OR (
+        SELECT COUNT(*) FROM recipe_tags rt
+            JOIN tags t ON t.tag_id = rt.tag_id
+            WHERE rt.recipe_id = recipes.recipe_id AND t.name = ANY(sqlc.arg(tags)::text[])
+    ) >= CASE WHEN sqlc.arg(match_all_tags)::boolean THEN cardinality(sqlc.arg(tags)::text[]) ELSE 1 END)
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR (favorite_count, recipe_id) < (sqlc.narg(cursor_favorite_count)::int, sqlc.narg(cursor_id)::uuid))
+    ORDER BY favorite_count DESC, recipe_id DESC
+    LIMIT sqlc.arg(row_limit);
+
+
+-- name: SearchRecipes :many
+WITH matched_recipes AS (
+    SELECT recipe_id, user_id, title, content, created_at, updated_at,
+        ts_rank(search_vector, websearch_to_tsquery('english', sqlc.arg(query)::text))::float8 AS rank
+    FROM recipes
+    WHERE search_vector @@ websearch_to_tsquery('english', sqlc.arg(query)::text)
+    AND deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+), recipes_page AS (
+    SELECT recipe_id, user_id, title, content, created_at, updated_at, rank FROM matched_recipes
+    WHERE (sqlc.narg(cursor_id)::uuid IS NULL OR (rank, recipe_id) < (sqlc.narg(cursor_rank)::float8, sqlc.narg(cursor_id)::uuid))
+    ORDER BY rank DESC, recipe_id DESC
+    LIMIT sqlc.arg(row_limit)
+)
+SELECT recipe_id, user_id, title, created_at, updated_at, rank,
+    ts_headline('english', title, websearch_to_tsquery('english', sqlc.arg(query)::text),
+        sqlc.arg(title_headline_options)::text)::text AS title_headline,
+    ts_headline('english', content, websearch_to_tsquery('english', sqlc.arg(query)::text),
+        sqlc.arg(content_headline_options)::text)::text AS content_headline
+FROM recipes_page
+ORDER BY rank DESC, recipe_id DESC;
@@ -0,0 +1,33 @@
+-- name: ListTags :many
+SELECT t.name, t.category, COUNT(r.recipe_id)::int AS recipe_count FROM tags t
+    LEFT JOIN recipe_tags rt ON rt.tag_id = t.tag_id
+    LEFT JOIN recipes r ON r.recipe_id = rt.recipe_id
+        AND r.deleted_at IS NULL AND r.status = 'published' AND r.visibility = 'public'
+    GROUP BY t.tag_id
+    ORDER BY t.category, t.name;
+
+-- name: CreateTags :exec
+INSERT INTO tags (name)
+    SELECT DISTINCT btrim(lower(regexp_replace(tag_name, '\s+', ' ', 'g'))) FROM unnest(sqlc.arg(names)::text[]) AS tag_name
+    WHERE btrim(lower(regexp_replace(tag_name, '\s+', ' ', 'g'))) <> ''
+    ON CONFLICT (name) DO NOTHING;
+
+-- name: CreateRecipeTags :exec
+INSERT INTO recipe_tags (recipe_id, tag_id)
+    SELECT $1, tag_id FROM tags
+    WHERE name IN (SELECT btrim(lower(regexp_replace(tag_name, '\s+', ' ', 'g'))) FROM unnest(sqlc.arg(names)::text[]) AS tag_name);
+
+-- name: CopyRecipeTags :exec
+INSERT INTO recipe_tags (recipe_id, tag_id)
+    SELECT sqlc.arg(to_recipe_id), tag_id FROM recipe_tags
+    WHERE recipe_id = sqlc.arg(from_recipe_id);
+
+-- name: GetTagNamesByRecipeId :many
+SELECT t.name FROM tags t
+    JOIN recipe_tags rt ON rt.tag_id = t.tag_id
+    WHERE rt.recipe_id = $1
+    ORDER BY t.name;
+
+-- name: DeleteRecipeTagsByRecipeId :exec
+DELETE FROM recipe_tags
+    WHERE recipe_id = $1;
@@ -0,0 +1,12 @@
+-- name: CreateTwoFactorRecoveryCodes :exec
+INSERT INTO two_factor_recovery_codes (user_id, code_hash)
+    SELECT $1, unnest(sqlc.arg(code_hashes)::bytea[]);
+
+-- name: UseTwoFactorRecoveryCode :execrows
+UPDATE two_factor_recovery_codes
+    SET used_at = NOW()
+    WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: DeleteTwoFactorRecoveryCodes :exec
+DELETE FROM two_factor_recovery_codes
+    WHERE user_id = $1;
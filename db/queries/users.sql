@@ -6,5 +6,58 @@ INSERT INTO users (
 ) VALUES ($1, $2, $3);
 
 -- name: GetUserByEmail :one
-SELECT email, password FROM users 
-    WHERE email = $1 LIMIT 1;
\ No newline at end of file
+SELECT user_id, email, password, measurement_system, email_verified_at, totp_enabled_at, session_version FROM users 
+    WHERE email = $1 LIMIT 1;
+
+-- name: GetUserById :one
+SELECT user_id, email, measurement_system, email_verified_at, totp_enabled_at, session_version, created_at FROM users
+    WHERE user_id = $1 LIMIT 1;
+
+-- name: UpdateUserMeasurementSystem :execrows
+UPDATE users
+    SET measurement_system = $2
+    WHERE user_id = $1;
+
+-- name: UpdateUserPassword :one
+UPDATE users
+    SET password = $2, session_version = session_version + 1
+    WHERE user_id = $1
+RETURNING session_version;
+
+-- name: GetUserSessionVersion :one
+SELECT session_version FROM users
+    WHERE user_id = $1 LIMIT 1;
+
+-- name: VerifyUserEmail :execrows
+UPDATE users
+    SET email_verified_at = COALESCE(email_verified_at, NOW())
+    WHERE user_id = $1;
+
+-- name: GetUserTotp :one
+SELECT totp_secret, totp_enabled_at FROM users
+    WHERE user_id = $1 LIMIT 1;
+
+-- name: SetUserTotpSecret :execrows
+UPDATE users
+    SET totp_secret = $2
+    WHERE user_id = $1 AND totp_enabled_at IS NULL;
+
+-- name: EnableUserTotp :execrows
+UPDATE users
+    SET totp_enabled_at = NOW(), totp_last_counter = $2
+    WHERE user_id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL;
+
+-- name: UseUserTotpCounter :execrows
+UPDATE users
+    SET totp_last_counter = $2
+    WHERE user_id = $1 AND totp_enabled_at IS NOT NULL AND (totp_last_counter IS NULL OR totp_last_counter < $2);
+
+-- name: DisableUserTotp :exec
+UPDATE users
+    SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_counter = NULL
+    WHERE user_id = $1;
+
+-- name: LockUser :one
+SELECT user_id FROM users
+    WHERE user_id = $1
+    FOR UPDATE;
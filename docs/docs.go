@@ -15,9 +15,158 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/auth/passkeys/signin/begin": {
+            "post": {
+                "description": "Get the options of navigator.credentials.get, which the browser reads with PublicKeyCredential.parseRequestOptionsFromJSON.\nThe user picks one of their passkeys, so no email is needed. The challenge expires after five minutes and can be used once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Begin signing in with a passkey",
+                "responses": {
+                    "200": {
+                        "description": "Options created successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-webauthn_RequestOptions"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/passkeys/signin/finish": {
+            "post": {
+                "description": "Sign in with a passkey which signed the challenge from /api/v1/auth/passkeys/signin/begin.\nPasskeys verify the user on the authenticator, so users with two-factor authentication do not need a code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Finish signing in with a passkey",
+                "parameters": [
+                    {
+                        "description": "Request body with the passkey response serialized by PublicKeyCredential.toJSON.",
+                        "name": "AssertionCredential",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webauthn.AssertionCredential"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sign in successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Passkey is not valid or the challenge has expired.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/password/forgot": {
+            "post": {
+                "description": "Email a link to reset the password to the owner of the email. The link is valid for a limited time and can be used once.\nThe response is the same whether the email has an account or not.\nOnly a few links can be requested for an email or from an IP address within a while.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Forgot password",
+                "parameters": [
+                    {
+                        "description": "Request body with the email of an account.",
+                        "name": "ForgotPasswordRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Password reset requested successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many password resets, retry after the number of seconds in the Retry-After header.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/password/reset": {
+            "post": {
+                "description": "Set a new password with the token from the password reset email. Every session of the user is signed out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Request body with the token and the new password.",
+                        "name": "ResetPasswordRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Password reset successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided, or the token is invalid, has expired or has already been used.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/auth/signin": {
             "post": {
-                "description": "Sign in to the app by providing an email and password.",
+                "description": "Sign in to the app by providing an email and password.\nUsers with two-factor authentication get a token instead of a session, which has to be sent with a code to /api/v1/auth/signin/2fa.\nFailed sign-ins of an account or an IP address make the next ones wait longer, until they are locked out for a while.",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,6 +188,64 @@ const docTemplate = `{
                         }
                     }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "Sign in successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Password is correct, the code of the second factor is required.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-auth_TwoFactorChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Email or password is invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed sign-ins, retry after the number of seconds in the Retry-After header.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/signin/2fa": {
+            "post": {
+                "description": "Finish the sign-in of a user with two-factor authentication with the token from /api/v1/auth/signin\nand a code from an authenticator app or a recovery code. A sign-in is cancelled after a few wrong codes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Sign in with the second factor",
+                "parameters": [
+                    {
+                        "description": "Request body with the token of the sign-in and a code.",
+                        "name": "SignInTwoFactorRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.SignInTwoFactorRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Sign in successfully.",
@@ -51,6 +258,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/validator.ValidationErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Code is invalid or the sign-in has expired.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed sign-ins, retry after the number of seconds in the Retry-After header.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
                     }
                 }
             }
@@ -112,23 +331,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/health": {
-            "get": {
-                "description": "Check the status of the recipe API.",
-                "tags": [
-                    "health"
-                ],
-                "summary": "Check health",
-                "responses": {
-                    "200": {
-                        "description": "The API is healthy."
-                    }
-                }
-            }
-        },
-        "/api/v1/recipes": {
+        "/api/v1/auth/verify-email": {
             "post": {
-                "description": "Insert a new recipe by providing a request body with title and content for the recipe you want to save.",
+                "description": "Verify the email of an account with the token from the verification email. Users with unverified emails cannot publish recipes or comment.",
                 "consumes": [
                     "application/json"
                 ],
@@ -136,66 +341,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "auth"
                 ],
-                "summary": "Create a new recipe",
+                "summary": "Verify email",
                 "parameters": [
                     {
-                        "description": "Request body with title and content.",
-                        "name": "NewRecipeRequest",
+                        "description": "Request body with the token from the verification email.",
+                        "name": "VerifyEmailRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/recipe.NewRecipeRequest"
+                            "$ref": "#/definitions/auth.VerifyEmailRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Recipe saved successfully.",
-                        "schema": {
-                            "$ref": "#/definitions/shared.CommonResponse"
-                        }
+                    "204": {
+                        "description": "Email verified successfully."
                     },
                     "400": {
-                        "description": "Invalid data provided.",
+                        "description": "Invalid data provided, or the token is invalid, has expired or has already been used.",
                         "schema": {
                             "$ref": "#/definitions/validator.ValidationErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Recipe not found.",
-                        "schema": {
-                            "$ref": "#/definitions/shared.CommonResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/v1/recipes/{id}": {
+        "/api/v1/cookbooks": {
             "get": {
-                "description": "Get a recipe by ID.",
+                "description": "Get a page of cookbooks of a user, starting from the newest ones. Lists the cookbooks of the signed-in user when author_id is omitted.\nPrivate cookbooks are listed only to their owner.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "cookbooks"
                 ],
-                "summary": "Get a recipe",
+                "summary": "List cookbooks",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID for a recipe",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "UUID of the owner of cookbooks.",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of cookbooks on a page.",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Recipe fetched successfully.",
+                        "description": "Cookbooks fetched successfully.",
                         "schema": {
-                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_RecipeResponse"
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-cookbook_CookbookSummaryResponse"
                         }
                     },
                     "400": {
@@ -204,16 +414,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/validator.ValidationErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Recipe is not found.",
+                    "401": {
+                        "description": "User is not signed in and author_id is omitted.",
                         "schema": {
                             "$ref": "#/definitions/shared.CommonResponse"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Update title or content of a recipe by UUID.",
+            "post": {
+                "description": "Create a named collection of recipes. Cookbooks are private unless is_public is set.",
                 "consumes": [
                     "application/json"
                 ],
@@ -221,30 +431,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "cookbooks"
                 ],
-                "summary": "Update a recipe",
+                "summary": "Create a cookbook",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "UUID of a recipe.",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Request body with title and content for updating a recipe.",
-                        "name": "UpdateRecipeRequest",
+                        "description": "Request body with a name, description and visibility.",
+                        "name": "CookbookRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/recipe.UpdateRecipeRequest"
+                            "$ref": "#/definitions/cookbook.CookbookRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Recipe  \tupdated successfully."
+                    "201": {
+                        "description": "Cookbook saved successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
                     },
                     "400": {
                         "description": "Invalid data provided.",
@@ -252,162 +458,4517 @@ const docTemplate = `{
                             "$ref": "#/definitions/validator.ValidationErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Database conflict occurred when trying to saving a recipe.",
+                    "401": {
+                        "description": "User is not signed in.",
                         "schema": {
                             "$ref": "#/definitions/shared.CommonResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a recipe by ID.",
+            }
+        },
+        "/api/v1/cookbooks/{id}": {
+            "get": {
+                "description": "Get a cookbook with its recipes in order. Private cookbooks are visible only to their owner.\nPrivate recipes and recipes which are not published yet are listed only to their author.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "cookbooks"
                 ],
-                "summary": "Delete a recipe",
+                "summary": "Get a cookbook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID for a recipe",
+                        "description": "UUID of a cookbook.",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Recipe deleted successfully."
+                    "200": {
+                        "description": "Cookbook fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-cookbook_CookbookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cookbook not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the name, description and visibility of a cookbook.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cookbooks"
+                ],
+                "summary": "Update a cookbook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a cookbook.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body with a name, description and visibility.",
+                        "name": "CookbookRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cookbook.CookbookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Cookbook updated successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the cookbook.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cookbook not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a cookbook. The recipes in the cookbook are not deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cookbooks"
+                ],
+                "summary": "Delete a cookbook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a cookbook.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Cookbook deleted successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the cookbook.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cookbook not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cookbooks/{id}/recipes": {
+            "post": {
+                "description": "Insert a recipe into a cookbook at the given position, moving the following recipes down. The recipe is appended to the end when the position is omitted.\nOnly published recipes which are not private can be added, unless the recipe belongs to the owner of the cookbook.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cookbooks"
+                ],
+                "summary": "Add a recipe to a cookbook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a cookbook.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body with a recipe ID and an optional position.",
+                        "name": "AddCookbookRecipeRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cookbook.AddCookbookRecipeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Recipe added successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
                     },
                     "400": {
                         "description": "Invalid data provided.",
                         "schema": {
                             "$ref": "#/definitions/validator.ValidationErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the cookbook.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cookbook or recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Recipe is already in the cookbook or the cookbook is full.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cookbooks/{id}/recipes/{recipeId}": {
+            "delete": {
+                "description": "Remove a recipe from a cookbook, moving the following recipes up. The recipe itself is not deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cookbooks"
+                ],
+                "summary": "Remove a recipe from a cookbook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a cookbook.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "recipeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Recipe removed successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the cookbook.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cookbook or recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/health": {
+            "get": {
+                "description": "Check the status of the recipe API.",
+                "tags": [
+                    "health"
+                ],
+                "summary": "Check health",
+                "responses": {
+                    "200": {
+                        "description": "The API is healthy."
+                    }
+                }
+            }
+        },
+        "/api/v1/me": {
+            "get": {
+                "description": "Get the profile and settings of the signed-in user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Get the profile",
+                "responses": {
+                    "200": {
+                        "description": "Profile fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-user_ProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the settings of the signed-in user. The preferred measurement system converts quantities and temperatures of recipes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Update the profile",
+                "parameters": [
+                    {
+                        "description": "Request body with the settings of the user.",
+                        "name": "UpdateProfileRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Profile updated successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "auth.SignInRequest": {
+        },
+        "/api/v1/me/2fa/confirm": {
+            "post": {
+                "description": "Turn on two-factor authentication with a code from the authenticator app. The response holds recovery codes,\nwhich are shown only once. Each of them can be used once to sign in without the app.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Confirm two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Request body with a code from the authenticator app.",
+                        "name": "TwoFactorCodeRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Two-factor authentication enabled successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-user_RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided, or the code is invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication is already enabled or has not been enrolled.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/2fa/disable": {
+            "post": {
+                "description": "Turn off two-factor authentication with a code from the authenticator app or a recovery code. The secret and the recovery codes are deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Disable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Request body with a code from the authenticator app or a recovery code.",
+                        "name": "TwoFactorCodeRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Two-factor authentication disabled successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided, or the code is invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication is not enabled.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many invalid codes, the Retry-After header tells how long to wait.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/2fa/enroll": {
+            "post": {
+                "description": "Generate a TOTP secret for an authenticator app. Two-factor authentication is turned on once a code from the app is confirmed.\nEnrolling again before confirming replaces the secret.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Enroll in two-factor authentication",
+                "responses": {
+                    "200": {
+                        "description": "Secret generated successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-user_TwoFactorEnrollmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication is already enabled.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/favorites": {
+            "get": {
+                "description": "Get a page of recipes saved to the favorites of the signed-in user, starting from the most recently saved ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "List favorite recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of recipes on a page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Favorite recipes fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_FavoriteRecipeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/favorites/{recipeId}": {
+            "put": {
+                "description": "Save a recipe to the favorites of the signed-in user. Adding a favorite recipe again does nothing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Add a recipe to favorites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "recipeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Recipe added to favorites successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a recipe from the favorites of the signed-in user. Removing a recipe which is not a favorite one does nothing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Remove a recipe from favorites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "recipeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Recipe removed from favorites successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/passkeys": {
+            "get": {
+                "description": "Get the passkeys of the signed-in user, starting from the oldest ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "List passkeys",
+                "responses": {
+                    "200": {
+                        "description": "Passkeys fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-array_user_PasskeyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/passkeys/register/begin": {
+            "post": {
+                "description": "Get the options of navigator.credentials.create, which the browser reads with PublicKeyCredential.parseCreationOptionsFromJSON.\nThe challenge of the options expires after five minutes and can be used once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Begin registering a passkey",
+                "responses": {
+                    "200": {
+                        "description": "Options created successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-webauthn_CreationOptions"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/passkeys/register/finish": {
+            "post": {
+                "description": "Save a passkey created with the options from /api/v1/me/passkeys/register/begin. The passkey can then be used to sign in without a password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Finish registering a passkey",
+                "parameters": [
+                    {
+                        "description": "Request body with a name and the passkey serialized by PublicKeyCredential.toJSON.",
+                        "name": "RegisterPasskeyRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.RegisterPasskeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Passkey registered successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-user_PasskeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided, the passkey is not valid or the challenge has expired.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Passkey is already registered.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/passkeys/{passkeyId}": {
+            "delete": {
+                "description": "Remove a passkey of the signed-in user, which can no longer be used to sign in.\nThe passkey stays on the authenticator, so the user should delete it there as well.",
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Remove a passkey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a passkey.",
+                        "name": "passkeyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Passkey removed successfully."
+                    },
+                    "400": {
+                        "description": "Invalid passkey ID.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Passkey not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/recipes": {
+            "get": {
+                "description": "Get a page of recipes of the signed-in user in every status, starting from the most recently updated ones.\nPass status to list only drafts, scheduled or published recipes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "List recipes of the signed-in user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of recipes on a page.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "scheduled",
+                            "published"
+                        ],
+                        "type": "string",
+                        "description": "Status of the listed recipes.",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recipes fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_OwnRecipeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/trash": {
+            "get": {
+                "description": "Get a page of recipes in the trash of the signed-in user, starting from the most recently deleted ones.\nEvery recipe can be restored until its purge time.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "List deleted recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of recipes on a page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted recipes fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_TrashedRecipeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/verify-email/resend": {
+            "post": {
+                "description": "Email a new link to verify the email of the signed-in user. Another email can be requested only after a while.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Resend the verification email",
+                "responses": {
+                    "202": {
+                        "description": "Verification email sent successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email is already verified.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Verification email has been sent recently.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes": {
+            "get": {
+                "description": "Get a page of public recipes. Use the next_cursor value from the response to fetch the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "List recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of recipes on a page.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at",
+                            "title",
+                            "favorite_count"
+                        ],
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort order of recipes.",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe author.",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only recipes created at or after this RFC 3339 time.",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only recipes created before this RFC 3339 time.",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only recipes with these tags.",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether recipes need any or all of the tags.",
+                        "name": "tag_match",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recipes fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_RecipeSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Insert a new recipe by providing a request body with title, content and an ordered list of ingredients for the recipe you want to save.\nA new recipe is saved as a draft, visible only to its owner until it is published.\nA private recipe stays visible only to its owner, an unlisted one can be read by anyone with its ID, and only a public one is listed and searched.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Create a new recipe",
+                "parameters": [
+                    {
+                        "description": "Request body with title and content.",
+                        "name": "NewRecipeRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/recipe.NewRecipeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Recipe saved successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/compare": {
+            "get": {
+                "description": "Compare two recipes side by side: word-level diffs of titles and contents, ingredients matched by name with their quantities and a similarity score.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Compare recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the first recipe.",
+                        "name": "a",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the second recipe.",
+                        "name": "b",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recipes compared successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_RecipeComparisonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe is not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/search": {
+            "get": {
+                "description": "Full-text search over titles and contents of public recipes, ordered by relevance. The text of recipes is HTML-escaped and matched words are wrapped in \u003cmark\u003e tags.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Search recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query. Supports quoted phrases, OR and -exclusions.",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of recipes on a page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recipes found successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_RecipeSearchResultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}": {
+            "get": {
+                "description": "Get a recipe by ID. Pass servings to get the quantities of ingredients scaled to a different number of servings.\nQuantities and oven temperatures are converted to the measurement system preferred by the signed-in user.\nThe is_favorite flag tells whether the signed-in user has saved the recipe to their favorites.\nDrafts, scheduled and private recipes are visible only to their owner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID for a recipe",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Number of servings to scale the recipe to.",
+                        "name": "servings",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recipe fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_RecipeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe is not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update title, content, servings, ingredients, tags or visibility of a recipe by UUID. Omitted fields keep their current values.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Update a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body with title and content for updating a recipe.",
+                        "name": "UpdateRecipeRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/recipe.UpdateRecipeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Recipe  \tupdated successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Database conflict occurred when trying to saving a recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move a recipe to the trash of its owner. A deleted recipe is hidden from everyone and can be restored until it is purged after the retention period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Delete a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID for a recipe",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Recipe deleted successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/comments": {
+            "get": {
+                "description": "Get a page of top-level comments on a recipe, starting from the newest ones. Deleted comments with replies are listed as \"comment removed\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List comments on a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of comments on a page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comments fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Post a comment on a recipe, or a reply to a top-level comment when parent_id is set. Replies cannot be replied to.\nUsers mentioned with @email are saved to notify them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment on a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body with the text of a comment.",
+                        "name": "CommentRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/recipe.CommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Comment saved successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User has not verified the email.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe or parent comment not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/comments/{commentId}": {
+            "put": {
+                "description": "Replace the text of a comment. Only the author can edit a comment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Edit a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a comment.",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body with the new text of a comment.",
+                        "name": "UpdateCommentRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/recipe.UpdateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Comment updated successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the author of the comment or has not verified the email.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a comment. Replies to a deleted comment stay visible under a \"comment removed\" placeholder. Only the author can delete a comment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a comment.",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Comment deleted successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the author of the comment.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/comments/{commentId}/replies": {
+            "get": {
+                "description": "Get a page of replies to a top-level comment, starting from the oldest ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List replies to a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a top-level comment.",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of replies on a page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Replies fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe or comment not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/fork": {
+            "post": {
+                "description": "Copy a recipe with its ingredients, steps and tags into the account of the signed-in user. Images are not copied.\nThe fork is saved as a draft and keeps a link to the original recipe, which stays visible even if the original is deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Fork a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Recipe forked successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/forks": {
+            "get": {
+                "description": "Get a page of published, public forks of a recipe, starting from the newest ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "List forks of a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of forks on a page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Forks fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_RecipeSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/images": {
+            "post": {
+                "description": "Attach a JPEG, PNG or WebP image of at most 5 MB to a recipe. The type of the image is detected from its content.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Upload an image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file.",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Image saved successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_ImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Recipe has reached the limit of images.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Image is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Image type is not supported.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/publish": {
+            "post": {
+                "description": "Make a draft visible to everyone. Pass publish_at to schedule the recipe to be published later instead.\nA scheduled recipe can be published again to change its publish time or to publish it right away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Publish a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional request body with a publish time.",
+                        "name": "PublishRecipeRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/recipe.PublishRecipeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recipe published or scheduled successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/recipe.PublishRecipeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe or has not verified the email.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Recipe is already published.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/restore": {
+            "post": {
+                "description": "Take a recipe out of the trash of the signed-in user, with its ingredients, images, reviews and places in cookbooks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a deleted recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Recipe restored successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found in the trash.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/reviews": {
+            "get": {
+                "description": "Get a page of reviews of a recipe, starting from the newest ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "List reviews of a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of reviews on a page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviews fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Rate a recipe with 1 to 5 stars and an optional text. A user has one review of a recipe, so posting again replaces the previous review.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Review a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body with a rating and an optional text.",
+                        "name": "ReviewRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/recipe.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review updated successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Review saved successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User has not verified the email or is the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/revisions": {
+            "get": {
+                "description": "Get a page of revisions of a recipe, starting from the newest ones. A revision is saved when a recipe is created and on every update.\nOnly the owner can see the history of a recipe.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "List revisions of a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor to the next page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of revisions on a page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revisions fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_RevisionSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/revisions/diff": {
+            "get": {
+                "description": "Diff the fields of two revisions of a recipe line by line. Ingredients and tags are compared one per line.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Compare two revisions of a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the older revision.",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the newer revision.",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revisions compared successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/recipe.RevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe or revision not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/revisions/{revisionNumber}": {
+            "get": {
+                "description": "Get the title, content, servings, ingredients and tags of a recipe as they were saved by a revision.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Get a revision of a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of a revision, starting from 1.",
+                        "name": "revisionNumber",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revision fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/recipe.RevisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe or revision not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/revisions/{revisionNumber}/revert": {
+            "post": {
+                "description": "Restore the title, content, servings, ingredients, steps and tags of a recipe from a revision.\nThe visibility is kept, so reverting cannot make a private recipe visible again. Steps are kept too for revisions saved before revisions kept steps.\nReverting is an update of its own, so it saves a new revision and keeps the history intact.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Revert a recipe to a revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of a revision, starting from 1.",
+                        "name": "revisionNumber",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Recipe reverted successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe or revision not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Recipe was modified at the same time.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/share-links": {
+            "get": {
+                "description": "Get every share link of a recipe, starting from the newest ones, with the number of views and the time of the last view.\nRevoked and expired links are listed too. The tokens are not stored, so they are not returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share links"
+                ],
+                "summary": "List share links of a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Share links fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-array_recipe_ShareLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a link which lets anyone read the recipe without an account, even a draft or a private recipe.\nThe token is returned only once, save it to share the link. Pass expires_at to create a link which stops working after that time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share links"
+                ],
+                "summary": "Create a share link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional request body with an expiration time.",
+                        "name": "ShareLinkRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/recipe.ShareLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Share link created successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_CreatedShareLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/share-links/{shareLinkId}": {
+            "delete": {
+                "description": "Stop a share link of a recipe from working. A revoked link stays on the list of links with its views.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share links"
+                ],
+                "summary": "Revoke a share link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a share link.",
+                        "name": "shareLinkId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Share link revoked successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe or share link not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/steps": {
+            "get": {
+                "description": "Get the ordered preparation steps of a recipe.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steps"
+                ],
+                "summary": "Get steps of a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Steps fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-array_recipe_StepResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Insert a step into a recipe at the given position and move the following steps down. The step is appended when the position is omitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steps"
+                ],
+                "summary": "Insert a step",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body with the step and its position.",
+                        "name": "InsertStepRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/recipe.InsertStepRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Step saved successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Database conflict occurred when trying to saving a recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/steps/order": {
+            "put": {
+                "description": "Set the order of steps in a recipe. The list must contain the ID of every step of the recipe exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steps"
+                ],
+                "summary": "Reorder steps",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body with step IDs in the new order.",
+                        "name": "ReorderStepsRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/recipe.ReorderStepsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Steps reordered successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/validator.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Database conflict occurred when trying to saving a recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recipes/{id}/steps/{stepId}": {
+            "delete": {
+                "description": "Delete a step from a recipe and move the following steps up.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steps"
+                ],
+                "summary": "Delete a step",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of a recipe.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a step.",
+                        "name": "stepId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Step deleted successfully."
+                    },
+                    "400": {
+                        "description": "Invalid data provided.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not signed in.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the owner of the recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe or step not found.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Database conflict occurred when trying to saving a recipe.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shared/{token}": {
+            "get": {
+                "description": "Get the recipe a share link points to. Share links work without an account and give access to drafts and private recipes too.\nEvery request is counted as a view of the link.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share links"
+                ],
+                "summary": "Get a shared recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token of a share link.",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recipe fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_RecipeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Share link is not valid, has expired or has been revoked.",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags": {
+            "get": {
+                "description": "Get all tags, grouped by category, with the number of recipes tagged with them.\nOnly recipes which are listed publicly are counted, so deleted, unpublished, unlisted and private recipes are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List tags",
+                "responses": {
+                    "200": {
+                        "description": "Tags fetched successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-array_recipe_TagResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auth.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@mail.com"
+                }
+            }
+        },
+        "auth.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "password_again",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 5,
+                    "example": "newsupersecretpassword"
+                },
+                "password_again": {
+                    "type": "string",
+                    "example": "newsupersecretpassword"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "q0bLZkF0cR0u6b2lZ0JxQm5yX1p3b0ZcY2lVd2tQeXM"
+                }
+            }
+        },
+        "auth.SignInRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@mail.com"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 5,
+                    "example": "supersecretpassword"
+                }
+            }
+        },
+        "auth.SignInTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "287082"
+                },
+                "token": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "C8M0RK2V7B1QJ5N4UD3LHT9E6GSAFPOI"
+                }
+            }
+        },
+        "auth.SignUpRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "password_again"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@mail.com"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 5,
+                    "example": "supersecretpassword"
+                },
+                "password_again": {
+                    "type": "string",
+                    "example": "supersecretpassword"
+                }
+            }
+        },
+        "auth.TwoFactorChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "ExpiresIn is the number of seconds the token is valid.",
+                    "type": "integer",
+                    "example": 300
+                },
+                "token": {
+                    "type": "string",
+                    "example": "C8M0RK2V7B1QJ5N4UD3LHT9E6GSAFPOI"
+                }
+            }
+        },
+        "auth.VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "q0bLZkF0cR0u6b2lZ0JxQm5yX1p3b0ZcY2lVd2tQeXM"
+                }
+            }
+        },
+        "cookbook.AddCookbookRecipeRequest": {
+            "type": "object",
+            "required": [
+                "recipe_id"
+            ],
+            "properties": {
+                "position": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "recipe_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "cookbook.CookbookRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recipe_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "cookbook.CookbookRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Quick meals for busy evenings"
+                },
+                "is_public": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Weeknight dinners"
+                }
+            }
+        },
+        "cookbook.CookbookResponse": {
+            "type": "object",
+            "properties": {
+                "cookbook_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Quick meals for busy evenings"
+                },
+                "is_public": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weeknight dinners"
+                },
+                "recipes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cookbook.CookbookRecipeResponse"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "cookbook.CookbookSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "cookbook_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Quick meals for busy evenings"
+                },
+                "is_public": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weeknight dinners"
+                },
+                "recipe_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-array_recipe_ShareLinkResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.ShareLinkResponse"
+                    }
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-array_recipe_StepResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.StepResponse"
+                    }
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-array_recipe_TagResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.TagResponse"
+                    }
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-array_user_PasskeyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user.PasskeyResponse"
+                    }
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-auth_TwoFactorChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/auth.TwoFactorChallengeResponse"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-cookbook_CookbookResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/cookbook.CookbookResponse"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_CreatedShareLinkResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/recipe.CreatedShareLinkResponse"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_ImageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/recipe.ImageResponse"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_RecipeComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/recipe.RecipeComparisonResponse"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_RecipeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/recipe.RecipeResponse"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-user_PasskeyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/user.PasskeyResponse"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-user_ProfileResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/user.ProfileResponse"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-user_RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/user.RecoveryCodesResponse"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-user_TwoFactorEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/user.TwoFactorEnrollmentResponse"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-webauthn_CreationOptions": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/webauthn.CreationOptions"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-webauthn_RequestOptions": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/webauthn.RequestOptions"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-cookbook_CookbookSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cookbook.CookbookSummaryResponse"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/shared.Paging"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_CommentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.CommentResponse"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/shared.Paging"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_FavoriteRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.FavoriteRecipeResponse"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/shared.Paging"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_OwnRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.OwnRecipeResponse"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/shared.Paging"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_RecipeSearchResultResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.RecipeSearchResultResponse"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/shared.Paging"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_RecipeSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.RecipeSummaryResponse"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/shared.Paging"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.ReviewResponse"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/shared.Paging"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_RevisionSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.RevisionSummaryResponse"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/shared.Paging"
+                }
+            }
+        },
+        "github_com_danielbukowski_recipe-app-backend_internal_shared.PagedDataResponse-recipe_TrashedRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.TrashedRecipeResponse"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/shared.Paging"
+                }
+            }
+        },
+        "recipe.CommentRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Thanks @jane@mail.com, I added a pinch of salt too!"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "recipe.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Thanks @jane@mail.com, I added a pinch of salt too!"
+                },
+                "comment_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "is_deleted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "reply_count": {
+                    "description": "ReplyCount is the number of replies to a top-level comment. It is always 0 for replies.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "recipe.ComparedRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "recipe_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "recipe.CreatedShareLinkResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "share_link_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "q3v1X0pTn8bA2cYdE5fGh7iJk9lMn0oPq2rSt4uVw6x"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/v1/shared/q3v1X0pTn8bA2cYdE5fGh7iJk9lMn0oPq2rSt4uVw6x"
+                }
+            }
+        },
+        "recipe.FavoriteRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "favorite_count": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "favorited_at": {
+                    "type": "string",
+                    "example": "2025-02-08T21:35:31.00635Z"
+                },
+                "recipe_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "recipe.ForkAttributionResponse": {
+            "type": "object",
+            "properties": {
+                "recipe_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                },
+                "user_id": {
+                    "description": "UserID is null when the author of the original recipe has deleted their account.",
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "recipe.ImageResponse": {
+            "type": "object",
+            "properties": {
+                "image_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/uploads/recipes/0194b341-6797-736a-9a98-474d08025925/0194b341-6797-736a-9a98-474d08025926.jpg"
+                }
+            }
+        },
+        "recipe.IngredientComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "dark chocolate"
+                },
+                "quantity_a": {
+                    "type": "string",
+                    "example": "200 g"
+                },
+                "quantity_b": {
+                    "type": "string",
+                    "example": "150 g"
+                }
+            }
+        },
+        "recipe.IngredientRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "dark chocolate"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "finely chopped"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 200
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "g"
+                }
+            }
+        },
+        "recipe.IngredientResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "dark chocolate"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "finely chopped"
+                },
+                "quantity": {
+                    "description": "Quantity is null for ingredients without a measured amount, e.g. \"salt to taste\".",
+                    "type": "number",
+                    "example": 200
+                },
+                "quantity_text": {
+                    "description": "QuantityText is the quantity written the way cooks write it, e.g. \"1 1/2\".",
+                    "type": "string",
+                    "example": "200"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "g"
+                }
+            }
+        },
+        "recipe.InsertStepRequest": {
+            "type": "object",
+            "required": [
+                "instruction"
+            ],
+            "properties": {
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "instruction": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "minLength": 3,
+                    "example": "Bake until the edges are golden."
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "temperature": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "temperature_unit": {
+                    "type": "string",
+                    "enum": [
+                        "C",
+                        "F"
+                    ],
+                    "example": "C"
+                }
+            }
+        },
+        "recipe.NewRecipeRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "tags",
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "minLength": 5,
+                    "example": "Having all your ingredients the same temperature really helps here"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/recipe.IngredientRequest"
+                    }
+                },
+                "servings": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 4
+                },
+                "steps": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/recipe.StepRequest"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "dessert",
+                        "vegetarian"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "minLength": 5,
+                    "example": "Chocolate Cookies"
+                },
+                "visibility": {
+                    "description": "Visibility is public when it is omitted.",
+                    "type": "string",
+                    "enum": [
+                        "private",
+                        "unlisted",
+                        "public"
+                    ],
+                    "example": "public"
+                }
+            }
+        },
+        "recipe.OwnRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "favorite_count": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                },
+                "recipe_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "draft"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "visibility": {
+                    "type": "string",
+                    "example": "private"
+                }
+            }
+        },
+        "recipe.PublishRecipeRequest": {
+            "type": "object",
+            "properties": {
+                "publish_at": {
+                    "description": "PublishAt schedules the recipe to be published later. The recipe is published right away when it is omitted or in the past.",
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                }
+            }
+        },
+        "recipe.PublishRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "publish_at": {
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "scheduled"
+                }
+            }
+        },
+        "recipe.RecipeComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/recipe.ComparedRecipeResponse"
+                },
+                "b": {
+                    "$ref": "#/definitions/recipe.ComparedRecipeResponse"
+                },
+                "content_diff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/textdiff.Segment"
+                    }
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.IngredientComparisonResponse"
+                    }
+                },
+                "similarity": {
+                    "description": "Similarity is 0 for recipes with nothing in common and 1 for the same recipes.",
+                    "type": "number",
+                    "example": 0.72
+                },
+                "title_diff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/textdiff.Segment"
+                    }
+                }
+            }
+        },
+        "recipe.RecipeResponse": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "description": "AverageRating is null for recipes nobody has reviewed yet.",
+                    "type": "number",
+                    "example": 4.25
+                },
+                "content": {
+                    "type": "string",
+                    "example": "Having all your ingredients the same temperature really helps here"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "favorite_count": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "fork_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "forked_from": {
+                    "description": "ForkedFrom is null for recipes which are not forks of another recipe.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/recipe.ForkAttributionResponse"
+                        }
+                    ]
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.ImageResponse"
+                    }
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.IngredientResponse"
+                    }
+                },
+                "is_favorite": {
+                    "description": "IsFavorite tells whether the signed-in user has saved the recipe to their favorites.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "publish_at": {
+                    "description": "PublishAt is when a scheduled recipe is going to be published, or when a published recipe was published.",
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                },
+                "rating_count": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "servings": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "status": {
+                    "type": "string",
+                    "example": "published"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.StepResponse"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "dessert",
+                        "vegetarian"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "visibility": {
+                    "type": "string",
+                    "example": "public"
+                }
+            }
+        },
+        "recipe.RecipeSearchResultResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "rank": {
+                    "type": "number",
+                    "example": 0.6079271
+                },
+                "recipe_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "snippet": {
+                    "type": "string",
+                    "example": "melt the \u003cmark\u003echocolate\u003c/mark\u003e over a water bath"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                },
+                "title_highlight": {
+                    "type": "string",
+                    "example": "\u003cmark\u003eChocolate\u003c/mark\u003e Cookies"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "recipe.RecipeSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "favorite_count": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "recipe_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "recipe.ReorderStepsRequest": {
+            "type": "object",
+            "required": [
+                "step_ids"
+            ],
+            "properties": {
+                "step_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "recipe.ReviewRequest": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Crispy edges and a soft middle, just as promised."
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 5
+                }
+            }
+        },
+        "recipe.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Crispy edges and a soft middle, just as promised."
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "recipe.RevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/textdiff.Segment"
+                    }
+                },
+                "from": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/textdiff.Segment"
+                    }
+                },
+                "servings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/textdiff.Segment"
+                    }
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/textdiff.Segment"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/textdiff.Segment"
+                    }
+                },
+                "title": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/textdiff.Segment"
+                    }
+                },
+                "to": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "visibility": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/textdiff.Segment"
+                    }
+                }
+            }
+        },
+        "recipe.RevisionResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Having all your ingredients the same temperature really helps here"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.IngredientResponse"
+                    }
+                },
+                "revision_number": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "servings": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipe.RevisionStepResponse"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "dessert",
+                        "vegetarian"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                },
+                "visibility": {
+                    "type": "string",
+                    "example": "public"
+                }
+            }
+        },
+        "recipe.RevisionStepResponse": {
+            "type": "object",
+            "properties": {
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "instruction": {
+                    "type": "string",
+                    "example": "Bake until the edges are golden."
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "temperature": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "temperature_unit": {
+                    "type": "string",
+                    "example": "C"
+                }
+            }
+        },
+        "recipe.RevisionSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "revision_number": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                }
+            }
+        },
+        "recipe.ShareLinkRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "ExpiresAt is when the link stops working. The link is valid until it is revoked when it is omitted.",
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                }
+            }
+        },
+        "recipe.ShareLinkResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "last_accessed_at": {
+                    "description": "LastAccessedAt is null for links nobody has opened yet.",
+                    "type": "string",
+                    "example": "2025-02-09T18:21:00Z"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2025-02-10T12:00:00Z"
+                },
+                "share_link_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "view_count": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "recipe.StepRequest": {
+            "type": "object",
+            "required": [
+                "instruction"
+            ],
+            "properties": {
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "instruction": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "minLength": 3,
+                    "example": "Bake until the edges are golden."
+                },
+                "temperature": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "temperature_unit": {
+                    "type": "string",
+                    "enum": [
+                        "C",
+                        "F"
+                    ],
+                    "example": "C"
+                }
+            }
+        },
+        "recipe.StepResponse": {
+            "type": "object",
+            "properties": {
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "instruction": {
+                    "type": "string",
+                    "example": "Bake until the edges are golden."
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "step_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "temperature": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "temperature_unit": {
+                    "type": "string",
+                    "example": "C"
+                }
+            }
+        },
+        "recipe.TagResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "diet"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "vegetarian"
+                },
+                "recipe_count": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "recipe.TrashedRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-05T21:35:31.00635Z"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2025-02-08T21:35:31.00635Z"
+                },
+                "favorite_count": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "purge_at": {
+                    "type": "string",
+                    "example": "2025-03-10T21:35:31.00635Z"
+                },
+                "recipe_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chocolate Cookies"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-07T21:35:31.00635Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
+                }
+            }
+        },
+        "recipe.UpdateCommentRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "body"
             ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@mail.com"
-                },
-                "password": {
+                "body": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 5,
-                    "example": "supersecretpassword"
+                    "maxLength": 2000,
+                    "example": "Thanks @jane@mail.com, I added a pinch of salt too!"
                 }
             }
         },
-        "auth.SignUpRequest": {
+        "recipe.UpdateRecipeRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password",
-                "password_again"
+                "content",
+                "servings",
+                "tags",
+                "title",
+                "visibility"
             ],
             "properties": {
-                "email": {
+                "content": {
                     "type": "string",
-                    "example": "user@mail.com"
+                    "minLength": 5,
+                    "example": "Having all your ingredients the same temperature really helps here"
                 },
-                "password": {
+                "ingredients": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/recipe.IngredientRequest"
+                    }
+                },
+                "servings": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 4
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "dessert",
+                        "vegetarian"
+                    ]
+                },
+                "title": {
                     "type": "string",
-                    "maxLength": 50,
                     "minLength": 5,
-                    "example": "supersecretpassword"
+                    "example": "Chocolate Cookies"
                 },
-                "password_again": {
+                "visibility": {
                     "type": "string",
-                    "example": "supersecretpassword"
+                    "enum": [
+                        "private",
+                        "unlisted",
+                        "public"
+                    ],
+                    "example": "unlisted"
                 }
             }
         },
-        "github_com_danielbukowski_recipe-app-backend_internal_shared.DataResponse-recipe_RecipeResponse": {
+        "shared.CommonResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/recipe.RecipeResponse"
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "recipe.NewRecipeRequest": {
+        "shared.Paging": {
             "type": "object",
-            "required": [
-                "content",
-                "title"
-            ],
             "properties": {
-                "content": {
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "description": "NextCursor is empty when there are no more pages to fetch.",
                     "type": "string",
-                    "minLength": 5,
-                    "example": "Having all your ingredients the same temperature really helps here"
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCJ9"
+                }
+            }
+        },
+        "textdiff.Operation": {
+            "type": "string",
+            "enum": [
+                "equal",
+                "insert",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "Equal",
+                "Insert",
+                "Delete"
+            ]
+        },
+        "textdiff.Segment": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/textdiff.Operation"
+                        }
+                    ],
+                    "example": "equal"
                 },
-                "title": {
+                "text": {
                     "type": "string",
-                    "minLength": 5,
-                    "example": "Chocolate Cookies"
+                    "example": "Melt the chocolate"
                 }
             }
         },
-        "recipe.RecipeResponse": {
+        "user.PasskeyResponse": {
             "type": "object",
             "properties": {
-                "content": {
+                "created_at": {
                     "type": "string",
-                    "example": "Having all your ingredients the same temperature really helps here"
+                    "example": "2025-06-20T12:00:00.00635Z"
+                },
+                "last_used_at": {
+                    "description": "LastUsedAt is null until the passkey is used to sign in.",
+                    "type": "string",
+                    "example": "2025-06-21T08:15:42.00635Z"
                 },
+                "name": {
+                    "type": "string",
+                    "example": "MacBook"
+                },
+                "passkey_id": {
+                    "type": "string",
+                    "example": "0197a1c2-5b3e-7d4f-8a90-1b2c3d4e5f60"
+                },
+                "transports": {
+                    "description": "Transports are the ways the browser reaches the authenticator, such as internal, usb or hybrid.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "internal",
+                        "hybrid"
+                    ]
+                }
+            }
+        },
+        "user.ProfileResponse": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "string",
                     "example": "2025-02-05T21:35:31.00635Z"
                 },
-                "title": {
+                "email": {
                     "type": "string",
-                    "example": "Chocolate Cookies"
+                    "example": "user@mail.com"
                 },
-                "updated_at": {
+                "email_verified_at": {
+                    "description": "EmailVerifiedAt is null until the user verifies the email. Users with unverified emails cannot publish recipes or comment.",
                     "type": "string",
-                    "example": "2025-02-07T21:35:31.00635Z"
+                    "example": "2025-02-05T21:40:12.00635Z"
+                },
+                "measurement_system": {
+                    "description": "MeasurementSystem is empty when the user has not chosen one and recipes are shown as they were written.",
+                    "type": "string",
+                    "example": "metric"
+                },
+                "two_factor_enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0194b341-6797-736a-9a98-474d08025925"
                 }
             }
         },
-        "recipe.UpdateRecipeRequest": {
+        "user.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k7d2m-x9q4t",
+                        "p3w8n-h5r2c"
+                    ]
+                }
+            }
+        },
+        "user.RegisterPasskeyRequest": {
             "type": "object",
             "required": [
-                "content",
-                "title"
+                "credential",
+                "name"
             ],
             "properties": {
-                "content": {
+                "credential": {
+                    "$ref": "#/definitions/webauthn.RegistrationCredential"
+                },
+                "name": {
                     "type": "string",
-                    "minLength": 5,
-                    "example": "Having all your ingredients the same temperature really helps here"
+                    "maxLength": 64,
+                    "example": "MacBook"
+                }
+            }
+        },
+        "user.TwoFactorCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "287082"
+                }
+            }
+        },
+        "user.TwoFactorEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
                 },
-                "title": {
+                "uri": {
+                    "description": "URI is the otpauth URI of the secret, usually shown as a QR code.",
                     "type": "string",
-                    "minLength": 5,
-                    "example": "Chocolate Cookies"
+                    "example": "otpauth://totp/Recipe%20App:user@mail.com?algorithm=SHA1\u0026digits=6\u0026issuer=Recipe+App\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
                 }
             }
         },
-        "shared.CommonResponse": {
+        "user.UpdateProfileRequest": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "measurement_system": {
+                    "type": "string",
+                    "enum": [
+                        "metric",
+                        "imperial"
+                    ],
+                    "example": "imperial"
                 }
             }
         },
@@ -424,6 +4985,232 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "webauthn.AssertionCredential": {
+            "type": "object",
+            "required": [
+                "id",
+                "rawId",
+                "response",
+                "type"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "rawId": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "response": {
+                    "$ref": "#/definitions/webauthn.AssertionResponse"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "webauthn.AssertionResponse": {
+            "type": "object",
+            "required": [
+                "authenticatorData",
+                "clientDataJSON",
+                "signature"
+            ],
+            "properties": {
+                "authenticatorData": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "clientDataJSON": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "signature": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "userHandle": {
+                    "type": "string",
+                    "format": "base64url"
+                }
+            }
+        },
+        "webauthn.AttestationResponse": {
+            "type": "object",
+            "required": [
+                "attestationObject",
+                "clientDataJSON"
+            ],
+            "properties": {
+                "attestationObject": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "clientDataJSON": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "transports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "webauthn.AuthenticatorSelection": {
+            "type": "object",
+            "properties": {
+                "requireResidentKey": {
+                    "type": "boolean"
+                },
+                "residentKey": {
+                    "type": "string"
+                },
+                "userVerification": {
+                    "type": "string"
+                }
+            }
+        },
+        "webauthn.CreationOptions": {
+            "type": "object",
+            "properties": {
+                "attestation": {
+                    "type": "string"
+                },
+                "authenticatorSelection": {
+                    "$ref": "#/definitions/webauthn.AuthenticatorSelection"
+                },
+                "challenge": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "excludeCredentials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webauthn.CredentialDescriptor"
+                    }
+                },
+                "pubKeyCredParams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webauthn.CredentialParameter"
+                    }
+                },
+                "rp": {
+                    "$ref": "#/definitions/webauthn.RelyingPartyEntity"
+                },
+                "timeout": {
+                    "type": "integer"
+                },
+                "user": {
+                    "$ref": "#/definitions/webauthn.UserEntity"
+                }
+            }
+        },
+        "webauthn.CredentialDescriptor": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "transports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "webauthn.CredentialParameter": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "webauthn.RegistrationCredential": {
+            "type": "object",
+            "required": [
+                "id",
+                "rawId",
+                "response",
+                "type"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "rawId": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "response": {
+                    "$ref": "#/definitions/webauthn.AttestationResponse"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "webauthn.RelyingPartyEntity": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "webauthn.RequestOptions": {
+            "type": "object",
+            "properties": {
+                "allowCredentials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webauthn.CredentialDescriptor"
+                    }
+                },
+                "challenge": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "rpId": {
+                    "type": "string"
+                },
+                "timeout": {
+                    "type": "integer"
+                },
+                "userVerification": {
+                    "type": "string"
+                }
+            }
+        },
+        "webauthn.UserEntity": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "base64url"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
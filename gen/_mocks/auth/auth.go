@@ -0,0 +1,341 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/auth/handlers.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/auth/handlers.go -destination=./gen/_mocks/auth/auth.go -mock_names=userService=MockUserService,sessionStorage=MockSessionStorage
+//
+
+// Package mock_auth is a generated GoMock package.
+package mock_auth
+
+import (
+	context "context"
+	reflect "reflect"
+
+	auth "github.com/danielbukowski/recipe-app-backend/internal/auth"
+	session "github.com/danielbukowski/recipe-app-backend/internal/session"
+	webauthn "github.com/danielbukowski/recipe-app-backend/internal/webauthn"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserService is a mock of userService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// BeginPasskeySignIn mocks base method.
+func (m *MockUserService) BeginPasskeySignIn() (webauthn.RequestOptions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginPasskeySignIn")
+	ret0, _ := ret[0].(webauthn.RequestOptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginPasskeySignIn indicates an expected call of BeginPasskeySignIn.
+func (mr *MockUserServiceMockRecorder) BeginPasskeySignIn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginPasskeySignIn", reflect.TypeOf((*MockUserService)(nil).BeginPasskeySignIn))
+}
+
+// CreateUser mocks base method.
+func (m *MockUserService) CreateUser(arg0 context.Context, arg1 auth.SignUpRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserServiceMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserService)(nil).CreateUser), arg0, arg1)
+}
+
+// FinishPasskeySignIn mocks base method.
+func (m *MockUserService) FinishPasskeySignIn(ctx context.Context, challenge []byte, credential webauthn.AssertionCredential) (auth.SignInResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishPasskeySignIn", ctx, challenge, credential)
+	ret0, _ := ret[0].(auth.SignInResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishPasskeySignIn indicates an expected call of FinishPasskeySignIn.
+func (mr *MockUserServiceMockRecorder) FinishPasskeySignIn(ctx, challenge, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishPasskeySignIn", reflect.TypeOf((*MockUserService)(nil).FinishPasskeySignIn), ctx, challenge, credential)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockUserService) RequestPasswordReset(arg0 context.Context, arg1 auth.ForgotPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockUserServiceMockRecorder) RequestPasswordReset(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockUserService)(nil).RequestPasswordReset), arg0, arg1)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUserService) ResetPassword(arg0 context.Context, arg1 auth.ResetPasswordRequest) (auth.ResetPasswordResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1)
+	ret0, _ := ret[0].(auth.ResetPasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserServiceMockRecorder) ResetPassword(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserService)(nil).ResetPassword), arg0, arg1)
+}
+
+// SignIn mocks base method.
+func (m *MockUserService) SignIn(ctx context.Context, signInRequest auth.SignInRequest) (auth.SignInResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", ctx, signInRequest)
+	ret0, _ := ret[0].(auth.SignInResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockUserServiceMockRecorder) SignIn(ctx, signInRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockUserService)(nil).SignIn), ctx, signInRequest)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockUserService) VerifyEmail(arg0 context.Context, arg1 auth.VerifyEmailRequest) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserServiceMockRecorder) VerifyEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserService)(nil).VerifyEmail), arg0, arg1)
+}
+
+// VerifySecondFactor mocks base method.
+func (m *MockUserService) VerifySecondFactor(arg0 context.Context, arg1 uuid.UUID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySecondFactor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifySecondFactor indicates an expected call of VerifySecondFactor.
+func (mr *MockUserServiceMockRecorder) VerifySecondFactor(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySecondFactor", reflect.TypeOf((*MockUserService)(nil).VerifySecondFactor), arg0, arg1, arg2)
+}
+
+// MockSessionStorage is a mock of sessionStorage interface.
+type MockSessionStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionStorageMockRecorder is the mock recorder for MockSessionStorage.
+type MockSessionStorageMockRecorder struct {
+	mock *MockSessionStorage
+}
+
+// NewMockSessionStorage creates a new mock instance.
+func NewMockSessionStorage(ctrl *gomock.Controller) *MockSessionStorage {
+	mock := &MockSessionStorage{ctrl: ctrl}
+	mock.recorder = &MockSessionStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionStorage) EXPECT() *MockSessionStorageMockRecorder {
+	return m.recorder
+}
+
+// CountPendingAttempt mocks base method.
+func (m *MockSessionStorage) CountPendingAttempt(pendingID string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingAttempt", pendingID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingAttempt indicates an expected call of CountPendingAttempt.
+func (mr *MockSessionStorageMockRecorder) CountPendingAttempt(pendingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingAttempt", reflect.TypeOf((*MockSessionStorage)(nil).CountPendingAttempt), pendingID)
+}
+
+// CreateNew mocks base method.
+func (m *MockSessionStorage) CreateNew(userId uuid.UUID, value []byte, expiration int32) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNew", userId, value, expiration)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNew indicates an expected call of CreateNew.
+func (mr *MockSessionStorageMockRecorder) CreateNew(userId, value, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNew", reflect.TypeOf((*MockSessionStorage)(nil).CreateNew), userId, value, expiration)
+}
+
+// CreatePending mocks base method.
+func (m *MockSessionStorage) CreatePending(value []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePending", value)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePending indicates an expected call of CreatePending.
+func (mr *MockSessionStorageMockRecorder) CreatePending(value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePending", reflect.TypeOf((*MockSessionStorage)(nil).CreatePending), value)
+}
+
+// Delete mocks base method.
+func (m *MockSessionStorage) Delete(key string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", key)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSessionStorageMockRecorder) Delete(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSessionStorage)(nil).Delete), key)
+}
+
+// DeleteAllOfUser mocks base method.
+func (m *MockSessionStorage) DeleteAllOfUser(userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllOfUser", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllOfUser indicates an expected call of DeleteAllOfUser.
+func (mr *MockSessionStorageMockRecorder) DeleteAllOfUser(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllOfUser", reflect.TypeOf((*MockSessionStorage)(nil).DeleteAllOfUser), userId)
+}
+
+// DeletePending mocks base method.
+func (m *MockSessionStorage) DeletePending(pendingID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeletePending", pendingID)
+}
+
+// DeletePending indicates an expected call of DeletePending.
+func (mr *MockSessionStorageMockRecorder) DeletePending(pendingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePending", reflect.TypeOf((*MockSessionStorage)(nil).DeletePending), pendingID)
+}
+
+// GetPending mocks base method.
+func (m *MockSessionStorage) GetPending(pendingID string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", pendingID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockSessionStorageMockRecorder) GetPending(pendingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockSessionStorage)(nil).GetPending), pendingID)
+}
+
+// SaveChallenge mocks base method.
+func (m *MockSessionStorage) SaveChallenge(challenge []byte, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChallenge", challenge, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChallenge indicates an expected call of SaveChallenge.
+func (mr *MockSessionStorageMockRecorder) SaveChallenge(challenge, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChallenge", reflect.TypeOf((*MockSessionStorage)(nil).SaveChallenge), challenge, userId)
+}
+
+// SetSessionVersion mocks base method.
+func (m *MockSessionStorage) SetSessionVersion(userId uuid.UUID, version int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSessionVersion", userId, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSessionVersion indicates an expected call of SetSessionVersion.
+func (mr *MockSessionStorageMockRecorder) SetSessionVersion(userId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSessionVersion", reflect.TypeOf((*MockSessionStorage)(nil).SetSessionVersion), userId, version)
+}
+
+// TakeChallenge mocks base method.
+func (m *MockSessionStorage) TakeChallenge(challenge []byte) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeChallenge", challenge)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeChallenge indicates an expected call of TakeChallenge.
+func (mr *MockSessionStorageMockRecorder) TakeChallenge(challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeChallenge", reflect.TypeOf((*MockSessionStorage)(nil).TakeChallenge), challenge)
+}
+
+// UpdateAllOfUser mocks base method.
+func (m *MockSessionStorage) UpdateAllOfUser(userId uuid.UUID, update func(*session.Session)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAllOfUser", userId, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAllOfUser indicates an expected call of UpdateAllOfUser.
+func (mr *MockSessionStorageMockRecorder) UpdateAllOfUser(userId, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAllOfUser", reflect.TypeOf((*MockSessionStorage)(nil).UpdateAllOfUser), userId, update)
+}
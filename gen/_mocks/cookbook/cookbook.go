@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/cookbook/handlers.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/cookbook/handlers.go -destination=./gen/_mocks/cookbook/cookbook.go -mock_names=cookbookService=MockCookbookService
+//
+
+// Package mock_cookbook is a generated GoMock package.
+package mock_cookbook
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cookbook "github.com/danielbukowski/recipe-app-backend/internal/cookbook"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCookbookService is a mock of cookbookService interface.
+type MockCookbookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCookbookServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCookbookServiceMockRecorder is the mock recorder for MockCookbookService.
+type MockCookbookServiceMockRecorder struct {
+	mock *MockCookbookService
+}
+
+// NewMockCookbookService creates a new mock instance.
+func NewMockCookbookService(ctrl *gomock.Controller) *MockCookbookService {
+	mock := &MockCookbookService{ctrl: ctrl}
+	mock.recorder = &MockCookbookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCookbookService) EXPECT() *MockCookbookServiceMockRecorder {
+	return m.recorder
+}
+
+// AddCookbookRecipe mocks base method.
+func (m *MockCookbookService) AddCookbookRecipe(arg0 context.Context, arg1 uuid.UUID, arg2 cookbook.AddCookbookRecipeRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCookbookRecipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCookbookRecipe indicates an expected call of AddCookbookRecipe.
+func (mr *MockCookbookServiceMockRecorder) AddCookbookRecipe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCookbookRecipe", reflect.TypeOf((*MockCookbookService)(nil).AddCookbookRecipe), arg0, arg1, arg2)
+}
+
+// CreateCookbook mocks base method.
+func (m *MockCookbookService) CreateCookbook(arg0 context.Context, arg1 uuid.UUID, arg2 cookbook.CookbookRequest) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCookbook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCookbook indicates an expected call of CreateCookbook.
+func (mr *MockCookbookServiceMockRecorder) CreateCookbook(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCookbook", reflect.TypeOf((*MockCookbookService)(nil).CreateCookbook), arg0, arg1, arg2)
+}
+
+// DeleteCookbook mocks base method.
+func (m *MockCookbookService) DeleteCookbook(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCookbook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCookbook indicates an expected call of DeleteCookbook.
+func (mr *MockCookbookServiceMockRecorder) DeleteCookbook(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCookbook", reflect.TypeOf((*MockCookbookService)(nil).DeleteCookbook), arg0, arg1)
+}
+
+// GetCookbookById mocks base method.
+func (m *MockCookbookService) GetCookbookById(arg0 context.Context, arg1, arg2 uuid.UUID) (cookbook.CookbookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCookbookById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(cookbook.CookbookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCookbookById indicates an expected call of GetCookbookById.
+func (mr *MockCookbookServiceMockRecorder) GetCookbookById(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCookbookById", reflect.TypeOf((*MockCookbookService)(nil).GetCookbookById), arg0, arg1, arg2)
+}
+
+// ListCookbooks mocks base method.
+func (m *MockCookbookService) ListCookbooks(arg0 context.Context, arg1 uuid.UUID, arg2 bool, arg3 cookbook.ListCookbooksRequest) ([]cookbook.CookbookSummaryResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCookbooks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]cookbook.CookbookSummaryResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListCookbooks indicates an expected call of ListCookbooks.
+func (mr *MockCookbookServiceMockRecorder) ListCookbooks(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCookbooks", reflect.TypeOf((*MockCookbookService)(nil).ListCookbooks), arg0, arg1, arg2, arg3)
+}
+
+// RemoveCookbookRecipe mocks base method.
+func (m *MockCookbookService) RemoveCookbookRecipe(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCookbookRecipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCookbookRecipe indicates an expected call of RemoveCookbookRecipe.
+func (mr *MockCookbookServiceMockRecorder) RemoveCookbookRecipe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCookbookRecipe", reflect.TypeOf((*MockCookbookService)(nil).RemoveCookbookRecipe), arg0, arg1, arg2)
+}
+
+// UpdateCookbook mocks base method.
+func (m *MockCookbookService) UpdateCookbook(arg0 context.Context, arg1 uuid.UUID, arg2 cookbook.CookbookRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCookbook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCookbook indicates an expected call of UpdateCookbook.
+func (mr *MockCookbookServiceMockRecorder) UpdateCookbook(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCookbook", reflect.TypeOf((*MockCookbookService)(nil).UpdateCookbook), arg0, arg1, arg2)
+}
@@ -11,6 +11,7 @@ package mock_recipe
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -43,19 +44,107 @@ func (m *MockRecipeService) EXPECT() *MockRecipeServiceMockRecorder {
 	return m.recorder
 }
 
+// AddFavoriteRecipe mocks base method.
+func (m *MockRecipeService) AddFavoriteRecipe(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavoriteRecipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFavoriteRecipe indicates an expected call of AddFavoriteRecipe.
+func (mr *MockRecipeServiceMockRecorder) AddFavoriteRecipe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavoriteRecipe", reflect.TypeOf((*MockRecipeService)(nil).AddFavoriteRecipe), arg0, arg1, arg2)
+}
+
+// AddRecipeImage mocks base method.
+func (m *MockRecipeService) AddRecipeImage(arg0 context.Context, arg1 uuid.UUID, arg2 io.Reader, arg3 int64, arg4 string) (recipe.ImageResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecipeImage", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(recipe.ImageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRecipeImage indicates an expected call of AddRecipeImage.
+func (mr *MockRecipeServiceMockRecorder) AddRecipeImage(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecipeImage", reflect.TypeOf((*MockRecipeService)(nil).AddRecipeImage), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateComment mocks base method.
+func (m *MockRecipeService) CreateComment(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 recipe.CommentRequest) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockRecipeServiceMockRecorder) CreateComment(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockRecipeService)(nil).CreateComment), arg0, arg1, arg2, arg3)
+}
+
 // CreateNewRecipe mocks base method.
-func (m *MockRecipeService) CreateNewRecipe(arg0 context.Context, arg1 recipe.NewRecipeRequest) (uuid.UUID, error) {
+func (m *MockRecipeService) CreateNewRecipe(arg0 context.Context, arg1 uuid.UUID, arg2 recipe.NewRecipeRequest) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNewRecipe", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateNewRecipe", arg0, arg1, arg2)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateNewRecipe indicates an expected call of CreateNewRecipe.
-func (mr *MockRecipeServiceMockRecorder) CreateNewRecipe(arg0, arg1 any) *gomock.Call {
+func (mr *MockRecipeServiceMockRecorder) CreateNewRecipe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewRecipe", reflect.TypeOf((*MockRecipeService)(nil).CreateNewRecipe), arg0, arg1, arg2)
+}
+
+// CreateRecipeStep mocks base method.
+func (m *MockRecipeService) CreateRecipeStep(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 recipe.InsertStepRequest) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipeStep", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecipeStep indicates an expected call of CreateRecipeStep.
+func (mr *MockRecipeServiceMockRecorder) CreateRecipeStep(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipeStep", reflect.TypeOf((*MockRecipeService)(nil).CreateRecipeStep), arg0, arg1, arg2, arg3)
+}
+
+// CreateShareLink mocks base method.
+func (m *MockRecipeService) CreateShareLink(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) (recipe.CreatedShareLinkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShareLink", arg0, arg1, arg2)
+	ret0, _ := ret[0].(recipe.CreatedShareLinkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShareLink indicates an expected call of CreateShareLink.
+func (mr *MockRecipeServiceMockRecorder) CreateShareLink(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewRecipe", reflect.TypeOf((*MockRecipeService)(nil).CreateNewRecipe), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShareLink", reflect.TypeOf((*MockRecipeService)(nil).CreateShareLink), arg0, arg1, arg2)
+}
+
+// DeleteComment mocks base method.
+func (m *MockRecipeService) DeleteComment(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockRecipeServiceMockRecorder) DeleteComment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockRecipeService)(nil).DeleteComment), arg0, arg1)
 }
 
 // DeleteRecipeById mocks base method.
@@ -72,6 +161,65 @@ func (mr *MockRecipeServiceMockRecorder) DeleteRecipeById(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeById", reflect.TypeOf((*MockRecipeService)(nil).DeleteRecipeById), arg0, arg1)
 }
 
+// DeleteRecipeStep mocks base method.
+func (m *MockRecipeService) DeleteRecipeStep(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeStep", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeStep indicates an expected call of DeleteRecipeStep.
+func (mr *MockRecipeServiceMockRecorder) DeleteRecipeStep(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeStep", reflect.TypeOf((*MockRecipeService)(nil).DeleteRecipeStep), arg0, arg1, arg2, arg3)
+}
+
+// DiffRecipeRevisions mocks base method.
+func (m *MockRecipeService) DiffRecipeRevisions(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 int32) (recipe.RevisionDiffResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRecipeRevisions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(recipe.RevisionDiffResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRecipeRevisions indicates an expected call of DiffRecipeRevisions.
+func (mr *MockRecipeServiceMockRecorder) DiffRecipeRevisions(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRecipeRevisions", reflect.TypeOf((*MockRecipeService)(nil).DiffRecipeRevisions), arg0, arg1, arg2, arg3)
+}
+
+// ForkRecipe mocks base method.
+func (m *MockRecipeService) ForkRecipe(arg0 context.Context, arg1, arg2 uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForkRecipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForkRecipe indicates an expected call of ForkRecipe.
+func (mr *MockRecipeServiceMockRecorder) ForkRecipe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForkRecipe", reflect.TypeOf((*MockRecipeService)(nil).ForkRecipe), arg0, arg1, arg2)
+}
+
+// GetComment mocks base method.
+func (m *MockRecipeService) GetComment(arg0 context.Context, arg1, arg2 uuid.UUID) (recipe.CommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(recipe.CommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockRecipeServiceMockRecorder) GetComment(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockRecipeService)(nil).GetComment), arg0, arg1, arg2)
+}
+
 // GetRecipeById mocks base method.
 func (m *MockRecipeService) GetRecipeById(arg0 context.Context, arg1 uuid.UUID) (recipe.RecipeResponse, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +235,340 @@ func (mr *MockRecipeServiceMockRecorder) GetRecipeById(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeById", reflect.TypeOf((*MockRecipeService)(nil).GetRecipeById), arg0, arg1)
 }
 
+// GetRecipeRevision mocks base method.
+func (m *MockRecipeService) GetRecipeRevision(arg0 context.Context, arg1 uuid.UUID, arg2 int32) (recipe.RevisionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeRevision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(recipe.RevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeRevision indicates an expected call of GetRecipeRevision.
+func (mr *MockRecipeServiceMockRecorder) GetRecipeRevision(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeRevision", reflect.TypeOf((*MockRecipeService)(nil).GetRecipeRevision), arg0, arg1, arg2)
+}
+
+// IsFavoriteRecipe mocks base method.
+func (m *MockRecipeService) IsFavoriteRecipe(arg0 context.Context, arg1, arg2 uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFavoriteRecipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFavoriteRecipe indicates an expected call of IsFavoriteRecipe.
+func (mr *MockRecipeServiceMockRecorder) IsFavoriteRecipe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFavoriteRecipe", reflect.TypeOf((*MockRecipeService)(nil).IsFavoriteRecipe), arg0, arg1, arg2)
+}
+
+// ListCommentReplies mocks base method.
+func (m *MockRecipeService) ListCommentReplies(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 recipe.ListCommentsRequest) ([]recipe.CommentResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentReplies", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]recipe.CommentResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListCommentReplies indicates an expected call of ListCommentReplies.
+func (mr *MockRecipeServiceMockRecorder) ListCommentReplies(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentReplies", reflect.TypeOf((*MockRecipeService)(nil).ListCommentReplies), arg0, arg1, arg2, arg3)
+}
+
+// ListComments mocks base method.
+func (m *MockRecipeService) ListComments(arg0 context.Context, arg1 uuid.UUID, arg2 recipe.ListCommentsRequest) ([]recipe.CommentResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComments", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]recipe.CommentResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListComments indicates an expected call of ListComments.
+func (mr *MockRecipeServiceMockRecorder) ListComments(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*MockRecipeService)(nil).ListComments), arg0, arg1, arg2)
+}
+
+// ListDeletedRecipes mocks base method.
+func (m *MockRecipeService) ListDeletedRecipes(arg0 context.Context, arg1 uuid.UUID, arg2 recipe.ListTrashRequest) ([]recipe.TrashedRecipeResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedRecipes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]recipe.TrashedRecipeResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeletedRecipes indicates an expected call of ListDeletedRecipes.
+func (mr *MockRecipeServiceMockRecorder) ListDeletedRecipes(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedRecipes", reflect.TypeOf((*MockRecipeService)(nil).ListDeletedRecipes), arg0, arg1, arg2)
+}
+
+// ListFavoriteRecipes mocks base method.
+func (m *MockRecipeService) ListFavoriteRecipes(arg0 context.Context, arg1 uuid.UUID, arg2 recipe.ListFavoritesRequest) ([]recipe.FavoriteRecipeResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFavoriteRecipes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]recipe.FavoriteRecipeResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFavoriteRecipes indicates an expected call of ListFavoriteRecipes.
+func (mr *MockRecipeServiceMockRecorder) ListFavoriteRecipes(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFavoriteRecipes", reflect.TypeOf((*MockRecipeService)(nil).ListFavoriteRecipes), arg0, arg1, arg2)
+}
+
+// ListOwnRecipes mocks base method.
+func (m *MockRecipeService) ListOwnRecipes(arg0 context.Context, arg1 uuid.UUID, arg2 recipe.ListOwnRecipesRequest) ([]recipe.OwnRecipeResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnRecipes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]recipe.OwnRecipeResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListOwnRecipes indicates an expected call of ListOwnRecipes.
+func (mr *MockRecipeServiceMockRecorder) ListOwnRecipes(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnRecipes", reflect.TypeOf((*MockRecipeService)(nil).ListOwnRecipes), arg0, arg1, arg2)
+}
+
+// ListRecipeForks mocks base method.
+func (m *MockRecipeService) ListRecipeForks(arg0 context.Context, arg1 uuid.UUID, arg2 recipe.ListForksRequest) ([]recipe.RecipeSummaryResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeForks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]recipe.RecipeSummaryResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRecipeForks indicates an expected call of ListRecipeForks.
+func (mr *MockRecipeServiceMockRecorder) ListRecipeForks(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeForks", reflect.TypeOf((*MockRecipeService)(nil).ListRecipeForks), arg0, arg1, arg2)
+}
+
+// ListRecipeReviews mocks base method.
+func (m *MockRecipeService) ListRecipeReviews(arg0 context.Context, arg1 uuid.UUID, arg2 recipe.ListReviewsRequest) ([]recipe.ReviewResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeReviews", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]recipe.ReviewResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRecipeReviews indicates an expected call of ListRecipeReviews.
+func (mr *MockRecipeServiceMockRecorder) ListRecipeReviews(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeReviews", reflect.TypeOf((*MockRecipeService)(nil).ListRecipeReviews), arg0, arg1, arg2)
+}
+
+// ListRecipeRevisions mocks base method.
+func (m *MockRecipeService) ListRecipeRevisions(arg0 context.Context, arg1 uuid.UUID, arg2 recipe.ListRevisionsRequest) ([]recipe.RevisionSummaryResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeRevisions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]recipe.RevisionSummaryResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRecipeRevisions indicates an expected call of ListRecipeRevisions.
+func (mr *MockRecipeServiceMockRecorder) ListRecipeRevisions(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeRevisions", reflect.TypeOf((*MockRecipeService)(nil).ListRecipeRevisions), arg0, arg1, arg2)
+}
+
+// ListRecipes mocks base method.
+func (m *MockRecipeService) ListRecipes(arg0 context.Context, arg1 recipe.ListRecipesRequest) ([]recipe.RecipeSummaryResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipes", arg0, arg1)
+	ret0, _ := ret[0].([]recipe.RecipeSummaryResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRecipes indicates an expected call of ListRecipes.
+func (mr *MockRecipeServiceMockRecorder) ListRecipes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipes", reflect.TypeOf((*MockRecipeService)(nil).ListRecipes), arg0, arg1)
+}
+
+// ListShareLinks mocks base method.
+func (m *MockRecipeService) ListShareLinks(arg0 context.Context, arg1 uuid.UUID) ([]recipe.ShareLinkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShareLinks", arg0, arg1)
+	ret0, _ := ret[0].([]recipe.ShareLinkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShareLinks indicates an expected call of ListShareLinks.
+func (mr *MockRecipeServiceMockRecorder) ListShareLinks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShareLinks", reflect.TypeOf((*MockRecipeService)(nil).ListShareLinks), arg0, arg1)
+}
+
+// ListTags mocks base method.
+func (m *MockRecipeService) ListTags(arg0 context.Context) ([]recipe.TagResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", arg0)
+	ret0, _ := ret[0].([]recipe.TagResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockRecipeServiceMockRecorder) ListTags(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockRecipeService)(nil).ListTags), arg0)
+}
+
+// PublishRecipe mocks base method.
+func (m *MockRecipeService) PublishRecipe(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishRecipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishRecipe indicates an expected call of PublishRecipe.
+func (mr *MockRecipeServiceMockRecorder) PublishRecipe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishRecipe", reflect.TypeOf((*MockRecipeService)(nil).PublishRecipe), arg0, arg1, arg2)
+}
+
+// RemoveFavoriteRecipe mocks base method.
+func (m *MockRecipeService) RemoveFavoriteRecipe(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFavoriteRecipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFavoriteRecipe indicates an expected call of RemoveFavoriteRecipe.
+func (mr *MockRecipeServiceMockRecorder) RemoveFavoriteRecipe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavoriteRecipe", reflect.TypeOf((*MockRecipeService)(nil).RemoveFavoriteRecipe), arg0, arg1, arg2)
+}
+
+// ReorderRecipeSteps mocks base method.
+func (m *MockRecipeService) ReorderRecipeSteps(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderRecipeSteps", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderRecipeSteps indicates an expected call of ReorderRecipeSteps.
+func (mr *MockRecipeServiceMockRecorder) ReorderRecipeSteps(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderRecipeSteps", reflect.TypeOf((*MockRecipeService)(nil).ReorderRecipeSteps), arg0, arg1, arg2, arg3)
+}
+
+// RestoreRecipe mocks base method.
+func (m *MockRecipeService) RestoreRecipe(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRecipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreRecipe indicates an expected call of RestoreRecipe.
+func (mr *MockRecipeServiceMockRecorder) RestoreRecipe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRecipe", reflect.TypeOf((*MockRecipeService)(nil).RestoreRecipe), arg0, arg1, arg2)
+}
+
+// RevertRecipeRevision mocks base method.
+func (m *MockRecipeService) RevertRecipeRevision(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 recipe.UpdateRecipeRequest, arg4 []recipe.StepRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertRecipeRevision", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevertRecipeRevision indicates an expected call of RevertRecipeRevision.
+func (mr *MockRecipeServiceMockRecorder) RevertRecipeRevision(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertRecipeRevision", reflect.TypeOf((*MockRecipeService)(nil).RevertRecipeRevision), arg0, arg1, arg2, arg3, arg4)
+}
+
+// RevokeShareLink mocks base method.
+func (m *MockRecipeService) RevokeShareLink(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShareLink", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShareLink indicates an expected call of RevokeShareLink.
+func (mr *MockRecipeServiceMockRecorder) RevokeShareLink(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShareLink", reflect.TypeOf((*MockRecipeService)(nil).RevokeShareLink), arg0, arg1, arg2)
+}
+
+// SaveRecipeReview mocks base method.
+func (m *MockRecipeService) SaveRecipeReview(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 recipe.ReviewRequest) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRecipeReview", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveRecipeReview indicates an expected call of SaveRecipeReview.
+func (mr *MockRecipeServiceMockRecorder) SaveRecipeReview(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRecipeReview", reflect.TypeOf((*MockRecipeService)(nil).SaveRecipeReview), arg0, arg1, arg2, arg3)
+}
+
+// SearchRecipes mocks base method.
+func (m *MockRecipeService) SearchRecipes(arg0 context.Context, arg1 recipe.SearchRecipesRequest) ([]recipe.RecipeSearchResultResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchRecipes", arg0, arg1)
+	ret0, _ := ret[0].([]recipe.RecipeSearchResultResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchRecipes indicates an expected call of SearchRecipes.
+func (mr *MockRecipeServiceMockRecorder) SearchRecipes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRecipes", reflect.TypeOf((*MockRecipeService)(nil).SearchRecipes), arg0, arg1)
+}
+
+// UpdateComment mocks base method.
+func (m *MockRecipeService) UpdateComment(arg0 context.Context, arg1 uuid.UUID, arg2 recipe.UpdateCommentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockRecipeServiceMockRecorder) UpdateComment(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockRecipeService)(nil).UpdateComment), arg0, arg1, arg2)
+}
+
 // UpdateRecipeById mocks base method.
 func (m *MockRecipeService) UpdateRecipeById(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 recipe.UpdateRecipeRequest) error {
 	m.ctrl.T.Helper()
@@ -101,6 +583,21 @@ func (mr *MockRecipeServiceMockRecorder) UpdateRecipeById(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipeById", reflect.TypeOf((*MockRecipeService)(nil).UpdateRecipeById), arg0, arg1, arg2, arg3)
 }
 
+// UseShareLink mocks base method.
+func (m *MockRecipeService) UseShareLink(arg0 context.Context, arg1 string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseShareLink", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseShareLink indicates an expected call of UseShareLink.
+func (mr *MockRecipeServiceMockRecorder) UseShareLink(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseShareLink", reflect.TypeOf((*MockRecipeService)(nil).UseShareLink), arg0, arg1)
+}
+
 // MockCacheStorage is a mock of cacheStorage interface.
 type MockCacheStorage struct {
 	ctrl     *gomock.Controller
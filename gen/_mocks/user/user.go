@@ -0,0 +1,258 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/user/handlers.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/user/handlers.go -destination=./gen/_mocks/user/user.go -mock_names=profileService=MockProfileService,sessionStorage=MockSessionStorage
+//
+
+// Package mock_user is a generated GoMock package.
+package mock_user
+
+import (
+	context "context"
+	reflect "reflect"
+
+	session "github.com/danielbukowski/recipe-app-backend/internal/session"
+	user "github.com/danielbukowski/recipe-app-backend/internal/user"
+	webauthn "github.com/danielbukowski/recipe-app-backend/internal/webauthn"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProfileService is a mock of profileService interface.
+type MockProfileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockProfileServiceMockRecorder is the mock recorder for MockProfileService.
+type MockProfileServiceMockRecorder struct {
+	mock *MockProfileService
+}
+
+// NewMockProfileService creates a new mock instance.
+func NewMockProfileService(ctrl *gomock.Controller) *MockProfileService {
+	mock := &MockProfileService{ctrl: ctrl}
+	mock.recorder = &MockProfileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileService) EXPECT() *MockProfileServiceMockRecorder {
+	return m.recorder
+}
+
+// BeginPasskeyRegistration mocks base method.
+func (m *MockProfileService) BeginPasskeyRegistration(arg0 context.Context, arg1 uuid.UUID) (webauthn.CreationOptions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginPasskeyRegistration", arg0, arg1)
+	ret0, _ := ret[0].(webauthn.CreationOptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginPasskeyRegistration indicates an expected call of BeginPasskeyRegistration.
+func (mr *MockProfileServiceMockRecorder) BeginPasskeyRegistration(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginPasskeyRegistration", reflect.TypeOf((*MockProfileService)(nil).BeginPasskeyRegistration), arg0, arg1)
+}
+
+// ConfirmTwoFactor mocks base method.
+func (m *MockProfileService) ConfirmTwoFactor(arg0 context.Context, arg1 uuid.UUID, arg2 user.TwoFactorCodeRequest) (user.RecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(user.RecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockProfileServiceMockRecorder) ConfirmTwoFactor(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockProfileService)(nil).ConfirmTwoFactor), arg0, arg1, arg2)
+}
+
+// DeletePasskey mocks base method.
+func (m *MockProfileService) DeletePasskey(ctx context.Context, userId, passkeyId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasskey", ctx, userId, passkeyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePasskey indicates an expected call of DeletePasskey.
+func (mr *MockProfileServiceMockRecorder) DeletePasskey(ctx, userId, passkeyId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasskey", reflect.TypeOf((*MockProfileService)(nil).DeletePasskey), ctx, userId, passkeyId)
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockProfileService) DisableTwoFactor(arg0 context.Context, arg1 uuid.UUID, arg2 user.TwoFactorCodeRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockProfileServiceMockRecorder) DisableTwoFactor(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockProfileService)(nil).DisableTwoFactor), arg0, arg1, arg2)
+}
+
+// EnrollTwoFactor mocks base method.
+func (m *MockProfileService) EnrollTwoFactor(arg0 context.Context, arg1 uuid.UUID) (user.TwoFactorEnrollmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(user.TwoFactorEnrollmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockProfileServiceMockRecorder) EnrollTwoFactor(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockProfileService)(nil).EnrollTwoFactor), arg0, arg1)
+}
+
+// FinishPasskeyRegistration mocks base method.
+func (m *MockProfileService) FinishPasskeyRegistration(ctx context.Context, userId uuid.UUID, challenge []byte, registerPasskeyRequest user.RegisterPasskeyRequest) (user.PasskeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishPasskeyRegistration", ctx, userId, challenge, registerPasskeyRequest)
+	ret0, _ := ret[0].(user.PasskeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishPasskeyRegistration indicates an expected call of FinishPasskeyRegistration.
+func (mr *MockProfileServiceMockRecorder) FinishPasskeyRegistration(ctx, userId, challenge, registerPasskeyRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishPasskeyRegistration", reflect.TypeOf((*MockProfileService)(nil).FinishPasskeyRegistration), ctx, userId, challenge, registerPasskeyRequest)
+}
+
+// GetProfile mocks base method.
+func (m *MockProfileService) GetProfile(arg0 context.Context, arg1 uuid.UUID) (user.ProfileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", arg0, arg1)
+	ret0, _ := ret[0].(user.ProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockProfileServiceMockRecorder) GetProfile(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockProfileService)(nil).GetProfile), arg0, arg1)
+}
+
+// ListPasskeys mocks base method.
+func (m *MockProfileService) ListPasskeys(arg0 context.Context, arg1 uuid.UUID) ([]user.PasskeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPasskeys", arg0, arg1)
+	ret0, _ := ret[0].([]user.PasskeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPasskeys indicates an expected call of ListPasskeys.
+func (mr *MockProfileServiceMockRecorder) ListPasskeys(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPasskeys", reflect.TypeOf((*MockProfileService)(nil).ListPasskeys), arg0, arg1)
+}
+
+// ResendVerificationEmail mocks base method.
+func (m *MockProfileService) ResendVerificationEmail(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerificationEmail indicates an expected call of ResendVerificationEmail.
+func (mr *MockProfileServiceMockRecorder) ResendVerificationEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationEmail", reflect.TypeOf((*MockProfileService)(nil).ResendVerificationEmail), arg0, arg1)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockProfileService) UpdateProfile(arg0 context.Context, arg1 uuid.UUID, arg2 user.UpdateProfileRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockProfileServiceMockRecorder) UpdateProfile(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockProfileService)(nil).UpdateProfile), arg0, arg1, arg2)
+}
+
+// MockSessionStorage is a mock of sessionStorage interface.
+type MockSessionStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionStorageMockRecorder is the mock recorder for MockSessionStorage.
+type MockSessionStorageMockRecorder struct {
+	mock *MockSessionStorage
+}
+
+// NewMockSessionStorage creates a new mock instance.
+func NewMockSessionStorage(ctrl *gomock.Controller) *MockSessionStorage {
+	mock := &MockSessionStorage{ctrl: ctrl}
+	mock.recorder = &MockSessionStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionStorage) EXPECT() *MockSessionStorageMockRecorder {
+	return m.recorder
+}
+
+// SaveChallenge mocks base method.
+func (m *MockSessionStorage) SaveChallenge(challenge []byte, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChallenge", challenge, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChallenge indicates an expected call of SaveChallenge.
+func (mr *MockSessionStorageMockRecorder) SaveChallenge(challenge, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChallenge", reflect.TypeOf((*MockSessionStorage)(nil).SaveChallenge), challenge, userId)
+}
+
+// TakeChallenge mocks base method.
+func (m *MockSessionStorage) TakeChallenge(challenge []byte) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeChallenge", challenge)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeChallenge indicates an expected call of TakeChallenge.
+func (mr *MockSessionStorageMockRecorder) TakeChallenge(challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeChallenge", reflect.TypeOf((*MockSessionStorage)(nil).TakeChallenge), challenge)
+}
+
+// UpdateAllOfUser mocks base method.
+func (m *MockSessionStorage) UpdateAllOfUser(userId uuid.UUID, update func(*session.Session)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAllOfUser", userId, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAllOfUser indicates an expected call of UpdateAllOfUser.
+func (mr *MockSessionStorageMockRecorder) UpdateAllOfUser(userId, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAllOfUser", reflect.TypeOf((*MockSessionStorage)(nil).UpdateAllOfUser), userId, update)
+}
@@ -9,17 +9,186 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Comment struct {
+	CommentID uuid.UUID
+	RecipeID  uuid.UUID
+	UserID    uuid.UUID
+	ParentID  pgtype.UUID
+	Body      string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+	DeletedAt pgtype.Timestamp
+}
+
+type CommentMention struct {
+	CommentID uuid.UUID
+	Handle    string
+	UserID    pgtype.UUID
+}
+
+type Cookbook struct {
+	CookbookID  uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	IsPublic    bool
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+type CookbookRecipe struct {
+	CookbookID uuid.UUID
+	RecipeID   uuid.UUID
+	Position   int32
+	AddedAt    pgtype.Timestamp
+}
+
+type EmailVerificationToken struct {
+	TokenID   uuid.UUID
+	UserID    uuid.UUID
+	TokenHash []byte
+	ExpiresAt pgtype.Timestamp
+	UsedAt    pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
+}
+
+type Favorite struct {
+	UserID    uuid.UUID
+	RecipeID  uuid.UUID
+	CreatedAt pgtype.Timestamp
+}
+
+type Passkey struct {
+	PasskeyID    uuid.UUID
+	UserID       uuid.UUID
+	CredentialID []byte
+	PublicKey    []byte
+	SignCount    int64
+	Aaguid       []byte
+	Transports   []string
+	Name         string
+	CreatedAt    pgtype.Timestamp
+	LastUsedAt   pgtype.Timestamp
+}
+
+type PasswordResetToken struct {
+	TokenID   uuid.UUID
+	UserID    uuid.UUID
+	TokenHash []byte
+	ExpiresAt pgtype.Timestamp
+	UsedAt    pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
+}
+
 type Recipe struct {
+	RecipeID         uuid.UUID
+	Title            string
+	Content          string
+	CreatedAt        pgtype.Timestamp
+	UpdatedAt        pgtype.Timestamp
+	UserID           uuid.UUID
+	SearchVector     interface{}
+	Servings         int32
+	RatingSum        int32
+	RatingCount      int32
+	FavoriteCount    int32
+	DeletedAt        pgtype.Timestamp
+	Status           string
+	PublishAt        pgtype.Timestamp
+	Visibility       string
+	ForkedFrom       pgtype.UUID
+	ForkedFromUserID pgtype.UUID
+	ForkedFromTitle  pgtype.Text
+}
+
+type RecipeImage struct {
+	ImageID     uuid.UUID
+	RecipeID    uuid.UUID
+	ObjectKey   string
+	ContentType string
+	SizeBytes   int64
+	CreatedAt   pgtype.Timestamp
+}
+
+type RecipeIngredient struct {
+	RecipeID uuid.UUID
+	Position int32
+	Quantity pgtype.Float8
+	Unit     string
+	Name     string
+	Note     string
+}
+
+type RecipeReview struct {
 	RecipeID  uuid.UUID
-	Title     string
-	Content   string
+	UserID    uuid.UUID
+	Rating    int16
+	Body      string
 	CreatedAt pgtype.Timestamp
 	UpdatedAt pgtype.Timestamp
 }
 
-type User struct {
+type RecipeRevision struct {
+	RecipeID       uuid.UUID
+	RevisionNumber int32
+	Title          string
+	Content        string
+	Servings       int32
+	Ingredients    []byte
+	Tags           []string
+	CreatedAt      pgtype.Timestamp
+	Steps          []byte
+	Visibility     pgtype.Text
+}
+
+type RecipeShareLink struct {
+	ShareLinkID    uuid.UUID
+	RecipeID       uuid.UUID
+	TokenHash      []byte
+	ExpiresAt      pgtype.Timestamp
+	RevokedAt      pgtype.Timestamp
+	ViewCount      int32
+	LastAccessedAt pgtype.Timestamp
+	CreatedAt      pgtype.Timestamp
+}
+
+type RecipeStep struct {
+	StepID          uuid.UUID
+	RecipeID        uuid.UUID
+	Position        int32
+	Instruction     string
+	DurationMinutes pgtype.Int4
+	Temperature     pgtype.Int4
+	TemperatureUnit pgtype.Text
+}
+
+type RecipeTag struct {
+	RecipeID uuid.UUID
+	TagID    uuid.UUID
+}
+
+type Tag struct {
+	TagID    uuid.UUID
+	Name     string
+	Category string
+}
+
+type TwoFactorRecoveryCode struct {
 	UserID    uuid.UUID
-	Email     string
-	Password  string
+	CodeHash  []byte
+	UsedAt    pgtype.Timestamp
 	CreatedAt pgtype.Timestamp
 }
+
+type User struct {
+	UserID            uuid.UUID
+	Email             string
+	Password          string
+	CreatedAt         pgtype.Timestamp
+	MeasurementSystem pgtype.Text
+	EmailVerifiedAt   pgtype.Timestamp
+	TotpSecret        pgtype.Text
+	TotpEnabledAt     pgtype.Timestamp
+	TotpLastCounter   pgtype.Int8
+	SessionVersion    int32
+}
@@ -16,19 +16,32 @@ const createRecipe = `-- name: CreateRecipe :one
 INSERT INTO recipes (
     recipe_id, 
     title, 
-    content
-) VALUES ($1, $2, $3)
+    content,
+    user_id,
+    servings,
+    visibility
+) VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING recipe_id
 `
 
 type CreateRecipeParams struct {
-	RecipeID uuid.UUID
-	Title    string
-	Content  string
+	RecipeID   uuid.UUID
+	Title      string
+	Content    string
+	UserID     uuid.UUID
+	Servings   int32
+	Visibility string
 }
 
 func (q *Queries) CreateRecipe(ctx context.Context, arg CreateRecipeParams) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, createRecipe, arg.RecipeID, arg.Title, arg.Content)
+	row := q.db.QueryRow(ctx, createRecipe,
+		arg.RecipeID,
+		arg.Title,
+		arg.Content,
+		arg.UserID,
+		arg.Servings,
+		arg.Visibility,
+	)
 	var recipe_id uuid.UUID
 	err := row.Scan(&recipe_id)
 	return recipe_id, err
@@ -44,28 +57,758 @@ func (q *Queries) DeleteRecipeById(ctx context.Context, recipeID uuid.UUID) erro
 	return err
 }
 
+const forkRecipe = `-- name: ForkRecipe :one
+INSERT INTO recipes (
+    recipe_id,
+    title,
+    content,
+    user_id,
+    servings,
+    visibility,
+    forked_from,
+    forked_from_user_id,
+    forked_from_title
+)
+SELECT $1, title, content, $2, servings, visibility, recipe_id, user_id, title FROM recipes
+    WHERE recipe_id = $3 AND deleted_at IS NULL
+RETURNING recipe_id
+`
+
+type ForkRecipeParams struct {
+	ForkID   uuid.UUID
+	UserID   uuid.UUID
+	RecipeID uuid.UUID
+}
+
+func (q *Queries) ForkRecipe(ctx context.Context, arg ForkRecipeParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, forkRecipe, arg.ForkID, arg.UserID, arg.RecipeID)
+	var recipe_id uuid.UUID
+	err := row.Scan(&recipe_id)
+	return recipe_id, err
+}
+
 const getRecipeById = `-- name: GetRecipeById :one
-SELECT recipe_id, title, content, created_at, updated_at FROM recipes
-    WHERE recipe_id = $1 LIMIT 1
+SELECT recipe_id, user_id, title, content, servings, status, publish_at, visibility, rating_sum, rating_count, favorite_count,
+    forked_from, forked_from_user_id, forked_from_title,
+    (SELECT COUNT(*) FROM recipes f WHERE f.forked_from = recipes.recipe_id AND f.deleted_at IS NULL)::int AS fork_count,
+    created_at, updated_at FROM recipes
+    WHERE recipe_id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
-func (q *Queries) GetRecipeById(ctx context.Context, recipeID uuid.UUID) (Recipe, error) {
+type GetRecipeByIdRow struct {
+	RecipeID         uuid.UUID
+	UserID           uuid.UUID
+	Title            string
+	Content          string
+	Servings         int32
+	Status           string
+	PublishAt        pgtype.Timestamp
+	Visibility       string
+	RatingSum        int32
+	RatingCount      int32
+	FavoriteCount    int32
+	ForkedFrom       pgtype.UUID
+	ForkedFromUserID pgtype.UUID
+	ForkedFromTitle  pgtype.Text
+	ForkCount        int32
+	CreatedAt        pgtype.Timestamp
+	UpdatedAt        pgtype.Timestamp
+}
+
+func (q *Queries) GetRecipeById(ctx context.Context, recipeID uuid.UUID) (GetRecipeByIdRow, error) {
 	row := q.db.QueryRow(ctx, getRecipeById, recipeID)
-	var i Recipe
+	var i GetRecipeByIdRow
 	err := row.Scan(
 		&i.RecipeID,
+		&i.UserID,
 		&i.Title,
 		&i.Content,
+		&i.Servings,
+		&i.Status,
+		&i.PublishAt,
+		&i.Visibility,
+		&i.RatingSum,
+		&i.RatingCount,
+		&i.FavoriteCount,
+		&i.ForkedFrom,
+		&i.ForkedFromUserID,
+		&i.ForkedFromTitle,
+		&i.ForkCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const updateRecipeById = `-- name: UpdateRecipeById :exec
+const listDeletedRecipes = `-- name: ListDeletedRecipes :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at, deleted_at FROM recipes
+    WHERE user_id = $1 AND deleted_at IS NOT NULL
+    AND ($2::uuid IS NULL OR (deleted_at, recipe_id) < ($3::timestamp, $2::uuid))
+    ORDER BY deleted_at DESC, recipe_id DESC
+    LIMIT $4
+`
+
+type ListDeletedRecipesParams struct {
+	UserID          uuid.UUID
+	CursorID        pgtype.UUID
+	CursorDeletedAt pgtype.Timestamp
+	RowLimit        int32
+}
+
+type ListDeletedRecipesRow struct {
+	RecipeID      uuid.UUID
+	UserID        uuid.UUID
+	Title         string
+	FavoriteCount int32
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	DeletedAt     pgtype.Timestamp
+}
+
+func (q *Queries) ListDeletedRecipes(ctx context.Context, arg ListDeletedRecipesParams) ([]ListDeletedRecipesRow, error) {
+	rows, err := q.db.Query(ctx, listDeletedRecipes,
+		arg.UserID,
+		arg.CursorID,
+		arg.CursorDeletedAt,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDeletedRecipesRow
+	for rows.Next() {
+		var i ListDeletedRecipesRow
+		if err := rows.Scan(
+			&i.RecipeID,
+			&i.UserID,
+			&i.Title,
+			&i.FavoriteCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableRecipes = `-- name: ListPurgeableRecipes :many
+SELECT recipe_id FROM recipes
+    WHERE deleted_at < $1::timestamp
+    ORDER BY deleted_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+`
+
+type ListPurgeableRecipesParams struct {
+	DeletedBefore pgtype.Timestamp
+	RowLimit      int32
+}
+
+func (q *Queries) ListPurgeableRecipes(ctx context.Context, arg ListPurgeableRecipesParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listPurgeableRecipes, arg.DeletedBefore, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var recipe_id uuid.UUID
+		if err := rows.Scan(&recipe_id); err != nil {
+			return nil, err
+		}
+		items = append(items, recipe_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipeForks = `-- name: ListRecipeForks :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at FROM recipes
+    WHERE forked_from = $1 AND deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+    AND ($2::uuid IS NULL OR (created_at, recipe_id) < ($3::timestamp, $2::uuid))
+    ORDER BY created_at DESC, recipe_id DESC
+    LIMIT $4
+`
+
+type ListRecipeForksParams struct {
+	ForkedFrom      pgtype.UUID
+	CursorID        pgtype.UUID
+	CursorCreatedAt pgtype.Timestamp
+	RowLimit        int32
+}
+
+type ListRecipeForksRow struct {
+	RecipeID      uuid.UUID
+	UserID        uuid.UUID
+	Title         string
+	FavoriteCount int32
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) ListRecipeForks(ctx context.Context, arg ListRecipeForksParams) ([]ListRecipeForksRow, error) {
+	rows, err := q.db.Query(ctx, listRecipeForks,
+		arg.ForkedFrom,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecipeForksRow
+	for rows.Next() {
+		var i ListRecipeForksRow
+		if err := rows.Scan(
+			&i.RecipeID,
+			&i.UserID,
+			&i.Title,
+			&i.FavoriteCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesByCreatedAt = `-- name: ListRecipesByCreatedAt :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at FROM recipes
+    WHERE deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+    AND ($1::uuid IS NULL OR user_id = $1::uuid)
+    AND ($2::timestamp IS NULL OR created_at >= $2::timestamp)
+    AND ($3::timestamp IS NULL OR created_at < $3::timestamp)
+    AND (COALESCE(cardinality($4::text[]), 0) = 0 OR (
+        SELECT COUNT(*) FROM recipe_tags rt
+            JOIN tags t ON t.tag_id = rt.tag_id
+            WHERE rt.recipe_id = recipes.recipe_id AND t.name = ANY($4::text[])
+    ) >= CASE WHEN $5::boolean THEN cardinality($4::text[]) ELSE 1 END)
+    AND ($6::uuid IS NULL OR (created_at, recipe_id) < ($7::timestamp, $6::uuid))
+    ORDER BY created_at DESC, recipe_id DESC
+    LIMIT $8
+`
+
+type ListRecipesByCreatedAtParams struct {
+	AuthorID        pgtype.UUID
+	CreatedAfter    pgtype.Timestamp
+	CreatedBefore   pgtype.Timestamp
+	Tags            []string
+	MatchAllTags    bool
+	CursorID        pgtype.UUID
+	CursorCreatedAt pgtype.Timestamp
+	RowLimit        int32
+}
+
+type ListRecipesByCreatedAtRow struct {
+	RecipeID      uuid.UUID
+	UserID        uuid.UUID
+	Title         string
+	FavoriteCount int32
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) ListRecipesByCreatedAt(ctx context.Context, arg ListRecipesByCreatedAtParams) ([]ListRecipesByCreatedAtRow, error) {
+	rows, err := q.db.Query(ctx, listRecipesByCreatedAt,
+		arg.AuthorID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Tags,
+		arg.MatchAllTags,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecipesByCreatedAtRow
+	for rows.Next() {
+		var i ListRecipesByCreatedAtRow
+		if err := rows.Scan(
+			&i.RecipeID,
+			&i.UserID,
+			&i.Title,
+			&i.FavoriteCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesByFavoriteCount = `-- name: ListRecipesByFavoriteCount :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at FROM recipes
+    WHERE deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+    AND ($1::uuid IS NULL OR user_id = $1::uuid)
+    AND ($2::timestamp IS NULL OR created_at >= $2::timestamp)
+    AND ($3::timestamp IS NULL OR created_at < $3::timestamp)
+    AND (COALESCE(cardinality($4::text[]), 0) = 0 OR (
+        SELECT COUNT(*) FROM recipe_tags rt
+            JOIN tags t ON t.tag_id = rt.tag_id
+            WHERE rt.recipe_id = recipes.recipe_id AND t.name = ANY($4::text[])
+    ) >= CASE WHEN $5::boolean THEN cardinality($4::text[]) ELSE 1 END)
+    AND ($6::uuid IS NULL OR (favorite_count, recipe_id) < ($7::int, $6::uuid))
+    ORDER BY favorite_count DESC, recipe_id DESC
+    LIMIT $8
+`
+
+type ListRecipesByFavoriteCountParams struct {
+	AuthorID            pgtype.UUID
+	CreatedAfter        pgtype.Timestamp
+	CreatedBefore       pgtype.Timestamp
+	Tags                []string
+	MatchAllTags        bool
+	CursorID            pgtype.UUID
+	CursorFavoriteCount pgtype.Int4
+	RowLimit            int32
+}
+
+type ListRecipesByFavoriteCountRow struct {
+	RecipeID      uuid.UUID
+	UserID        uuid.UUID
+	Title         string
+	FavoriteCount int32
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) ListRecipesByFavoriteCount(ctx context.Context, arg ListRecipesByFavoriteCountParams) ([]ListRecipesByFavoriteCountRow, error) {
+	rows, err := q.db.Query(ctx, listRecipesByFavoriteCount,
+		arg.AuthorID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Tags,
+		arg.MatchAllTags,
+		arg.CursorID,
+		arg.CursorFavoriteCount,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecipesByFavoriteCountRow
+	for rows.Next() {
+		var i ListRecipesByFavoriteCountRow
+		if err := rows.Scan(
+			&i.RecipeID,
+			&i.UserID,
+			&i.Title,
+			&i.FavoriteCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesByTitle = `-- name: ListRecipesByTitle :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at FROM recipes
+    WHERE deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+    AND ($1::uuid IS NULL OR user_id = $1::uuid)
+    AND ($2::timestamp IS NULL OR created_at >= $2::timestamp)
+    AND ($3::timestamp IS NULL OR created_at < $3::timestamp)
+    AND (COALESCE(cardinality($4::text[]), 0) = 0 OR (
+        SELECT COUNT(*) FROM recipe_tags rt
+            JOIN tags t ON t.tag_id = rt.tag_id
+            WHERE rt.recipe_id = recipes.recipe_id AND t.name = ANY($4::text[])
+    ) >= CASE WHEN $5::boolean THEN cardinality($4::text[]) ELSE 1 END)
+    AND ($6::uuid IS NULL OR (title, recipe_id) > ($7::text, $6::uuid))
+    ORDER BY title ASC, recipe_id ASC
+    LIMIT $8
+`
+
+type ListRecipesByTitleParams struct {
+	AuthorID      pgtype.UUID
+	CreatedAfter  pgtype.Timestamp
+	CreatedBefore pgtype.Timestamp
+	Tags          []string
+	MatchAllTags  bool
+	CursorID      pgtype.UUID
+	CursorTitle   pgtype.Text
+	RowLimit      int32
+}
+
+type ListRecipesByTitleRow struct {
+	RecipeID      uuid.UUID
+	UserID        uuid.UUID
+	Title         string
+	FavoriteCount int32
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) ListRecipesByTitle(ctx context.Context, arg ListRecipesByTitleParams) ([]ListRecipesByTitleRow, error) {
+	rows, err := q.db.Query(ctx, listRecipesByTitle,
+		arg.AuthorID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Tags,
+		arg.MatchAllTags,
+		arg.CursorID,
+		arg.CursorTitle,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecipesByTitleRow
+	for rows.Next() {
+		var i ListRecipesByTitleRow
+		if err := rows.Scan(
+			&i.RecipeID,
+			&i.UserID,
+			&i.Title,
+			&i.FavoriteCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesByUpdatedAt = `-- name: ListRecipesByUpdatedAt :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at FROM recipes
+    WHERE deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+    AND ($1::uuid IS NULL OR user_id = $1::uuid)
+    AND ($2::timestamp IS NULL OR created_at >= $2::timestamp)
+    AND ($3::timestamp IS NULL OR created_at < $3::timestamp)
+    AND (COALESCE(cardinality($4::text[]), 0) = 0 OR (
+        SELECT COUNT(*) FROM recipe_tags rt
+            JOIN tags t ON t.tag_id = rt.tag_id
+            WHERE rt.recipe_id = recipes.recipe_id AND t.name = ANY($4::text[])
+    ) >= CASE WHEN $5::boolean THEN cardinality($4::text[]) ELSE 1 END)
+    AND ($6::uuid IS NULL OR (updated_at, recipe_id) < ($7::timestamp, $6::uuid))
+    ORDER BY updated_at DESC, recipe_id DESC
+    LIMIT $8
+`
+
+type ListRecipesByUpdatedAtParams struct {
+	AuthorID        pgtype.UUID
+	CreatedAfter    pgtype.Timestamp
+	CreatedBefore   pgtype.Timestamp
+	Tags            []string
+	MatchAllTags    bool
+	CursorID        pgtype.UUID
+	CursorUpdatedAt pgtype.Timestamp
+	RowLimit        int32
+}
+
+type ListRecipesByUpdatedAtRow struct {
+	RecipeID      uuid.UUID
+	UserID        uuid.UUID
+	Title         string
+	FavoriteCount int32
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) ListRecipesByUpdatedAt(ctx context.Context, arg ListRecipesByUpdatedAtParams) ([]ListRecipesByUpdatedAtRow, error) {
+	rows, err := q.db.Query(ctx, listRecipesByUpdatedAt,
+		arg.AuthorID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Tags,
+		arg.MatchAllTags,
+		arg.CursorID,
+		arg.CursorUpdatedAt,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecipesByUpdatedAtRow
+	for rows.Next() {
+		var i ListRecipesByUpdatedAtRow
+		if err := rows.Scan(
+			&i.RecipeID,
+			&i.UserID,
+			&i.Title,
+			&i.FavoriteCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesByUserId = `-- name: ListRecipesByUserId :many
+SELECT recipe_id, user_id, title, favorite_count, created_at, updated_at, status, publish_at, visibility FROM recipes
+    WHERE user_id = $1 AND deleted_at IS NULL
+    AND ($2::text IS NULL OR status = $2::text)
+    AND ($3::uuid IS NULL OR (updated_at, recipe_id) < ($4::timestamp, $3::uuid))
+    ORDER BY updated_at DESC, recipe_id DESC
+    LIMIT $5
+`
+
+type ListRecipesByUserIdParams struct {
+	UserID          uuid.UUID
+	Status          pgtype.Text
+	CursorID        pgtype.UUID
+	CursorUpdatedAt pgtype.Timestamp
+	RowLimit        int32
+}
+
+type ListRecipesByUserIdRow struct {
+	RecipeID      uuid.UUID
+	UserID        uuid.UUID
+	Title         string
+	FavoriteCount int32
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	Status        string
+	PublishAt     pgtype.Timestamp
+	Visibility    string
+}
+
+func (q *Queries) ListRecipesByUserId(ctx context.Context, arg ListRecipesByUserIdParams) ([]ListRecipesByUserIdRow, error) {
+	rows, err := q.db.Query(ctx, listRecipesByUserId,
+		arg.UserID,
+		arg.Status,
+		arg.CursorID,
+		arg.CursorUpdatedAt,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecipesByUserIdRow
+	for rows.Next() {
+		var i ListRecipesByUserIdRow
+		if err := rows.Scan(
+			&i.RecipeID,
+			&i.UserID,
+			&i.Title,
+			&i.FavoriteCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.Visibility,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const publishRecipe = `-- name: PublishRecipe :execrows
 UPDATE recipes
-    SET title = $3, content = $4, updated_at = $5
-    WHERE recipe_id = $1 AND updated_at = $2
+    SET status = $2, publish_at = $3
+    WHERE recipe_id = $1 AND deleted_at IS NULL AND status <> 'published'
+`
+
+type PublishRecipeParams struct {
+	RecipeID  uuid.UUID
+	Status    string
+	PublishAt pgtype.Timestamp
+}
+
+func (q *Queries) PublishRecipe(ctx context.Context, arg PublishRecipeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, publishRecipe, arg.RecipeID, arg.Status, arg.PublishAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const publishScheduledRecipes = `-- name: PublishScheduledRecipes :execrows
+UPDATE recipes
+    SET status = 'published'
+    WHERE status = 'scheduled' AND publish_at <= NOW()
+`
+
+func (q *Queries) PublishScheduledRecipes(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, publishScheduledRecipes)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreRecipeById = `-- name: RestoreRecipeById :execrows
+UPDATE recipes
+    SET deleted_at = NULL
+    WHERE recipe_id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+`
+
+type RestoreRecipeByIdParams struct {
+	RecipeID uuid.UUID
+	UserID   uuid.UUID
+}
+
+func (q *Queries) RestoreRecipeById(ctx context.Context, arg RestoreRecipeByIdParams) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreRecipeById, arg.RecipeID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const searchRecipes = `-- name: SearchRecipes :many
+WITH matched_recipes AS (
+    SELECT recipe_id, user_id, title, content, created_at, updated_at,
+        ts_rank(search_vector, websearch_to_tsquery('english', $1::text))::float8 AS rank
+    FROM recipes
+    WHERE search_vector @@ websearch_to_tsquery('english', $1::text)
+    AND deleted_at IS NULL AND status = 'published' AND visibility = 'public'
+), recipes_page AS (
+    SELECT recipe_id, user_id, title, content, created_at, updated_at, rank FROM matched_recipes
+    WHERE ($2::uuid IS NULL OR (rank, recipe_id) < ($3::float8, $2::uuid))
+    ORDER BY rank DESC, recipe_id DESC
+    LIMIT $4
+)
+SELECT recipe_id, user_id, title, created_at, updated_at, rank,
+    ts_headline('english', title, websearch_to_tsquery('english', $1::text),
+        $5::text)::text AS title_headline,
+    ts_headline('english', content, websearch_to_tsquery('english', $1::text),
+        $6::text)::text AS content_headline
+FROM recipes_page
+ORDER BY rank DESC, recipe_id DESC
+`
+
+type SearchRecipesParams struct {
+	Query                  string
+	CursorID               pgtype.UUID
+	CursorRank             pgtype.Float8
+	RowLimit               int32
+	TitleHeadlineOptions   string
+	ContentHeadlineOptions string
+}
+
+type SearchRecipesRow struct {
+	RecipeID        uuid.UUID
+	UserID          uuid.UUID
+	Title           string
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+	Rank            float64
+	TitleHeadline   string
+	ContentHeadline string
+}
+
+func (q *Queries) SearchRecipes(ctx context.Context, arg SearchRecipesParams) ([]SearchRecipesRow, error) {
+	rows, err := q.db.Query(ctx, searchRecipes,
+		arg.Query,
+		arg.CursorID,
+		arg.CursorRank,
+		arg.RowLimit,
+		arg.TitleHeadlineOptions,
+		arg.ContentHeadlineOptions,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchRecipesRow
+	for rows.Next() {
+		var i SearchRecipesRow
+		if err := rows.Scan(
+			&i.RecipeID,
+			&i.UserID,
+			&i.Title,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Rank,
+			&i.TitleHeadline,
+			&i.ContentHeadline,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteRecipeById = `-- name: SoftDeleteRecipeById :execrows
+UPDATE recipes
+    SET deleted_at = NOW()
+    WHERE recipe_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteRecipeById(ctx context.Context, recipeID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteRecipeById, recipeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const touchRecipe = `-- name: TouchRecipe :execrows
+UPDATE recipes
+    SET updated_at = $3
+    WHERE recipe_id = $1 AND updated_at = $2 AND deleted_at IS NULL
+`
+
+type TouchRecipeParams struct {
+	RecipeID     uuid.UUID
+	UpdatedAt    pgtype.Timestamp
+	NewUpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) TouchRecipe(ctx context.Context, arg TouchRecipeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, touchRecipe, arg.RecipeID, arg.UpdatedAt, arg.NewUpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateRecipeById = `-- name: UpdateRecipeById :execrows
+UPDATE recipes
+    SET title = $3, content = $4, servings = $5, visibility = $6, updated_at = $7
+    WHERE recipe_id = $1 AND updated_at = $2 AND deleted_at IS NULL
 `
 
 type UpdateRecipeByIdParams struct {
@@ -73,16 +816,23 @@ type UpdateRecipeByIdParams struct {
 	UpdatedAt    pgtype.Timestamp
 	Title        string
 	Content      string
+	Servings     int32
+	Visibility   string
 	NewUpdatedAt pgtype.Timestamp
 }
 
-func (q *Queries) UpdateRecipeById(ctx context.Context, arg UpdateRecipeByIdParams) error {
-	_, err := q.db.Exec(ctx, updateRecipeById,
+func (q *Queries) UpdateRecipeById(ctx context.Context, arg UpdateRecipeByIdParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateRecipeById,
 		arg.RecipeID,
 		arg.UpdatedAt,
 		arg.Title,
 		arg.Content,
+		arg.Servings,
+		arg.Visibility,
 		arg.NewUpdatedAt,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
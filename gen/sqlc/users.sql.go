@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createUser = `-- name: CreateUser :exec
@@ -30,19 +31,224 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 	return err
 }
 
+const disableUserTotp = `-- name: DisableUserTotp :exec
+UPDATE users
+    SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_counter = NULL
+    WHERE user_id = $1
+`
+
+func (q *Queries) DisableUserTotp(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, disableUserTotp, userID)
+	return err
+}
+
+const enableUserTotp = `-- name: EnableUserTotp :execrows
+UPDATE users
+    SET totp_enabled_at = NOW(), totp_last_counter = $2
+    WHERE user_id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL
+`
+
+type EnableUserTotpParams struct {
+	UserID          uuid.UUID
+	TotpLastCounter pgtype.Int8
+}
+
+func (q *Queries) EnableUserTotp(ctx context.Context, arg EnableUserTotpParams) (int64, error) {
+	result, err := q.db.Exec(ctx, enableUserTotp, arg.UserID, arg.TotpLastCounter)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT email, password FROM users 
+SELECT user_id, email, password, measurement_system, email_verified_at, totp_enabled_at, session_version FROM users 
     WHERE email = $1 LIMIT 1
 `
 
 type GetUserByEmailRow struct {
-	Email    string
-	Password string
+	UserID            uuid.UUID
+	Email             string
+	Password          string
+	MeasurementSystem pgtype.Text
+	EmailVerifiedAt   pgtype.Timestamp
+	TotpEnabledAt     pgtype.Timestamp
+	SessionVersion    int32
 }
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
 	row := q.db.QueryRow(ctx, getUserByEmail, email)
 	var i GetUserByEmailRow
-	err := row.Scan(&i.Email, &i.Password)
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.MeasurementSystem,
+		&i.EmailVerifiedAt,
+		&i.TotpEnabledAt,
+		&i.SessionVersion,
+	)
 	return i, err
 }
+
+const getUserById = `-- name: GetUserById :one
+SELECT user_id, email, measurement_system, email_verified_at, totp_enabled_at, session_version, created_at FROM users
+    WHERE user_id = $1 LIMIT 1
+`
+
+type GetUserByIdRow struct {
+	UserID            uuid.UUID
+	Email             string
+	MeasurementSystem pgtype.Text
+	EmailVerifiedAt   pgtype.Timestamp
+	TotpEnabledAt     pgtype.Timestamp
+	SessionVersion    int32
+	CreatedAt         pgtype.Timestamp
+}
+
+func (q *Queries) GetUserById(ctx context.Context, userID uuid.UUID) (GetUserByIdRow, error) {
+	row := q.db.QueryRow(ctx, getUserById, userID)
+	var i GetUserByIdRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.MeasurementSystem,
+		&i.EmailVerifiedAt,
+		&i.TotpEnabledAt,
+		&i.SessionVersion,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserSessionVersion = `-- name: GetUserSessionVersion :one
+SELECT session_version FROM users
+    WHERE user_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserSessionVersion(ctx context.Context, userID uuid.UUID) (int32, error) {
+	row := q.db.QueryRow(ctx, getUserSessionVersion, userID)
+	var session_version int32
+	err := row.Scan(&session_version)
+	return session_version, err
+}
+
+const getUserTotp = `-- name: GetUserTotp :one
+SELECT totp_secret, totp_enabled_at FROM users
+    WHERE user_id = $1 LIMIT 1
+`
+
+type GetUserTotpRow struct {
+	TotpSecret    pgtype.Text
+	TotpEnabledAt pgtype.Timestamp
+}
+
+func (q *Queries) GetUserTotp(ctx context.Context, userID uuid.UUID) (GetUserTotpRow, error) {
+	row := q.db.QueryRow(ctx, getUserTotp, userID)
+	var i GetUserTotpRow
+	err := row.Scan(&i.TotpSecret, &i.TotpEnabledAt)
+	return i, err
+}
+
+const lockUser = `-- name: LockUser :one
+SELECT user_id FROM users
+    WHERE user_id = $1
+    FOR UPDATE
+`
+
+func (q *Queries) LockUser(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, lockUser, userID)
+	var user_id uuid.UUID
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
+const setUserTotpSecret = `-- name: SetUserTotpSecret :execrows
+UPDATE users
+    SET totp_secret = $2
+    WHERE user_id = $1 AND totp_enabled_at IS NULL
+`
+
+type SetUserTotpSecretParams struct {
+	UserID     uuid.UUID
+	TotpSecret pgtype.Text
+}
+
+func (q *Queries) SetUserTotpSecret(ctx context.Context, arg SetUserTotpSecretParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserTotpSecret, arg.UserID, arg.TotpSecret)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateUserMeasurementSystem = `-- name: UpdateUserMeasurementSystem :execrows
+UPDATE users
+    SET measurement_system = $2
+    WHERE user_id = $1
+`
+
+type UpdateUserMeasurementSystemParams struct {
+	UserID            uuid.UUID
+	MeasurementSystem pgtype.Text
+}
+
+func (q *Queries) UpdateUserMeasurementSystem(ctx context.Context, arg UpdateUserMeasurementSystemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserMeasurementSystem, arg.UserID, arg.MeasurementSystem)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE users
+    SET password = $2, session_version = session_version + 1
+    WHERE user_id = $1
+RETURNING session_version
+`
+
+type UpdateUserPasswordParams struct {
+	UserID   uuid.UUID
+	Password string
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (int32, error) {
+	row := q.db.QueryRow(ctx, updateUserPassword, arg.UserID, arg.Password)
+	var session_version int32
+	err := row.Scan(&session_version)
+	return session_version, err
+}
+
+const useUserTotpCounter = `-- name: UseUserTotpCounter :execrows
+UPDATE users
+    SET totp_last_counter = $2
+    WHERE user_id = $1 AND totp_enabled_at IS NOT NULL AND (totp_last_counter IS NULL OR totp_last_counter < $2)
+`
+
+type UseUserTotpCounterParams struct {
+	UserID          uuid.UUID
+	TotpLastCounter pgtype.Int8
+}
+
+func (q *Queries) UseUserTotpCounter(ctx context.Context, arg UseUserTotpCounterParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useUserTotpCounter, arg.UserID, arg.TotpLastCounter)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const verifyUserEmail = `-- name: VerifyUserEmail :execrows
+UPDATE users
+    SET email_verified_at = COALESCE(email_verified_at, NOW())
+    WHERE user_id = $1
+`
+
+func (q *Queries) VerifyUserEmail(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, verifyUserEmail, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -5,40 +5,64 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
 	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/webauthn"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
-const (
-	defaultSessionExpirationTime = 86400 * 14
-)
-
 type handler struct {
 	userService       userService
 	logger            *zap.Logger
 	sessionStorage    sessionStorage
 	isDev             bool
 	sessionCookieName string
+	accountLimiter    signInLimiter
+	ipLimiter         signInLimiter
+	resetEmailLimiter requestLimiter
+	resetIPLimiter    requestLimiter
 }
 
 type userService interface {
 	CreateUser(context.Context, SignUpRequest) error
 	SignIn(ctx context.Context, signInRequest SignInRequest) (SignInResponse, error)
+	RequestPasswordReset(context.Context, ForgotPasswordRequest) error
+	ResetPassword(context.Context, ResetPasswordRequest) (ResetPasswordResponse, error)
+	VerifyEmail(context.Context, VerifyEmailRequest) (uuid.UUID, error)
+	VerifySecondFactor(context.Context, uuid.UUID, string) error
+	BeginPasskeySignIn() (webauthn.RequestOptions, error)
+	FinishPasskeySignIn(ctx context.Context, challenge []byte, credential webauthn.AssertionCredential) (SignInResponse, error)
 }
 
 type sessionStorage interface {
-	CreateNew(value []byte, expiration int32) (string, error)
+	CreateNew(userId uuid.UUID, value []byte, expiration int32) (string, error)
 	Delete(key string)
+	DeleteAllOfUser(userId uuid.UUID) error
+	SetSessionVersion(userId uuid.UUID, version int32) error
+	UpdateAllOfUser(userId uuid.UUID, update func(*session.Session)) error
+	CreatePending(value []byte) (string, error)
+	GetPending(pendingID string) ([]byte, error)
+	CountPendingAttempt(pendingID string) (uint64, error)
+	DeletePending(pendingID string)
+	SaveChallenge(challenge []byte, userId uuid.UUID) error
+	TakeChallenge(challenge []byte) (uuid.UUID, error)
 }
 
-func NewHandler(logger *zap.Logger, userService userService, sessionStorage sessionStorage, isDev bool, sessionCookieName string) *handler {
+// NewHandler returns the handler of authentication. Failed sign-ins are throttled per account by accountLimiter
+// and per IP address by ipLimiter, and password resets per email by resetEmailLimiter and per IP address by resetIPLimiter.
+func NewHandler(logger *zap.Logger, userService userService, sessionStorage sessionStorage, isDev bool, sessionCookieName string, accountLimiter, ipLimiter signInLimiter, resetEmailLimiter, resetIPLimiter requestLimiter) *handler {
 	return &handler{
 		userService:       userService,
 		logger:            logger,
 		sessionStorage:    sessionStorage,
 		isDev:             isDev,
 		sessionCookieName: sessionCookieName,
+		accountLimiter:    accountLimiter,
+		ipLimiter:         ipLimiter,
+		resetEmailLimiter: resetEmailLimiter,
+		resetIPLimiter:    resetIPLimiter,
 	}
 }
 
@@ -85,14 +109,19 @@ func (h *handler) SignUp(c echo.Context) error {
 //
 //	@Summary		Sign in
 //	@Description	Sign in to the app by providing an email and password.
+//	@Description	Users with two-factor authentication get a token instead of a session, which has to be sent with a code to /api/v1/auth/signin/2fa.
+//	@Description	Failed sign-ins of an account or an IP address make the next ones wait longer, until they are locked out for a while.
 //	@Tags			auth
 //
 //	@Accept			json
 //	@Produce		json
-//	@Param			SignInRequest	body		auth.SignInRequest					true	"Request body with email and password."
+//	@Param			SignInRequest	body		auth.SignInRequest										true	"Request body with email and password."
 //
-//	@Success		200				{object}	shared.CommonResponse				"Sign in successfully."
-//	@Failure		400				{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Success		200				{object}	shared.CommonResponse									"Sign in successfully."
+//	@Success		202				{object}	shared.DataResponse[auth.TwoFactorChallengeResponse]	"Password is correct, the code of the second factor is required."
+//	@Failure		400				{object}	validator.ValidationErrorResponse						"Invalid data provided."
+//	@Failure		401				{object}	shared.CommonResponse									"Email or password is invalid."
+//	@Failure		429				{object}	shared.CommonResponse									"Too many failed sign-ins, retry after the number of seconds in the Retry-After header."
 //
 //	@Router			/api/v1/auth/signin [POST]
 func (h *handler) SignIn(c echo.Context) error {
@@ -110,17 +139,45 @@ func (h *handler) SignIn(c echo.Context) error {
 		return err
 	}
 
+	if err := h.reserveSignIn(c, requestBody.Email); err != nil {
+		return err
+	}
+
 	signInResponse, err := h.userService.SignIn(c.Request().Context(), requestBody)
 	if err != nil {
+		if isUnauthorized(err) {
+			h.failSignIn(c, requestBody.Email)
+		} else {
+			h.releaseSignIn(c, requestBody.Email)
+		}
+
 		return err
 	}
 
+	// The password is not enough for users with two-factor authentication, so the session waits for a code.
+	// Failures of the account are kept until the code is correct, so the codes cannot be guessed by signing in again.
+	if signInResponse.TwoFactorEnabled {
+		h.releaseSignIn(c, requestBody.Email)
+		return h.startPendingSignIn(c, signInResponse)
+	}
+
+	h.resetSignInThrottle(c, requestBody.Email)
+
+	if err := h.startSession(c, signInResponse); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.CommonResponse{Message: "successfully sign in"})
+}
+
+// startSession saves a new session of the signed-in user and sets the session cookie.
+func (h *handler) startSession(c echo.Context, signInResponse SignInResponse) error {
 	jsonEncodedSession, err := json.Marshal(signInResponse)
 	if err != nil {
 		return err
 	}
 
-	sessionID, err := h.sessionStorage.CreateNew(jsonEncodedSession, defaultSessionExpirationTime)
+	sessionID, err := h.sessionStorage.CreateNew(signInResponse.UserID, jsonEncodedSession, session.DefaultExpirationTime)
 	if err != nil {
 		return err
 	}
@@ -129,7 +186,7 @@ func (h *handler) SignIn(c echo.Context) error {
 		Name:     h.sessionCookieName,
 		Value:    sessionID,
 		Path:     "/",
-		MaxAge:   defaultSessionExpirationTime,
+		MaxAge:   session.DefaultExpirationTime,
 		Secure:   !h.isDev,
 		HttpOnly: true,
 		SameSite: http.SameSiteLaxMode,
@@ -137,7 +194,7 @@ func (h *handler) SignIn(c echo.Context) error {
 
 	c.SetCookie(&cookie)
 
-	return c.JSON(http.StatusOK, shared.CommonResponse{Message: "successfully sign in"})
+	return nil
 }
 
 // SignOut godoc
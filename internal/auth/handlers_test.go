@@ -0,0 +1,362 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mock_auth "github.com/danielbukowski/recipe-app-backend/gen/_mocks/auth"
+	"github.com/danielbukowski/recipe-app-backend/internal/auth"
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/throttle"
+	"github.com/danielbukowski/recipe-app-backend/internal/validator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+// accountPolicy lets an account fail a few times without a delay and then locks it out,
+// so the number of sign-ins which reach the password check does not depend on timing.
+var accountPolicy = throttle.Policy{
+	FreeAttempts:    4,
+	LockoutAttempts: 5,
+	LockoutDuration: 15 * time.Minute,
+	Window:          time.Hour,
+}
+
+// ipPolicy never delays an IP address, so tests of accounts are not throttled by the IP address of httptest.
+var ipPolicy = throttle.Policy{
+	FreeAttempts: 1000,
+	Window:       time.Hour,
+}
+
+// resetPolicy lets a few password resets through and then rejects the rest for an hour.
+var resetPolicy = throttle.Policy{
+	LockoutAttempts: 2,
+	LockoutDuration: time.Hour,
+	Window:          time.Hour,
+}
+
+// newServer returns a server with the routes of authentication and fresh limiters,
+// so tests do not throttle each other.
+func newServer(userService *mock_auth.MockUserService, sessionStorage *mock_auth.MockSessionStorage) *http.Server {
+	store := throttle.NewMemory()
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	authHandler := auth.NewHandler(
+		zap.NewNop(),
+		userService,
+		sessionStorage,
+		true,
+		"SESSION_ID",
+		throttle.New(store, "account", accountPolicy),
+		throttle.New(store, "ip", ipPolicy),
+		throttle.New(store, "password_reset_email", resetPolicy),
+		throttle.New(store, "password_reset_ip", ipPolicy),
+	)
+	authHandler.RegisterRoutes(e)
+
+	return &http.Server{Handler: e}
+}
+
+// newJSONRequest returns a request with the JSON body.
+func newJSONRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Add("content-type", "application/json")
+
+	return req
+}
+
+func TestSignInHandlerHidesWhichCredentialIsWrong(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	userService := mock_auth.NewMockUserService(ctrl)
+
+	// The service returns the same error for an unknown email and a wrong password.
+	invalidCredentials := echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: auth.InvalidCredentialsMessage})
+
+	userService.EXPECT().
+		SignIn(gomock.Any(), auth.SignInRequest{Email: "unknown@mail.com", Password: "supersecretpassword"}).
+		Return(auth.SignInResponse{}, invalidCredentials)
+	userService.EXPECT().
+		SignIn(gomock.Any(), auth.SignInRequest{Email: "user@mail.com", Password: "wrongpassword"}).
+		Return(auth.SignInResponse{}, invalidCredentials)
+
+	server := newServer(userService, mock_auth.NewMockSessionStorage(ctrl))
+
+	// when
+	unknownEmailRec := httptest.NewRecorder()
+	server.Handler.ServeHTTP(unknownEmailRec, newJSONRequest(http.MethodPost, "/api/v1/auth/signin", `{"email": "unknown@mail.com", "password": "supersecretpassword"}`))
+
+	wrongPasswordRec := httptest.NewRecorder()
+	server.Handler.ServeHTTP(wrongPasswordRec, newJSONRequest(http.MethodPost, "/api/v1/auth/signin", `{"email": "user@mail.com", "password": "wrongpassword"}`))
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, unknownEmailRec.Code)
+	assert.Equal(t, unknownEmailRec.Code, wrongPasswordRec.Code)
+	assert.Equal(t, unknownEmailRec.Body.String(), wrongPasswordRec.Body.String())
+	assert.Contains(t, unknownEmailRec.Body.String(), auth.InvalidCredentialsMessage)
+}
+
+func TestSignInHandlerLocksOutAccount(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	userService := mock_auth.NewMockUserService(ctrl)
+
+	userService.EXPECT().
+		SignIn(gomock.Any(), gomock.Any()).
+		Return(auth.SignInResponse{}, echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: auth.InvalidCredentialsMessage})).
+		Times(int(accountPolicy.LockoutAttempts))
+
+	server := newServer(userService, mock_auth.NewMockSessionStorage(ctrl))
+
+	for range accountPolicy.LockoutAttempts {
+		rec := httptest.NewRecorder()
+		server.Handler.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/api/v1/auth/signin", `{"email": "user@mail.com", "password": "wrongpassword"}`))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+
+	// when
+	rec := httptest.NewRecorder()
+	server.Handler.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/api/v1/auth/signin", `{"email": "User@mail.com", "password": "supersecretpassword"}`))
+
+	// then
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "900", rec.Header().Get("Retry-After"))
+}
+
+func TestSignInTwoFactorHandler(t *testing.T) {
+	userId := uuid.Must(uuid.NewV7())
+	pendingSession := []byte(`{"user_id": "` + userId.String() + `", "email": "user@mail.com"}`)
+
+	testCases := []struct {
+		name           string
+		setupMocks     func(userService *mock_auth.MockUserService, sessionStorage *mock_auth.MockSessionStorage)
+		wantStatusCode int
+	}{
+		{
+			name: "sign-in has expired",
+			setupMocks: func(userService *mock_auth.MockUserService, sessionStorage *mock_auth.MockSessionStorage) {
+				sessionStorage.EXPECT().GetPending("token").Return(nil, session.ErrPendingNotFound)
+			},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "too many codes cancel the sign-in before the code is verified",
+			setupMocks: func(userService *mock_auth.MockUserService, sessionStorage *mock_auth.MockSessionStorage) {
+				sessionStorage.EXPECT().GetPending("token").Return(pendingSession, nil)
+				sessionStorage.EXPECT().CountPendingAttempt("token").Return(uint64(6), nil)
+				sessionStorage.EXPECT().DeletePending("token")
+			},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "code is wrong or has already been used",
+			setupMocks: func(userService *mock_auth.MockUserService, sessionStorage *mock_auth.MockSessionStorage) {
+				sessionStorage.EXPECT().GetPending("token").Return(pendingSession, nil)
+				sessionStorage.EXPECT().CountPendingAttempt("token").Return(uint64(1), nil)
+				userService.EXPECT().
+					VerifySecondFactor(gomock.Any(), userId, "287082").
+					Return(echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "the two-factor code is invalid or has already been used"}))
+			},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "valid code",
+			setupMocks: func(userService *mock_auth.MockUserService, sessionStorage *mock_auth.MockSessionStorage) {
+				sessionStorage.EXPECT().GetPending("token").Return(pendingSession, nil)
+				sessionStorage.EXPECT().CountPendingAttempt("token").Return(uint64(1), nil)
+				userService.EXPECT().VerifySecondFactor(gomock.Any(), userId, "287082").Return(nil)
+				sessionStorage.EXPECT().DeletePending("token")
+				sessionStorage.EXPECT().CreateNew(userId, gomock.Any(), int32(session.DefaultExpirationTime)).Return("sessionID", nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			ctrl := gomock.NewController(t)
+			userService := mock_auth.NewMockUserService(ctrl)
+			sessionStorage := mock_auth.NewMockSessionStorage(ctrl)
+			tc.setupMocks(userService, sessionStorage)
+
+			server := newServer(userService, sessionStorage)
+			rec := httptest.NewRecorder()
+
+			// when
+			server.Handler.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/api/v1/auth/signin/2fa", `{"token": "token", "code": "287082"}`))
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestForgotPasswordHandlerLimitsResets(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	userService := mock_auth.NewMockUserService(ctrl)
+
+	userService.EXPECT().
+		RequestPasswordReset(gomock.Any(), auth.ForgotPasswordRequest{Email: "user@mail.com"}).
+		Return(nil).
+		Times(int(resetPolicy.LockoutAttempts))
+
+	server := newServer(userService, mock_auth.NewMockSessionStorage(ctrl))
+
+	for range resetPolicy.LockoutAttempts {
+		rec := httptest.NewRecorder()
+		server.Handler.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/api/v1/auth/password/forgot", `{"email": "user@mail.com"}`))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+	}
+
+	// when
+	rec := httptest.NewRecorder()
+	server.Handler.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/api/v1/auth/password/forgot", `{"email": "user@mail.com"}`))
+
+	// then
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "3600", rec.Header().Get("Retry-After"))
+}
+
+func TestResetPasswordHandler(t *testing.T) {
+	userId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		setupMocks     func(userService *mock_auth.MockUserService, sessionStorage *mock_auth.MockSessionStorage)
+		wantStatusCode int
+	}{
+		{
+			name: "token is invalid",
+			setupMocks: func(userService *mock_auth.MockUserService, sessionStorage *mock_auth.MockSessionStorage) {
+				userService.EXPECT().
+					ResetPassword(gomock.Any(), gomock.Any()).
+					Return(auth.ResetPasswordResponse{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the password reset token is invalid, has expired or has already been used"}))
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "password reset signs out every session of the user",
+			setupMocks: func(userService *mock_auth.MockUserService, sessionStorage *mock_auth.MockSessionStorage) {
+				userService.EXPECT().
+					ResetPassword(gomock.Any(), gomock.Any()).
+					Return(auth.ResetPasswordResponse{UserID: userId, SessionVersion: 3}, nil)
+				sessionStorage.EXPECT().SetSessionVersion(userId, int32(3)).Return(nil)
+				sessionStorage.EXPECT().DeleteAllOfUser(userId).Return(nil)
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "session version could not be saved",
+			setupMocks: func(userService *mock_auth.MockUserService, sessionStorage *mock_auth.MockSessionStorage) {
+				userService.EXPECT().
+					ResetPassword(gomock.Any(), gomock.Any()).
+					Return(auth.ResetPasswordResponse{UserID: userId, SessionVersion: 3}, nil)
+				sessionStorage.EXPECT().SetSessionVersion(userId, int32(3)).Return(errors.New("memcached is down"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			ctrl := gomock.NewController(t)
+			userService := mock_auth.NewMockUserService(ctrl)
+			sessionStorage := mock_auth.NewMockSessionStorage(ctrl)
+			tc.setupMocks(userService, sessionStorage)
+
+			server := newServer(userService, sessionStorage)
+			rec := httptest.NewRecorder()
+
+			// when
+			server.Handler.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/api/v1/auth/password/reset", `{"token": "token", "password": "newsupersecretpassword", "password_again": "newsupersecretpassword"}`))
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestSignInHandlerThrottlesConcurrentSignIns(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	userService := mock_auth.NewMockUserService(ctrl)
+
+	var passwordChecks atomic.Uint64
+
+	userService.EXPECT().
+		SignIn(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, auth.SignInRequest) (auth.SignInResponse, error) {
+			passwordChecks.Add(1)
+			// The password check takes a while, so the other sign-ins arrive before it fails.
+			time.Sleep(10 * time.Millisecond)
+
+			return auth.SignInResponse{}, echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: auth.InvalidCredentialsMessage})
+		}).
+		AnyTimes()
+
+	server := newServer(userService, mock_auth.NewMockSessionStorage(ctrl))
+
+	const signIns = 20
+
+	var (
+		wg                  sync.WaitGroup
+		unauthorized        atomic.Uint64
+		tooManyRequests     atomic.Uint64
+		missingRetryAfter   atomic.Uint64
+		unexpectedResponses atomic.Uint64
+	)
+
+	// when
+	for range signIns {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			rec := httptest.NewRecorder()
+			server.Handler.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/api/v1/auth/signin", `{"email": "user@mail.com", "password": "wrongpassword"}`))
+
+			switch rec.Code {
+			case http.StatusUnauthorized:
+				unauthorized.Add(1)
+			case http.StatusTooManyRequests:
+				tooManyRequests.Add(1)
+
+				if rec.Header().Get("Retry-After") != "900" {
+					missingRetryAfter.Add(1)
+				}
+			default:
+				unexpectedResponses.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// then
+	assert.Equal(t, accountPolicy.LockoutAttempts, passwordChecks.Load(), "only the allowed sign-ins should reach the password check")
+	assert.Equal(t, accountPolicy.LockoutAttempts, unauthorized.Load())
+	assert.Equal(t, signIns-accountPolicy.LockoutAttempts, tooManyRequests.Load())
+	assert.Zero(t, missingRetryAfter.Load(), "throttled sign-ins should tell when to retry")
+	assert.Zero(t, unexpectedResponses.Load())
+}
@@ -1,5 +1,7 @@
 package auth
 
+import "github.com/google/uuid"
+
 type SignUpRequest struct {
 	Email         string `json:"email" validate:"required,email" example:"user@mail.com"`
 	Password      string `json:"password" validate:"required,min=5,max=50" example:"supersecretpassword"`
@@ -12,5 +14,47 @@ type SignInRequest struct {
 }
 
 type SignInResponse struct {
-	Email string `json:"email" example:"user@mail.com"`
+	UserID            uuid.UUID `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Email             string    `json:"email" example:"user@mail.com"`
+	MeasurementSystem string    `json:"measurement_system" example:"metric"`
+	UnverifiedEmail   bool      `json:"unverified_email,omitempty" example:"false"`
+	SessionVersion    int32     `json:"session_version,omitempty" example:"0"`
+	// TwoFactorEnabled tells that the user has to pass the second factor before the session is created.
+	TwoFactorEnabled bool `json:"-"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email" example:"user@mail.com"`
+}
+
+// ResetPasswordRequest sets a new password with the token from the password reset email.
+type ResetPasswordRequest struct {
+	Token         string `json:"token" validate:"required" example:"q0bLZkF0cR0u6b2lZ0JxQm5yX1p3b0ZcY2lVd2tQeXM"`
+	Password      string `json:"password" validate:"required,min=5,max=50" example:"newsupersecretpassword"`
+	PasswordAgain string `json:"password_again" validate:"required,eqfield=Password" example:"newsupersecretpassword"`
+}
+
+// ResetPasswordResponse tells whose password has been reset and the new session version of the user,
+// which is newer than the versions of the sessions created with the old password.
+type ResetPasswordResponse struct {
+	UserID         uuid.UUID
+	SessionVersion int32
+}
+
+// VerifyEmailRequest verifies an email with the token from the verification email.
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required" example:"q0bLZkF0cR0u6b2lZ0JxQm5yX1p3b0ZcY2lVd2tQeXM"`
+}
+
+// TwoFactorChallengeResponse holds the token of a sign-in which waits for the second factor.
+type TwoFactorChallengeResponse struct {
+	Token string `json:"token" example:"C8M0RK2V7B1QJ5N4UD3LHT9E6GSAFPOI"`
+	// ExpiresIn is the number of seconds the token is valid.
+	ExpiresIn int `json:"expires_in" example:"300"`
+}
+
+// SignInTwoFactorRequest finishes a sign-in with a code from an authenticator app or a recovery code.
+type SignInTwoFactorRequest struct {
+	Token string `json:"token" validate:"required,max=64" example:"C8M0RK2V7B1QJ5N4UD3LHT9E6GSAFPOI"`
+	Code  string `json:"code" validate:"required,max=32" example:"287082"`
 }
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/webauthn"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// BeginPasskeySignIn godoc
+//
+//	@Summary		Begin signing in with a passkey
+//	@Description	Get the options of navigator.credentials.get, which the browser reads with PublicKeyCredential.parseRequestOptionsFromJSON.
+//	@Description	The user picks one of their passkeys, so no email is needed. The challenge expires after five minutes and can be used once.
+//	@Tags			auth
+//
+//	@Produce		json
+//
+//	@Success		200	{object}	shared.DataResponse[webauthn.RequestOptions]	"Options created successfully."
+//
+//	@Router			/api/v1/auth/passkeys/signin/begin [POST]
+func (h *handler) BeginPasskeySignIn(c echo.Context) error {
+	options, err := h.userService.BeginPasskeySignIn()
+	if err != nil {
+		return err
+	}
+
+	// Sign-in challenges are not bound to a user, who is known only once the passkey is verified.
+	if err := h.sessionStorage.SaveChallenge(options.Challenge, uuid.Nil); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[webauthn.RequestOptions]{Data: options})
+}
+
+// FinishPasskeySignIn godoc
+//
+//	@Summary		Finish signing in with a passkey
+//	@Description	Sign in with a passkey which signed the challenge from /api/v1/auth/passkeys/signin/begin.
+//	@Description	Passkeys verify the user on the authenticator, so users with two-factor authentication do not need a code.
+//	@Tags			auth
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			AssertionCredential	body		webauthn.AssertionCredential		true	"Request body with the passkey response serialized by PublicKeyCredential.toJSON."
+//
+//	@Success		200					{object}	shared.CommonResponse				"Sign in successfully."
+//	@Failure		400					{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401					{object}	shared.CommonResponse				"Passkey is not valid or the challenge has expired."
+//
+//	@Router			/api/v1/auth/passkeys/signin/finish [POST]
+func (h *handler) FinishPasskeySignIn(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	var requestBody = webauthn.AssertionCredential{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	challenge, err := webauthn.ParseChallenge(requestBody.Response.ClientDataJSON)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "passkey is not valid"})
+	}
+
+	// Challenges of registrations belong to a user and cannot be used to sign in.
+	challengeUserId, err := h.sessionStorage.TakeChallenge(challenge)
+	if err != nil && !errors.Is(err, session.ErrChallengeNotFound) {
+		return err
+	}
+
+	if err != nil || challengeUserId != uuid.Nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "the sign-in has expired, sign in again"})
+	}
+
+	signInResponse, err := h.userService.FinishPasskeySignIn(c.Request().Context(), challenge, requestBody)
+	if err != nil {
+		return err
+	}
+
+	if err := h.startSession(c, signInResponse); err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully signed in with a passkey", zap.String("userId", signInResponse.UserID.String()))
+
+	return c.JSON(http.StatusOK, shared.CommonResponse{Message: "successfully sign in"})
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// tooManyPasswordResetsMessage is the message of password resets rejected by the limit.
+const tooManyPasswordResetsMessage = "too many password resets, try again later"
+
+// ForgotPassword godoc
+//
+//	@Summary		Forgot password
+//	@Description	Email a link to reset the password to the owner of the email. The link is valid for a limited time and can be used once.
+//	@Description	The response is the same whether the email has an account or not.
+//	@Description	Only a few links can be requested for an email or from an IP address within a while.
+//	@Tags			auth
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			ForgotPasswordRequest	body		auth.ForgotPasswordRequest			true	"Request body with the email of an account."
+//
+//	@Success		202						{object}	shared.CommonResponse				"Password reset requested successfully."
+//	@Failure		400						{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		429						{object}	shared.CommonResponse				"Too many password resets, retry after the number of seconds in the Retry-After header."
+//
+//	@Router			/api/v1/auth/password/forgot [POST]
+func (h *handler) ForgotPassword(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	var requestBody = ForgotPasswordRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	if err := h.reservePasswordReset(c, requestBody.Email); err != nil {
+		return err
+	}
+
+	if err := h.userService.RequestPasswordReset(c.Request().Context(), requestBody); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, shared.CommonResponse{Message: "if an account with this email exists, a link to reset the password has been sent to it"})
+}
+
+// requestLimiter limits the requests of keys, like emails and IP addresses, which can be made within a while.
+type requestLimiter interface {
+	Reserve(key string) (time.Duration, error)
+	Release(key string) error
+}
+
+// reservePasswordReset counts a password reset of the email and the IP address of the request.
+// The resets are never released, so they are limited whether they succeed or not. It returns 429 with the Retry-After
+// header when too many resets have been requested for the email, whether it has an account or not, or from the IP address.
+func (h *handler) reservePasswordReset(c echo.Context, email string) error {
+	emailWait, err := h.resetEmailLimiter.Reserve(accountThrottleKey(email))
+	if err != nil {
+		return err
+	}
+
+	if emailWait > 0 {
+		return shared.TooManyRequests(c, emailWait, tooManyPasswordResetsMessage)
+	}
+
+	ipWait, err := h.resetIPLimiter.Reserve(c.RealIP())
+	if err != nil || ipWait > 0 {
+		// No email is sent, so the reset does not count for the email.
+		if err := h.resetEmailLimiter.Release(accountThrottleKey(email)); err != nil {
+			h.logger.Error("failed to release a password reset of an email", zap.Error(err))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if ipWait > 0 {
+		return shared.TooManyRequests(c, ipWait, tooManyPasswordResetsMessage)
+	}
+
+	return nil
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Reset password
+//	@Description	Set a new password with the token from the password reset email. Every session of the user is signed out.
+//	@Tags			auth
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			ResetPasswordRequest	body	auth.ResetPasswordRequest	true	"Request body with the token and the new password."
+//
+//	@Success		204						"Password reset successfully."
+//	@Failure		400						{object}	validator.ValidationErrorResponse	"Invalid data provided, or the token is invalid, has expired or has already been used."
+//
+//	@Router			/api/v1/auth/password/reset [POST]
+func (h *handler) ResetPassword(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	var requestBody = ResetPasswordRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	resetPasswordResponse, err := h.userService.ResetPassword(c.Request().Context(), requestBody)
+	if err != nil {
+		return err
+	}
+
+	userId := resetPasswordResponse.UserID
+
+	// Whoever knew the old password must not stay signed in. Sessions missing from the list of the user
+	// are rejected by their older session version.
+	if err := h.sessionStorage.SetSessionVersion(userId, resetPasswordResponse.SessionVersion); err != nil {
+		h.logger.Error("failed to set the session version of a user", zap.String("userId", userId.String()), zap.Error(err))
+		return err
+	}
+
+	if err := h.sessionStorage.DeleteAllOfUser(userId); err != nil {
+		h.logger.Error("failed to delete the sessions of a user", zap.String("userId", userId.String()), zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("successfully reset the password of a user", zap.String("userId", userId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -7,5 +7,11 @@ import (
 func (h *handler) RegisterRoutes(e *echo.Echo) {
 	e.POST("api/v1/auth/signup", h.SignUp)
 	e.POST("api/v1/auth/signin", h.SignIn)
+	e.POST("api/v1/auth/signin/2fa", h.SignInTwoFactor)
+	e.POST("api/v1/auth/passkeys/signin/begin", h.BeginPasskeySignIn)
+	e.POST("api/v1/auth/passkeys/signin/finish", h.FinishPasskeySignIn)
 	e.POST("api/v1/auth/signout", h.SignOut)
+	e.POST("api/v1/auth/password/forgot", h.ForgotPassword)
+	e.POST("api/v1/auth/password/reset", h.ResetPassword)
+	e.POST("api/v1/auth/verify-email", h.VerifyEmail)
 }
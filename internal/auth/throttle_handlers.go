@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// InvalidCredentialsMessage is the message of every sign-in with a wrong email or password,
+// so the response does not reveal which of them was wrong.
+const InvalidCredentialsMessage = "email or password is invalid"
+
+// tooManySignInsMessage is the message of sign-ins rejected by the throttle.
+const tooManySignInsMessage = "too many failed sign-ins, try again later"
+
+// signInLimiter delays and locks out the sign-ins of keys, like accounts and IP addresses, after they fail.
+// A sign-in is reserved before its credentials are verified and then fails, is released or resets the key.
+type signInLimiter interface {
+	Reserve(key string) (time.Duration, error)
+	Fail(key string) (time.Duration, error)
+	Release(key string) error
+	Reset(key string) error
+}
+
+// reserveSignIn counts a sign-in of the account and the IP address of the request before its credentials are verified,
+// so concurrent sign-ins cannot all pass the throttle before any of them fails. It returns 429 with the Retry-After
+// header when the account or the IP address has failed to sign in too often and has to wait.
+func (h *handler) reserveSignIn(c echo.Context, email string) error {
+	accountWait, err := h.accountLimiter.Reserve(accountThrottleKey(email))
+	if err != nil {
+		return err
+	}
+
+	if accountWait > 0 {
+		return shared.TooManyRequests(c, accountWait, tooManySignInsMessage)
+	}
+
+	ipWait, err := h.ipLimiter.Reserve(c.RealIP())
+	if err != nil || ipWait > 0 {
+		// The credentials are not verified, so the sign-in does not count for the account.
+		if err := h.accountLimiter.Release(accountThrottleKey(email)); err != nil {
+			h.logger.Error("failed to release a sign-in of an account", zap.Error(err))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if ipWait > 0 {
+		return shared.TooManyRequests(c, ipWait, tooManySignInsMessage)
+	}
+
+	return nil
+}
+
+// failSignIn delays the next sign-ins of the account and the IP address of a reserved sign-in which has failed.
+// The sign-in has failed anyway, so errors are only logged.
+func (h *handler) failSignIn(c echo.Context, email string) {
+	if _, err := h.accountLimiter.Fail(accountThrottleKey(email)); err != nil {
+		h.logger.Error("failed to count a failed sign-in of an account", zap.Error(err))
+	}
+
+	if _, err := h.ipLimiter.Fail(c.RealIP()); err != nil {
+		h.logger.Error("failed to count a failed sign-in of an IP address", zap.Error(err))
+	}
+}
+
+// releaseSignIn takes back a reserved sign-in whose credentials were not wrong, e.g. because they could not be verified.
+func (h *handler) releaseSignIn(c echo.Context, email string) {
+	if err := h.accountLimiter.Release(accountThrottleKey(email)); err != nil {
+		h.logger.Error("failed to release a sign-in of an account", zap.Error(err))
+	}
+
+	if err := h.ipLimiter.Release(c.RealIP()); err != nil {
+		h.logger.Error("failed to release a sign-in of an IP address", zap.Error(err))
+	}
+}
+
+// resetSignInThrottle forgets the failed sign-ins of an account once its user has signed in.
+// Failures of the IP address are kept and only the reserved sign-in is taken back,
+// otherwise an attacker could clear them by signing in to their own account.
+func (h *handler) resetSignInThrottle(c echo.Context, email string) {
+	if err := h.accountLimiter.Reset(accountThrottleKey(email)); err != nil {
+		h.logger.Error("failed to reset failed sign-ins of an account", zap.Error(err))
+	}
+
+	if err := h.ipLimiter.Release(c.RealIP()); err != nil {
+		h.logger.Error("failed to release a sign-in of an IP address", zap.Error(err))
+	}
+}
+
+// accountThrottleKey normalizes an email, so differently typed versions of it share their failures.
+func accountThrottleKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// isUnauthorized reports whether the error is a 401 response, which services return for wrong credentials.
+func isUnauthorized(err error) bool {
+	var httpErr *echo.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusUnauthorized
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+)
+
+// maxTwoFactorAttempts is the number of wrong codes after which a pending sign-in is cancelled,
+// so the codes cannot be guessed within the lifetime of the token.
+const maxTwoFactorAttempts = 5
+
+// SignInTwoFactor godoc
+//
+//	@Summary		Sign in with the second factor
+//	@Description	Finish the sign-in of a user with two-factor authentication with the token from /api/v1/auth/signin
+//	@Description	and a code from an authenticator app or a recovery code. A sign-in is cancelled after a few wrong codes.
+//	@Tags			auth
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			SignInTwoFactorRequest	body		auth.SignInTwoFactorRequest			true	"Request body with the token of the sign-in and a code."
+//
+//	@Success		200						{object}	shared.CommonResponse				"Sign in successfully."
+//	@Failure		400						{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401						{object}	shared.CommonResponse				"Code is invalid or the sign-in has expired."
+//	@Failure		429						{object}	shared.CommonResponse				"Too many failed sign-ins, retry after the number of seconds in the Retry-After header."
+//
+//	@Router			/api/v1/auth/signin/2fa [POST]
+func (h *handler) SignInTwoFactor(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	var requestBody = SignInTwoFactorRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	pendingSession, err := h.sessionStorage.GetPending(requestBody.Token)
+	if err != nil {
+		if errors.Is(err, session.ErrPendingNotFound) {
+			return echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "the sign-in has expired, sign in again"})
+		}
+
+		return err
+	}
+
+	var signInResponse SignInResponse
+
+	if err := json.Unmarshal(pendingSession, &signInResponse); err != nil {
+		return errors.Join(errors.New("failed to decode the pending sign-in"), err)
+	}
+
+	if err := h.reservePendingAttempt(requestBody.Token); err != nil {
+		return err
+	}
+
+	if err := h.reserveSignIn(c, signInResponse.Email); err != nil {
+		return err
+	}
+
+	if err := h.userService.VerifySecondFactor(c.Request().Context(), signInResponse.UserID, requestBody.Code); err != nil {
+		if isUnauthorized(err) {
+			h.failSignIn(c, signInResponse.Email)
+		} else {
+			h.releaseSignIn(c, signInResponse.Email)
+		}
+
+		return err
+	}
+
+	h.sessionStorage.DeletePending(requestBody.Token)
+	h.resetSignInThrottle(c, signInResponse.Email)
+
+	if err := h.startSession(c, signInResponse); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.CommonResponse{Message: "successfully sign in"})
+}
+
+// startPendingSignIn saves the session of a user who has to pass the second factor
+// and returns the token of the pending sign-in.
+func (h *handler) startPendingSignIn(c echo.Context, signInResponse SignInResponse) error {
+	jsonEncodedSession, err := json.Marshal(signInResponse)
+	if err != nil {
+		return err
+	}
+
+	pendingID, err := h.sessionStorage.CreatePending(jsonEncodedSession)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, shared.DataResponse[TwoFactorChallengeResponse]{
+		Data: TwoFactorChallengeResponse{
+			Token:     pendingID,
+			ExpiresIn: session.PendingExpirationTime,
+		},
+	})
+}
+
+// reservePendingAttempt counts an attempt to pass the second factor of a pending sign-in before the code is verified,
+// so concurrent attempts cannot guess more codes than maxTwoFactorAttempts. An attempt past the last one cancels the sign-in.
+func (h *handler) reservePendingAttempt(pendingID string) error {
+	attempts, err := h.sessionStorage.CountPendingAttempt(pendingID)
+	if err != nil {
+		if errors.Is(err, session.ErrPendingNotFound) {
+			return echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "the sign-in has expired, sign in again"})
+		}
+
+		return err
+	}
+
+	if attempts > maxTwoFactorAttempts {
+		h.sessionStorage.DeletePending(pendingID)
+		return echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "the sign-in has expired, sign in again"})
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// VerifyEmail godoc
+//
+//	@Summary		Verify email
+//	@Description	Verify the email of an account with the token from the verification email. Users with unverified emails cannot publish recipes or comment.
+//	@Tags			auth
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			VerifyEmailRequest	body	auth.VerifyEmailRequest	true	"Request body with the token from the verification email."
+//
+//	@Success		204					"Email verified successfully."
+//	@Failure		400					{object}	validator.ValidationErrorResponse	"Invalid data provided, or the token is invalid, has expired or has already been used."
+//
+//	@Router			/api/v1/auth/verify-email [POST]
+func (h *handler) VerifyEmail(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	var requestBody = VerifyEmailRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	userId, err := h.userService.VerifyEmail(c.Request().Context(), requestBody)
+	if err != nil {
+		return err
+	}
+
+	// The email is verified in the database anyway, so a user whose sessions could not be updated only has to sign in again.
+	err = h.sessionStorage.UpdateAllOfUser(userId, func(s *session.Session) {
+		s.UnverifiedEmail = false
+	})
+	if err != nil {
+		h.logger.Error("failed to update the sessions of a user", zap.String("userId", userId.String()), zap.Error(err))
+	}
+
+	h.logger.Info("successfully verified the email of a user", zap.String("userId", userId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,21 @@
+// Package blobstore stores binary objects, like images of recipes, outside of the database.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrInvalidKey is returned for keys which are empty or point outside of the store.
+var ErrInvalidKey = errors.New("blobstore: invalid object key")
+
+// Store saves objects under slash-separated keys, e.g. "recipes/<id>/<image id>.jpg".
+type Store interface {
+	// Put saves the object under the key, replacing an existing object.
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	// Delete removes the object. Deleting an object which does not exist is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the address the object can be downloaded from.
+	URL(key string) string
+}
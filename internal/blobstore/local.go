@@ -0,0 +1,85 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores objects as files in a directory, which is served by the API itself.
+// It is meant for development and single-instance deployments.
+type Local struct {
+	dir       string
+	publicURL string
+}
+
+// NewLocal returns a store which saves objects in dir and links to them under publicURL.
+func NewLocal(dir, publicURL string) *Local {
+	return &Local{
+		dir:       dir,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+// Put writes the object to a temporary file first, so readers never see a partially written object.
+func (l *Local) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, body); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (l *Local) URL(key string) string {
+	return l.publicURL + "/" + key
+}
+
+// path returns the file path of the object and makes sure it stays inside the directory of the store.
+func (l *Local) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,49 @@
+package blobstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	store := NewLocal(dir, "http://localhost:8080/uploads/")
+	ctx := context.Background()
+
+	// when
+	err := store.Put(ctx, "images/cookies.png", strings.NewReader("png"), "image/png")
+
+	// then
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "images", "cookies.png"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png"), content)
+	assert.Equal(t, "http://localhost:8080/uploads/images/cookies.png", store.URL("images/cookies.png"))
+
+	// when
+	err = store.Delete(ctx, "images/cookies.png")
+
+	// then
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "images", "cookies.png"))
+	assert.NoError(t, store.Delete(ctx, "images/cookies.png"))
+}
+
+func TestLocalRejectsKeysOutsideOfDirectory(t *testing.T) {
+	// given
+	store := NewLocal(t.TempDir(), "http://localhost:8080/uploads")
+
+	// when
+	err := store.Put(context.Background(), "../cookies.png", strings.NewReader("png"), "image/png")
+
+	// then
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
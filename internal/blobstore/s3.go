@@ -0,0 +1,123 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// S3Config holds the settings of an S3-compatible object storage, like AWS S3 or MinIO.
+type S3Config struct {
+	// Endpoint is the base URL of the storage, e.g. "https://s3.eu-central-1.amazonaws.com" or "http://localhost:9000".
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PublicURL is the base URL objects are downloaded from, e.g. a CDN in front of the bucket.
+	// Objects are linked directly in the bucket when it is empty.
+	PublicURL string
+}
+
+// S3 stores objects in a bucket of an S3-compatible object storage.
+// Requests are addressed in the path style, which is supported by both AWS and MinIO.
+type S3 struct {
+	cfg        S3Config
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3 returns a store which saves objects in the bucket from the config.
+func NewS3(cfg S3Config, httpClient *http.Client) *S3 {
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	cfg.PublicURL = strings.TrimSuffix(cfg.PublicURL, "/")
+
+	return &S3{
+		cfg:        cfg,
+		httpClient: httpClient,
+		now:        time.Now,
+	}
+}
+
+// Put reads the whole object into memory, because its hash is a part of the signature.
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	return s.do(req)
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.do(req)
+}
+
+func (s *S3) URL(key string) string {
+	if s.cfg.PublicURL != "" {
+		return s.cfg.PublicURL + "/" + uriEncode(key, false)
+	}
+
+	return s.objectURL(key)
+}
+
+func (s *S3) objectURL(key string) string {
+	return s.cfg.Endpoint + "/" + s.cfg.Bucket + "/" + uriEncode(key, false)
+}
+
+// newRequest creates a request to the object with the hash of the body set, ready to be signed.
+func (s *S3) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, ErrInvalidKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set(headerAmzContentSha256, hashHex(body))
+
+	return req, nil
+}
+
+// do signs and sends the request and turns error responses of the storage into errors.
+func (s *S3) do(req *http.Request) error {
+	signRequest(req, credentials{
+		accessKeyID:     s.cfg.AccessKeyID,
+		secretAccessKey: s.cfg.SecretAccessKey,
+	}, s.cfg.Region, "s3", s.now())
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		// Error responses are short XML documents, read just enough of them to know what went wrong.
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+		return fmt.Errorf("blobstore: %s %s returned %s: %s", req.Method, req.URL.Path, res.Status, message)
+	}
+
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	return nil
+}
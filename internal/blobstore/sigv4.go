@@ -0,0 +1,164 @@
+package blobstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AWS Signature Version 4, as described in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html.
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	shortDateFormat  = "20060102"
+
+	headerAmzDate          = "X-Amz-Date"
+	headerAmzContentSha256 = "X-Amz-Content-Sha256"
+)
+
+type credentials struct {
+	accessKeyID     string
+	secretAccessKey string
+}
+
+// signRequest adds the X-Amz-Date and Authorization headers to the request.
+// The X-Amz-Content-Sha256 header has to be set to the hex-encoded SHA-256 hash of the body before.
+// All headers of the request are signed, together with the host.
+func signRequest(req *http.Request, creds credentials, region, service string, signingTime time.Time) {
+	signingTime = signingTime.UTC()
+	amzDate := signingTime.Format(amzDateFormat)
+	shortDate := signingTime.Format(shortDateFormat)
+
+	req.Header.Set(headerAmzDate, amzDate)
+
+	canonicalHeaders, signedHeaders := canonicalizeHeaders(req)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalizeQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		req.Header.Get(headerAmzContentSha256),
+	}, "\n")
+
+	scope := strings.Join([]string{shortDate, region, service, "aws4_request"}, "/")
+
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.secretAccessKey), shortDate)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", signingAlgorithm+
+		" Credential="+creds.accessKeyID+"/"+scope+
+		",SignedHeaders="+signedHeaders+
+		",Signature="+signature)
+}
+
+// canonicalizeHeaders returns the headers in the canonical form and the list of their names.
+func canonicalizeHeaders(req *http.Request) (string, string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+
+		if name == "authorization" || name == "user-agent" {
+			continue
+		}
+
+		trimmedValues := make([]string, 0, len(values))
+		for _, value := range values {
+			trimmedValues = append(trimmedValues, strings.Join(strings.Fields(value), " "))
+		}
+
+		headers[name] = strings.Join(trimmedValues, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	return canonicalHeaders.String(), strings.Join(names, ";")
+}
+
+// canonicalizeQuery sorts the query parameters by name and value and encodes them.
+func canonicalizeQuery(query url.Values) string {
+	params := make([]string, 0, len(query))
+
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+
+	sort.Strings(params)
+
+	return strings.Join(params, "&")
+}
+
+// uriEncode percent-encodes every byte except the unreserved characters.
+// Slashes are kept when encoding paths.
+func uriEncode(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var encoded strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			encoded.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			encoded.WriteByte(c)
+		default:
+			encoded.WriteByte('%')
+			encoded.WriteByte(hexDigits[c>>4])
+			encoded.WriteByte(hexDigits[c&15])
+		}
+	}
+
+	return encoded.String()
+}
+
+func hashHex(data []byte) string {
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
@@ -1,21 +1,71 @@
 package config
 
 import (
+	"time"
+
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DatabaseURL      string `env:"DATABASE_URL,notEmpty"`
-	HTTPServerPort   string `env:"HTTP_SERVER_PORT,notEmpty"`
-	ArgonMemory      uint32 `env:"ARGON_MEMORY,notEmpty"`
-	ArgonIterations  uint32 `env:"ARGON_ITERATIONS,notEmpty"`
-	ArgonParallelism uint8  `env:"ARGON_PARALLELISM,notEmpty"`
-	ArgonSaltLength  uint32 `env:"ARGON_SALT_LENGTH,notEmpty"`
-	ArgonKeyLength   uint32 `env:"ARGON_KEY_LENGTH,notEmpty"`
-	AppEnv           string `env:"APP_ENV,notEmpty"`
-	MemcachedServer  string `env:"MEMCACHE_SERVER,notEmpty"`
-	DomainName       string `env:"DOMAIN_NAME,notEmpty"`
+	DatabaseURL       string `env:"DATABASE_URL,notEmpty"`
+	HTTPServerPort    string `env:"HTTP_SERVER_PORT,notEmpty"`
+	ArgonMemory       uint32 `env:"ARGON_MEMORY,notEmpty"`
+	ArgonIterations   uint32 `env:"ARGON_ITERATIONS,notEmpty"`
+	ArgonParallelism  uint8  `env:"ARGON_PARALLELISM,notEmpty"`
+	ArgonSaltLength   uint32 `env:"ARGON_SALT_LENGTH,notEmpty"`
+	ArgonKeyLength    uint32 `env:"ARGON_KEY_LENGTH,notEmpty"`
+	AppEnv            string `env:"APP_ENV,notEmpty"`
+	MemcachedServer   string `env:"MEMCACHE_SERVER,notEmpty"`
+	DomainName        string `env:"DOMAIN_NAME,notEmpty"`
+	BlobStorage       string `env:"BLOB_STORAGE" envDefault:"local"`
+	BlobLocalDir      string `env:"BLOB_LOCAL_DIR" envDefault:"./uploads"`
+	BlobPublicURL     string `env:"BLOB_PUBLIC_URL"`
+	S3Endpoint        string `env:"S3_ENDPOINT"`
+	S3Region          string `env:"S3_REGION" envDefault:"us-east-1"`
+	S3Bucket          string `env:"S3_BUCKET"`
+	S3AccessKeyID     string `env:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `env:"S3_SECRET_ACCESS_KEY"`
+
+	TrashRetention     time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
+	TrashPurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL" envDefault:"1h"`
+
+	PublishSchedulerInterval time.Duration `env:"PUBLISH_SCHEDULER_INTERVAL" envDefault:"1m"`
+
+	AppURL        string `env:"APP_URL"`
+	Mailer        string `env:"MAILER" envDefault:"file"`
+	MailerFileDir string `env:"MAILER_FILE_DIR" envDefault:"./mail"`
+	MailFrom      string `env:"MAIL_FROM" envDefault:"noreply@localhost"`
+	SMTPHost      string `env:"SMTP_HOST"`
+	SMTPPort      string `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUsername  string `env:"SMTP_USERNAME"`
+	SMTPPassword  string `env:"SMTP_PASSWORD"`
+
+	PasswordResetTokenTTL time.Duration `env:"PASSWORD_RESET_TOKEN_TTL" envDefault:"1h"`
+
+	EmailVerificationTokenTTL       time.Duration `env:"EMAIL_VERIFICATION_TOKEN_TTL" envDefault:"48h"`
+	EmailVerificationResendInterval time.Duration `env:"EMAIL_VERIFICATION_RESEND_INTERVAL" envDefault:"1m"`
+
+	WebAuthnRPID    string   `env:"WEBAUTHN_RP_ID"`
+	WebAuthnRPName  string   `env:"WEBAUTHN_RP_NAME" envDefault:"Recipe App"`
+	WebAuthnOrigins []string `env:"WEBAUTHN_ORIGINS" envSeparator:","`
+
+	TrustProxyHeaders bool `env:"TRUST_PROXY_HEADERS" envDefault:"false"`
+
+	SignInThrottleStore          string        `env:"SIGNIN_THROTTLE_STORE" envDefault:"memcached"`
+	SignInThrottleWindow         time.Duration `env:"SIGNIN_THROTTLE_WINDOW" envDefault:"1h"`
+	SignInBackoffBase            time.Duration `env:"SIGNIN_BACKOFF_BASE" envDefault:"1s"`
+	SignInBackoffMax             time.Duration `env:"SIGNIN_BACKOFF_MAX" envDefault:"1m"`
+	SignInAccountFreeAttempts    uint64        `env:"SIGNIN_ACCOUNT_FREE_ATTEMPTS" envDefault:"5"`
+	SignInAccountLockoutAttempts uint64        `env:"SIGNIN_ACCOUNT_LOCKOUT_ATTEMPTS" envDefault:"10"`
+	SignInAccountLockout         time.Duration `env:"SIGNIN_ACCOUNT_LOCKOUT_DURATION" envDefault:"15m"`
+	SignInIPFreeAttempts         uint64        `env:"SIGNIN_IP_FREE_ATTEMPTS" envDefault:"20"`
+	SignInIPLockoutAttempts      uint64        `env:"SIGNIN_IP_LOCKOUT_ATTEMPTS" envDefault:"100"`
+	SignInIPLockout              time.Duration `env:"SIGNIN_IP_LOCKOUT_DURATION" envDefault:"1h"`
+
+	PasswordResetWindow     time.Duration `env:"PASSWORD_RESET_WINDOW" envDefault:"1h"`
+	PasswordResetEmailLimit uint64        `env:"PASSWORD_RESET_EMAIL_LIMIT" envDefault:"3"`
+	PasswordResetIPLimit    uint64        `env:"PASSWORD_RESET_IP_LIMIT" envDefault:"20"`
 }
 
 func LoadEnvironmentVariablesToConfig() (cfg Config, err error) {
@@ -0,0 +1,380 @@
+package cookbook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const defaultListLimit = 20
+
+type handler struct {
+	logger          *zap.Logger
+	cookbookService cookbookService
+}
+
+type cookbookService interface {
+	CreateCookbook(context.Context, uuid.UUID, CookbookRequest) (uuid.UUID, error)
+	GetCookbookById(context.Context, uuid.UUID, uuid.UUID) (CookbookResponse, error)
+	UpdateCookbook(context.Context, uuid.UUID, CookbookRequest) error
+	DeleteCookbook(context.Context, uuid.UUID) error
+	ListCookbooks(context.Context, uuid.UUID, bool, ListCookbooksRequest) ([]CookbookSummaryResponse, string, error)
+	AddCookbookRecipe(context.Context, uuid.UUID, AddCookbookRecipeRequest) error
+	RemoveCookbookRecipe(context.Context, uuid.UUID, uuid.UUID) error
+}
+
+func NewHandler(logger *zap.Logger, cookbookService cookbookService) *handler {
+	return &handler{
+		logger:          logger,
+		cookbookService: cookbookService,
+	}
+}
+
+// CreateCookbook godoc
+//
+//	@Summary		Create a cookbook
+//	@Description	Create a named collection of recipes. Cookbooks are private unless is_public is set.
+//	@Tags			cookbooks
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			CookbookRequest	body		cookbook.CookbookRequest			true	"Request body with a name, description and visibility."
+//
+//	@Success		201				{object}	shared.CommonResponse				"Cookbook saved successfully."
+//	@Failure		400				{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401				{object}	shared.CommonResponse				"User is not signed in."
+//
+//	@Router			/api/v1/cookbooks [POST]
+func (h *handler) CreateCookbook(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	var requestBody = CookbookRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	cookbookId, err := h.cookbookService.CreateCookbook(c.Request().Context(), session.FromContext(c).UserID, requestBody)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully saved a cookbook", zap.String("cookbookId", cookbookId.String()))
+
+	c.Response().Header().Add("Location", fmt.Sprintf("/api/v1/cookbooks/%v", cookbookId.String()))
+	return c.JSON(http.StatusCreated, shared.CommonResponse{Message: "successfully saved a cookbook"})
+}
+
+// GetCookbookById godoc
+//
+//	@Summary		Get a cookbook
+//	@Description	Get a cookbook with its recipes in order. Private cookbooks are visible only to their owner.
+//	@Description	Private recipes and recipes which are not published yet are listed only to their author.
+//	@Tags			cookbooks
+//
+//	@Produce		json
+//	@Param			id	path		string											true	"UUID of a cookbook."
+//
+//	@Success		200	{object}	shared.DataResponse[cookbook.CookbookResponse]	"Cookbook fetched successfully."
+//	@Failure		400	{object}	shared.CommonResponse							"Invalid data provided."
+//	@Failure		404	{object}	shared.CommonResponse							"Cookbook not found."
+//
+//	@Router			/api/v1/cookbooks/{id} [GET]
+func (h *handler) GetCookbookById(c echo.Context) error {
+	cookbookId, err := parseCookbookId(c)
+	if err != nil {
+		return err
+	}
+
+	cookbook, err := h.cookbookService.GetCookbookById(c.Request().Context(), cookbookId, session.FromContext(c).UserID)
+	if err != nil {
+		return err
+	}
+
+	// Do not reveal that a private cookbook exists.
+	if !cookbook.IsPublic && cookbook.UserID != session.FromContext(c).UserID {
+		return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a cookbook with this ID"})
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[CookbookResponse]{Data: cookbook})
+}
+
+// UpdateCookbook godoc
+//
+//	@Summary		Update a cookbook
+//	@Description	Replace the name, description and visibility of a cookbook.
+//	@Tags			cookbooks
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string								true	"UUID of a cookbook."
+//	@Param			CookbookRequest	body		cookbook.CookbookRequest			true	"Request body with a name, description and visibility."
+//
+//	@Success		204				"Cookbook updated successfully."
+//	@Failure		400				{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401				{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403				{object}	shared.CommonResponse				"User is not the owner of the cookbook."
+//	@Failure		404				{object}	shared.CommonResponse				"Cookbook not found."
+//
+//	@Router			/api/v1/cookbooks/{id} [PUT]
+func (h *handler) UpdateCookbook(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	cookbookId, err := parseCookbookId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestBody = CookbookRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	if err := h.checkCookbookOwner(c, cookbookId); err != nil {
+		return err
+	}
+
+	if err := h.cookbookService.UpdateCookbook(c.Request().Context(), cookbookId, requestBody); err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully updated a cookbook", zap.String("cookbookId", cookbookId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteCookbook godoc
+//
+//	@Summary		Delete a cookbook
+//	@Description	Delete a cookbook. The recipes in the cookbook are not deleted.
+//	@Tags			cookbooks
+//
+//	@Produce		json
+//	@Param			id	path	string	true	"UUID of a cookbook."
+//
+//	@Success		204	"Cookbook deleted successfully."
+//	@Failure		400	{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401	{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		403	{object}	shared.CommonResponse	"User is not the owner of the cookbook."
+//	@Failure		404	{object}	shared.CommonResponse	"Cookbook not found."
+//
+//	@Router			/api/v1/cookbooks/{id} [DELETE]
+func (h *handler) DeleteCookbook(c echo.Context) error {
+	cookbookId, err := parseCookbookId(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.checkCookbookOwner(c, cookbookId); err != nil {
+		return err
+	}
+
+	if err := h.cookbookService.DeleteCookbook(c.Request().Context(), cookbookId); err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully deleted a cookbook", zap.String("cookbookId", cookbookId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListCookbooks godoc
+//
+//	@Summary		List cookbooks
+//	@Description	Get a page of cookbooks of a user, starting from the newest ones. Lists the cookbooks of the signed-in user when author_id is omitted.
+//	@Description	Private cookbooks are listed only to their owner.
+//	@Tags			cookbooks
+//
+//	@Produce		json
+//
+//	@Param			author_id	query		string													false	"UUID of the owner of cookbooks."
+//	@Param			cursor		query		string													false	"Cursor to the next page."
+//	@Param			limit		query		int														false	"Maximum number of cookbooks on a page."	minimum(1)	maximum(100)	default(20)
+//
+//	@Success		200			{object}	shared.PagedDataResponse[cookbook.CookbookSummaryResponse]	"Cookbooks fetched successfully."
+//	@Failure		400			{object}	validator.ValidationErrorResponse							"Invalid data provided."
+//	@Failure		401			{object}	shared.CommonResponse										"User is not signed in and author_id is omitted."
+//
+//	@Router			/api/v1/cookbooks [GET]
+func (h *handler) ListCookbooks(c echo.Context) error {
+	var requestQuery = ListCookbooksRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	if requestQuery.Limit == 0 {
+		requestQuery.Limit = defaultListLimit
+	}
+
+	userSession := session.FromContext(c)
+
+	if requestQuery.AuthorID == uuid.Nil {
+		if !userSession.IsAuthenticated() {
+			return echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "you must be signed in to list your cookbooks"})
+		}
+
+		requestQuery.AuthorID = userSession.UserID
+	}
+
+	includePrivate := requestQuery.AuthorID == userSession.UserID
+
+	cookbooks, nextCursor, err := h.cookbookService.ListCookbooks(c.Request().Context(), requestQuery.AuthorID, includePrivate, requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[CookbookSummaryResponse]{
+		Data: cookbooks,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
+
+// AddCookbookRecipe godoc
+//
+//	@Summary		Add a recipe to a cookbook
+//	@Description	Insert a recipe into a cookbook at the given position, moving the following recipes down. The recipe is appended to the end when the position is omitted.
+//	@Description	Only published recipes which are not private can be added, unless the recipe belongs to the owner of the cookbook.
+//	@Tags			cookbooks
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		string								true	"UUID of a cookbook."
+//	@Param			AddCookbookRecipeRequest	body		cookbook.AddCookbookRecipeRequest	true	"Request body with a recipe ID and an optional position."
+//
+//	@Success		201							{object}	shared.CommonResponse				"Recipe added successfully."
+//	@Failure		400							{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401							{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403							{object}	shared.CommonResponse				"User is not the owner of the cookbook."
+//	@Failure		404							{object}	shared.CommonResponse				"Cookbook or recipe not found."
+//	@Failure		409							{object}	shared.CommonResponse				"Recipe is already in the cookbook or the cookbook is full."
+//
+//	@Router			/api/v1/cookbooks/{id}/recipes [POST]
+func (h *handler) AddCookbookRecipe(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	cookbookId, err := parseCookbookId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestBody = AddCookbookRecipeRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	if err := h.checkCookbookOwner(c, cookbookId); err != nil {
+		return err
+	}
+
+	if err := h.cookbookService.AddCookbookRecipe(c.Request().Context(), cookbookId, requestBody); err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully added a recipe to a cookbook", zap.String("cookbookId", cookbookId.String()), zap.String("recipeId", requestBody.RecipeID.String()))
+
+	return c.JSON(http.StatusCreated, shared.CommonResponse{Message: "successfully added a recipe to the cookbook"})
+}
+
+// RemoveCookbookRecipe godoc
+//
+//	@Summary		Remove a recipe from a cookbook
+//	@Description	Remove a recipe from a cookbook, moving the following recipes up. The recipe itself is not deleted.
+//	@Tags			cookbooks
+//
+//	@Produce		json
+//	@Param			id			path	string	true	"UUID of a cookbook."
+//	@Param			recipeId	path	string	true	"UUID of a recipe."
+//
+//	@Success		204			"Recipe removed successfully."
+//	@Failure		400			{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401			{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		403			{object}	shared.CommonResponse	"User is not the owner of the cookbook."
+//	@Failure		404			{object}	shared.CommonResponse	"Cookbook or recipe not found."
+//
+//	@Router			/api/v1/cookbooks/{id}/recipes/{recipeId} [DELETE]
+func (h *handler) RemoveCookbookRecipe(c echo.Context) error {
+	cookbookId, err := parseCookbookId(c)
+	if err != nil {
+		return err
+	}
+
+	recipeId, err := uuid.Parse(c.Param("recipeId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "the received recipe ID is not a valid UUID"})
+	}
+
+	if err := h.checkCookbookOwner(c, cookbookId); err != nil {
+		return err
+	}
+
+	if err := h.cookbookService.RemoveCookbookRecipe(c.Request().Context(), cookbookId, recipeId); err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully removed a recipe from a cookbook", zap.String("cookbookId", cookbookId.String()), zap.String("recipeId", recipeId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseCookbookId reads the UUID of a cookbook from the ID path param.
+func parseCookbookId(c echo.Context) (uuid.UUID, error) {
+	cookbookId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.UUID{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received ID is not a valid UUID"})
+	}
+
+	return cookbookId, nil
+}
+
+// checkCookbookOwner fetches a cookbook and checks if it belongs to the signed-in user.
+// Private cookbooks of other users are reported as not found.
+func (h *handler) checkCookbookOwner(c echo.Context, cookbookId uuid.UUID) error {
+	cookbook, err := h.cookbookService.GetCookbookById(c.Request().Context(), cookbookId, session.FromContext(c).UserID)
+	if err != nil {
+		return err
+	}
+
+	if cookbook.UserID == session.FromContext(c).UserID {
+		return nil
+	}
+
+	if !cookbook.IsPublic {
+		return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a cookbook with this ID"})
+	}
+
+	return echo.NewHTTPError(http.StatusForbidden, shared.CommonResponse{Message: "only the owner of the cookbook can modify it"})
+}
@@ -0,0 +1,426 @@
+package cookbook_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mock_cookbook "github.com/danielbukowski/recipe-app-backend/gen/_mocks/cookbook"
+	"github.com/danielbukowski/recipe-app-backend/internal/cookbook"
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/validator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCreateCookbookHandler(t *testing.T) {
+	signedInSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com"}
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		requestBody    string
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			requestBody:    `{"name": "Weeknight dinners"}`,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "no request body",
+			session:        signedInSession,
+			requestBody:    "",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "cookbook has no name",
+			session:        signedInSession,
+			requestBody:    `{"description": "Quick meals for busy evenings"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "cookbook with a name",
+			session:        signedInSession,
+			requestBody:    `{"name": "Weeknight dinners", "is_public": true}`,
+			wantStatusCode: http.StatusCreated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/cookbooks", strings.NewReader(tc.requestBody))
+			req.Header.Add("content-type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			cookbookService := mock_cookbook.NewMockCookbookService(gomock.NewController(t))
+
+			cookbookId := uuid.Must(uuid.NewV7())
+
+			if tc.wantStatusCode == http.StatusCreated {
+				cookbookService.EXPECT().
+					CreateCookbook(gomock.Any(), tc.session.UserID, cookbook.CookbookRequest{Name: "Weeknight dinners", IsPublic: true}).
+					Return(cookbookId, nil)
+			}
+
+			handler := cookbook.NewHandler(logger, cookbookService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+
+			if tc.wantStatusCode == http.StatusCreated {
+				assert.Equal(t, "/api/v1/cookbooks/"+cookbookId.String(), rec.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestGetCookbookHandler(t *testing.T) {
+	ownerSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "owner@mail.com"}
+	otherSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com"}
+	cookbookId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		cookbookId     string
+		isPublic       bool
+		wantStatusCode int
+	}{
+		{
+			name:           "cookbook ID is not a valid UUID",
+			session:        &session.Session{},
+			cookbookId:     "123",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "anonymous user gets a public cookbook",
+			session:        &session.Session{},
+			cookbookId:     cookbookId.String(),
+			isPublic:       true,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "owner gets their private cookbook",
+			session:        ownerSession,
+			cookbookId:     cookbookId.String(),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "private cookbook of another user is not found",
+			session:        otherSession,
+			cookbookId:     cookbookId.String(),
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/cookbooks/"+tc.cookbookId, nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			cookbookService := mock_cookbook.NewMockCookbookService(gomock.NewController(t))
+
+			cookbookService.EXPECT().
+				GetCookbookById(gomock.Any(), cookbookId, tc.session.UserID).
+				Return(cookbook.CookbookResponse{CookbookID: cookbookId, UserID: ownerSession.UserID, Name: "Weeknight dinners", IsPublic: tc.isPublic}, nil).
+				AnyTimes()
+
+			handler := cookbook.NewHandler(logger, cookbookService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+
+			if tc.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var responseBody shared.DataResponse[cookbook.CookbookResponse]
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseBody))
+			assert.Equal(t, cookbookId, responseBody.Data.CookbookID)
+		})
+	}
+}
+
+func TestListCookbooksHandler(t *testing.T) {
+	signedInSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com"}
+	authorId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name               string
+		session            *session.Session
+		query              string
+		wantStatusCode     int
+		wantAuthorId       uuid.UUID
+		wantIncludePrivate bool
+		wantLimit          int32
+	}{
+		{
+			name:           "anonymous user lists their own cookbooks",
+			session:        &session.Session{},
+			query:          "",
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "limit is out of range",
+			session:        signedInSession,
+			query:          "?limit=101",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "signed-in user lists their own cookbooks",
+			session:            signedInSession,
+			query:              "",
+			wantStatusCode:     http.StatusOK,
+			wantAuthorId:       signedInSession.UserID,
+			wantIncludePrivate: true,
+			wantLimit:          20,
+		},
+		{
+			name:               "user lists public cookbooks of another user",
+			session:            signedInSession,
+			query:              "?limit=5&author_id=" + authorId.String(),
+			wantStatusCode:     http.StatusOK,
+			wantAuthorId:       authorId,
+			wantIncludePrivate: false,
+			wantLimit:          5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/cookbooks"+tc.query, nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			cookbookService := mock_cookbook.NewMockCookbookService(gomock.NewController(t))
+
+			if tc.wantStatusCode == http.StatusOK {
+				cookbookService.EXPECT().
+					ListCookbooks(gomock.Any(), tc.wantAuthorId, tc.wantIncludePrivate, gomock.Any()).
+					DoAndReturn(func(_ any, _ uuid.UUID, _ bool, req cookbook.ListCookbooksRequest) ([]cookbook.CookbookSummaryResponse, string, error) {
+						assert.Equal(t, tc.wantLimit, req.Limit)
+						return []cookbook.CookbookSummaryResponse{}, "", nil
+					})
+			}
+
+			handler := cookbook.NewHandler(logger, cookbookService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestAddCookbookRecipeHandler(t *testing.T) {
+	ownerSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "owner@mail.com"}
+	otherSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com"}
+	cookbookId := uuid.Must(uuid.NewV7())
+	recipeId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		isPublic       bool
+		requestBody    string
+		serviceErr     error
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			requestBody:    `{"recipe_id": "` + recipeId.String() + `"}`,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "position is not positive",
+			session:        ownerSession,
+			requestBody:    `{"recipe_id": "` + recipeId.String() + `", "position": 0}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "user adds a recipe to a public cookbook of another user",
+			session:        otherSession,
+			isPublic:       true,
+			requestBody:    `{"recipe_id": "` + recipeId.String() + `"}`,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "user adds a recipe to a private cookbook of another user",
+			session:        otherSession,
+			requestBody:    `{"recipe_id": "` + recipeId.String() + `"}`,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "owner adds a recipe they cannot read",
+			session:        ownerSession,
+			requestBody:    `{"recipe_id": "` + recipeId.String() + `"}`,
+			serviceErr:     echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a recipe with this ID"}),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "owner adds a recipe at a position",
+			session:        ownerSession,
+			requestBody:    `{"recipe_id": "` + recipeId.String() + `", "position": 1}`,
+			wantStatusCode: http.StatusCreated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/cookbooks/"+cookbookId.String()+"/recipes", strings.NewReader(tc.requestBody))
+			req.Header.Add("content-type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			cookbookService := mock_cookbook.NewMockCookbookService(gomock.NewController(t))
+
+			cookbookService.EXPECT().
+				GetCookbookById(gomock.Any(), cookbookId, tc.session.UserID).
+				Return(cookbook.CookbookResponse{CookbookID: cookbookId, UserID: ownerSession.UserID, IsPublic: tc.isPublic}, nil).
+				AnyTimes()
+
+			if tc.session == ownerSession && tc.wantStatusCode != http.StatusBadRequest {
+				cookbookService.EXPECT().
+					AddCookbookRecipe(gomock.Any(), cookbookId, gomock.Any()).
+					Return(tc.serviceErr)
+			}
+
+			handler := cookbook.NewHandler(logger, cookbookService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestRemoveCookbookRecipeHandler(t *testing.T) {
+	ownerSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "owner@mail.com"}
+	cookbookId := uuid.Must(uuid.NewV7())
+	recipeId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		recipeId       string
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			recipeId:       recipeId.String(),
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "recipe ID is not a valid UUID",
+			session:        ownerSession,
+			recipeId:       "123",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "owner removes a recipe",
+			session:        ownerSession,
+			recipeId:       recipeId.String(),
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/cookbooks/"+cookbookId.String()+"/recipes/"+tc.recipeId, nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			cookbookService := mock_cookbook.NewMockCookbookService(gomock.NewController(t))
+
+			if tc.wantStatusCode == http.StatusNoContent {
+				cookbookService.EXPECT().
+					GetCookbookById(gomock.Any(), cookbookId, tc.session.UserID).
+					Return(cookbook.CookbookResponse{CookbookID: cookbookId, UserID: ownerSession.UserID}, nil)
+
+				cookbookService.EXPECT().
+					RemoveCookbookRecipe(gomock.Any(), cookbookId, recipeId).
+					Return(nil)
+			}
+
+			handler := cookbook.NewHandler(logger, cookbookService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
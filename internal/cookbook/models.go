@@ -0,0 +1,59 @@
+package cookbook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CookbookResponse struct {
+	CookbookID  uuid.UUID                `json:"cookbook_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	UserID      uuid.UUID                `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Name        string                   `json:"name" example:"Weeknight dinners"`
+	Description string                   `json:"description" example:"Quick meals for busy evenings"`
+	IsPublic    bool                     `json:"is_public" example:"true"`
+	Recipes     []CookbookRecipeResponse `json:"recipes"`
+	CreatedAt   time.Time                `json:"created_at" example:"2025-02-05T21:35:31.00635Z"`
+	UpdatedAt   time.Time                `json:"updated_at" example:"2025-02-07T21:35:31.00635Z"`
+}
+
+type CookbookRecipeResponse struct {
+	Position int32     `json:"position" example:"1"`
+	RecipeID uuid.UUID `json:"recipe_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	UserID   uuid.UUID `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Title    string    `json:"title" example:"Chocolate Cookies"`
+	AddedAt  time.Time `json:"added_at" example:"2025-02-07T21:35:31.00635Z"`
+}
+
+type CookbookSummaryResponse struct {
+	CookbookID  uuid.UUID `json:"cookbook_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	UserID      uuid.UUID `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Name        string    `json:"name" example:"Weeknight dinners"`
+	Description string    `json:"description" example:"Quick meals for busy evenings"`
+	IsPublic    bool      `json:"is_public" example:"true"`
+	RecipeCount int32     `json:"recipe_count" example:"12"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-02-05T21:35:31.00635Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2025-02-07T21:35:31.00635Z"`
+}
+
+// CookbookRequest creates a private cookbook unless is_public is set.
+type CookbookRequest struct {
+	Name        string `json:"name" validate:"required,max=100" example:"Weeknight dinners"`
+	Description string `json:"description" validate:"max=1000" example:"Quick meals for busy evenings"`
+	IsPublic    bool   `json:"is_public" example:"true"`
+}
+
+// AddCookbookRecipeRequest inserts a recipe at the given position, moving the following recipes down.
+// The recipe is appended to the end of a cookbook when the position is omitted.
+type AddCookbookRecipeRequest struct {
+	RecipeID uuid.UUID `json:"recipe_id" validate:"required" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Position *int32    `json:"position" validate:"omitempty,min=1" example:"2"`
+}
+
+// ListCookbooksRequest lists the cookbooks of the signed-in user when the author is omitted.
+// Private cookbooks are listed only to their owner.
+type ListCookbooksRequest struct {
+	AuthorID uuid.UUID `query:"author_id"`
+	Cursor   string    `query:"cursor"`
+	Limit    int32     `query:"limit" validate:"omitempty,min=1,max=100"`
+}
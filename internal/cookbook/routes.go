@@ -0,0 +1,19 @@
+package cookbook
+
+import (
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes sets endpoints for Cookbook resource.
+// Routes that modify cookbooks are available only to signed-in users.
+func (h *handler) RegisterRoutes(e *echo.Echo) {
+	e.GET("api/v1/cookbooks", h.ListCookbooks)
+	e.POST("api/v1/cookbooks", h.CreateCookbook, session.RequireAuth)
+	e.GET("api/v1/cookbooks/:id", h.GetCookbookById)
+	e.PUT("api/v1/cookbooks/:id", h.UpdateCookbook, session.RequireAuth)
+	e.DELETE("api/v1/cookbooks/:id", h.DeleteCookbook, session.RequireAuth)
+
+	e.POST("api/v1/cookbooks/:id/recipes", h.AddCookbookRecipe, session.RequireAuth)
+	e.DELETE("api/v1/cookbooks/:id/recipes/:recipeId", h.RemoveCookbookRecipe, session.RequireAuth)
+}
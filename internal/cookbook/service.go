@@ -0,0 +1,412 @@
+package cookbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const queryExecutionTimeout = 3 * time.Second
+const acquireConnectionTimeout = 3 * time.Second
+
+// maxCookbookRecipes is the maximum number of recipes in a cookbook.
+const maxCookbookRecipes = 500
+
+var (
+	errPositionOutOfRange = errors.New("recipe position is out of range")
+	errTooManyRecipes     = errors.New("cookbook has reached the limit of recipes")
+	errRecipeNotFound     = errors.New("recipe does not exist or is deleted")
+)
+
+type service struct {
+	logger *zap.Logger
+	dbpool *pgxpool.Pool
+}
+
+func NewService(logger *zap.Logger, dbpool *pgxpool.Pool) *service {
+	return &service{
+		logger: logger,
+		dbpool: dbpool,
+	}
+}
+
+func (s *service) CreateCookbook(ctx context.Context, userId uuid.UUID, cookbookRequest CookbookRequest) (uuid.UUID, error) {
+	cookbookId, err := uuid.NewV7()
+	if err != nil {
+		return uuid.UUID{}, errors.Join(errors.New("failed to generate UUID"), err)
+	}
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		return q.CreateCookbook(qCtx, sqlc.CreateCookbookParams{
+			CookbookID:  cookbookId,
+			UserID:      userId,
+			Name:        cookbookRequest.Name,
+			Description: cookbookRequest.Description,
+			IsPublic:    cookbookRequest.IsPublic,
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("createCookbook method got uncaught error", zap.Error(err))
+			return uuid.UUID{}, err
+		}
+	}
+
+	return cookbookId, nil
+}
+
+// GetCookbookById returns a cookbook with its recipes in order.
+// Unpublished recipes are left out unless they belong to the viewer.
+func (s *service) GetCookbookById(ctx context.Context, cookbookId, viewerId uuid.UUID) (CookbookResponse, error) {
+	var cookbookResponse CookbookResponse
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		cookbookFromDb, err := q.GetCookbookById(qCtx, cookbookId)
+		if err != nil {
+			return err
+		}
+
+		recipesFromDb, err := q.GetCookbookRecipes(qCtx, sqlc.GetCookbookRecipesParams{
+			CookbookID: cookbookId,
+			ViewerID:   pgtype.UUID{Bytes: viewerId, Valid: viewerId != uuid.Nil},
+		})
+		if err != nil {
+			return err
+		}
+
+		recipes := make([]CookbookRecipeResponse, 0, len(recipesFromDb))
+
+		for _, recipe := range recipesFromDb {
+			recipes = append(recipes, CookbookRecipeResponse{
+				Position: recipe.Position,
+				RecipeID: recipe.RecipeID,
+				UserID:   recipe.UserID,
+				Title:    recipe.Title,
+				AddedAt:  recipe.AddedAt.Time,
+			})
+		}
+
+		cookbookResponse = CookbookResponse{
+			CookbookID:  cookbookFromDb.CookbookID,
+			UserID:      cookbookFromDb.UserID,
+			Name:        cookbookFromDb.Name,
+			Description: cookbookFromDb.Description,
+			IsPublic:    cookbookFromDb.IsPublic,
+			Recipes:     recipes,
+			CreatedAt:   cookbookFromDb.CreatedAt.Time,
+			UpdatedAt:   cookbookFromDb.UpdatedAt.Time,
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return CookbookResponse{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a cookbook with this ID"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return CookbookResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("getCookbookById method got uncaught error", zap.Error(err))
+			return CookbookResponse{}, err
+		}
+	}
+
+	return cookbookResponse, nil
+}
+
+func (s *service) UpdateCookbook(ctx context.Context, cookbookId uuid.UUID, cookbookRequest CookbookRequest) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		updatedRows, err := q.UpdateCookbook(qCtx, sqlc.UpdateCookbookParams{
+			CookbookID:  cookbookId,
+			Name:        cookbookRequest.Name,
+			Description: cookbookRequest.Description,
+			IsPublic:    cookbookRequest.IsPublic,
+		})
+		if err != nil {
+			return err
+		}
+
+		if updatedRows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a cookbook with this ID"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("updateCookbook method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteCookbook removes a cookbook. The recipes in it are left untouched.
+func (s *service) DeleteCookbook(ctx context.Context, cookbookId uuid.UUID) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		return q.DeleteCookbook(qCtx, cookbookId)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("deleteCookbook method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cookbookCursor stores the creation time and ID of the last cookbook returned on a page.
+type cookbookCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// ListCookbooks returns a page of cookbooks of a user, starting from the newest ones, and a cursor to the next page.
+// Private cookbooks are included only when includePrivate is set. The returned cursor is empty when there are no more cookbooks.
+func (s *service) ListCookbooks(ctx context.Context, userId uuid.UUID, includePrivate bool, listCookbooksRequest ListCookbooksRequest) ([]CookbookSummaryResponse, string, error) {
+	var cursor cookbookCursor
+
+	if listCookbooksRequest.Cursor != "" {
+		if err := shared.DecodeCursor(listCookbooksRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rowLimit := shared.PageLimit(listCookbooksRequest.Limit)
+
+	cookbooks := make([]CookbookSummaryResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListCookbooksByUserId(qCtx, sqlc.ListCookbooksByUserIdParams{
+			UserID:          userId,
+			IncludePrivate:  includePrivate,
+			CursorID:        pgtype.UUID{Bytes: cursor.ID, Valid: cursor.ID != uuid.Nil},
+			CursorCreatedAt: pgtype.Timestamp{Time: cursor.CreatedAt.UTC(), InfinityModifier: pgtype.Finite, Valid: !cursor.CreatedAt.IsZero()},
+			RowLimit:        rowLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			cookbooks = append(cookbooks, CookbookSummaryResponse{
+				CookbookID:  row.CookbookID,
+				UserID:      row.UserID,
+				Name:        row.Name,
+				Description: row.Description,
+				IsPublic:    row.IsPublic,
+				RecipeCount: row.RecipeCount,
+				CreatedAt:   row.CreatedAt.Time,
+				UpdatedAt:   row.UpdatedAt.Time,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listCookbooks method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return shared.Paginate(cookbooks, listCookbooksRequest.Limit, func(lastCookbook CookbookSummaryResponse) any {
+		return cookbookCursor{CreatedAt: lastCookbook.CreatedAt, ID: lastCookbook.CookbookID}
+	})
+}
+
+// AddCookbookRecipe inserts a recipe into a cookbook and moves the following recipes down.
+func (s *service) AddCookbookRecipe(ctx context.Context, cookbookId uuid.UUID, addCookbookRecipeRequest AddCookbookRecipeRequest) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		// Lock the cookbook, so concurrent changes cannot assign the same position twice.
+		if _, err := q.LockCookbook(qCtx, cookbookId); err != nil {
+			return err
+		}
+
+		recipesCount, err := q.CountCookbookRecipes(qCtx, cookbookId)
+		if err != nil {
+			return err
+		}
+
+		if recipesCount >= maxCookbookRecipes {
+			return errTooManyRecipes
+		}
+
+		position := int32(recipesCount) + 1
+
+		if addCookbookRecipeRequest.Position != nil {
+			if *addCookbookRecipeRequest.Position > position {
+				return errPositionOutOfRange
+			}
+
+			position = *addCookbookRecipeRequest.Position
+		}
+
+		err = q.ShiftCookbookRecipesDown(qCtx, sqlc.ShiftCookbookRecipesDownParams{
+			CookbookID: cookbookId,
+			Position:   position,
+		})
+		if err != nil {
+			return err
+		}
+
+		createdRows, err := q.CreateCookbookRecipe(qCtx, sqlc.CreateCookbookRecipeParams{
+			CookbookID: cookbookId,
+			RecipeID:   addCookbookRecipeRequest.RecipeID,
+			Position:   position,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Recipes are inserted only when the owner of the cookbook can read them, like in GetCookbookRecipes,
+		// so deleted recipes and unpublished or private recipes of other users cannot be added.
+		if createdRows == 0 {
+			return errRecipeNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		switch {
+		case errors.As(err, &pgErr) && pgErr.Code == "23505":
+			return echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "the recipe is already in the cookbook"})
+		case errors.Is(err, errRecipeNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a recipe with this ID"})
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a cookbook with this ID"})
+		case errors.Is(err, errPositionOutOfRange):
+			return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the position is greater than the number of recipes plus one"})
+		case errors.Is(err, errTooManyRecipes):
+			return echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: fmt.Sprintf("a cookbook can have at most %d recipes", maxCookbookRecipes)})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("addCookbookRecipe method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveCookbookRecipe removes a recipe from a cookbook and moves the following recipes up.
+func (s *service) RemoveCookbookRecipe(ctx context.Context, cookbookId, recipeId uuid.UUID) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		if _, err := q.LockCookbook(qCtx, cookbookId); err != nil {
+			return err
+		}
+
+		position, err := q.DeleteCookbookRecipe(qCtx, sqlc.DeleteCookbookRecipeParams{
+			CookbookID: cookbookId,
+			RecipeID:   recipeId,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.ShiftCookbookRecipesUp(qCtx, sqlc.ShiftCookbookRecipesUpParams{
+			CookbookID: cookbookId,
+			Position:   position,
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a recipe with this ID in the cookbook"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("removeCookbookRecipe method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
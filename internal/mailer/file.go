@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// File saves every message as an .eml file in a directory instead of sending it.
+// It is meant for development, where the messages can be opened in any mail client.
+type File struct {
+	dir  string
+	from string
+}
+
+// NewFile returns a mailer which saves messages from the from address to dir.
+func NewFile(dir, from string) *File {
+	return &File{
+		dir:  dir,
+		from: from,
+	}
+}
+
+func (f *File) Send(ctx context.Context, message Message) error {
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return err
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	// The name starts with the time, so the files are listed in the order the messages were sent.
+	name := now.Format("20060102T150405.000000000") + "-" + hex.EncodeToString(suffix) + ".eml"
+
+	return os.WriteFile(filepath.Join(f.dir, name), message.format(f.from, now), 0o600)
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	// given
+	dir := filepath.Join(t.TempDir(), "mail")
+	mailer := NewFile(dir, "noreply@recipes.local")
+
+	// when
+	err := mailer.Send(context.Background(), Message{To: "user@mail.com", Subject: "Reset your password", Body: "Hello,\nuse this link."})
+
+	// then
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, ".eml", filepath.Ext(files[0].Name()))
+
+	content, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "From: <noreply@recipes.local>\r\n")
+	assert.Contains(t, string(content), "To: <user@mail.com>\r\n")
+	assert.Contains(t, string(content), "Subject: Reset your password\r\n")
+	assert.Contains(t, string(content), "\r\n\r\nHello,\r\nuse this link.")
+}
+
+func TestMessageSubjectCannotAddHeaders(t *testing.T) {
+	// given
+	mailer := NewMemory()
+	message := Message{To: "user@mail.com", Subject: "Hello\r\nBcc: attacker@mail.com", Body: "body"}
+
+	// when
+	require.NoError(t, mailer.Send(context.Background(), message))
+
+	// then
+	assert.Equal(t, []Message{message}, mailer.Messages())
+	assert.NotContains(t, string(message.format("noreply@recipes.local", time.Now())), "\r\nBcc:")
+}
@@ -0,0 +1,43 @@
+// Package mailer sends emails, like the links to reset a password, to users.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"mime"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Message is a plain text email sent to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages to the mailboxes of users.
+type Mailer interface {
+	// Send delivers the message, or returns an error if it could not be handed over for delivery.
+	Send(ctx context.Context, message Message) error
+}
+
+// format returns the message encoded as an RFC 5322 email with CRLF line endings.
+func (m Message) format(from string, date time.Time) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("From: " + (&mail.Address{Address: from}).String() + "\r\n")
+	buf.WriteString("To: " + (&mail.Address{Address: m.To}).String() + "\r\n")
+	// Encoding the subject also escapes line breaks, so it cannot add headers to the message.
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", m.Subject) + "\r\n")
+	buf.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+
+	body := strings.ReplaceAll(m.Body, "\r\n", "\n")
+	buf.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return buf.Bytes()
+}
@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory keeps the sent messages in memory. It is meant for tests, which can read the messages back.
+type Memory struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemory returns a mailer without any sent messages.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Send(ctx context.Context, message Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, message)
+
+	return nil
+}
+
+// Messages returns the sent messages, starting from the oldest one.
+func (m *Memory) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.messages...)
+}
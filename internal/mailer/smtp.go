@@ -0,0 +1,91 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SMTPConfig describes the SMTP server messages are sent through.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	// From is the address messages are sent from.
+	From string
+}
+
+// SMTP sends messages through an SMTP server. The connection is upgraded with STARTTLS
+// when the server supports it, and the credentials are sent only if a username is set.
+type SMTP struct {
+	cfg    SMTPConfig
+	dialer net.Dialer
+}
+
+// NewSMTP returns a mailer which sends messages through the SMTP server from the config.
+func NewSMTP(cfg SMTPConfig) *SMTP {
+	return &SMTP{
+		cfg: cfg,
+	}
+}
+
+func (s *SMTP) Send(ctx context.Context, message Message) error {
+	conn, err := s.dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.cfg.Host, s.cfg.Port))
+	if err != nil {
+		return err
+	}
+
+	// The SMTP client does not take a context, so its deadline is applied to the connection instead.
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			_ = conn.Close()
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return err
+		}
+	}
+
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.cfg.From); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(message.To); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(message.format(s.cfg.From, time.Now())); err != nil {
+		_ = writer.Close()
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
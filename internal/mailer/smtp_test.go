@@ -0,0 +1,121 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTP is a stand-in for an SMTP server which accepts a single message without TLS.
+type fakeSMTP struct {
+	listener net.Listener
+	auth     chan string
+	from     chan string
+	to       chan string
+	data     chan string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	f := &fakeSMTP{
+		listener: listener,
+		auth:     make(chan string, 1),
+		from:     make(chan string, 1),
+		to:       make(chan string, 1),
+		data:     make(chan string, 1),
+	}
+
+	go f.serve()
+
+	return f
+}
+
+func (f *fakeSMTP) serve() {
+	conn, err := f.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 localhost ESMTP")
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command, argument, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(command) {
+		case "EHLO":
+			_ = text.PrintfLine("250-localhost")
+			_ = text.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			f.auth <- argument
+			_ = text.PrintfLine("235 authenticated")
+		case "MAIL":
+			f.from <- argument
+			_ = text.PrintfLine("250 ok")
+		case "RCPT":
+			f.to <- argument
+			_ = text.PrintfLine("250 ok")
+		case "DATA":
+			_ = text.PrintfLine("354 send the message")
+
+			lines, err := text.ReadDotLines()
+			if err != nil {
+				return
+			}
+
+			f.data <- strings.Join(lines, "\n")
+			_ = text.PrintfLine("250 queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func TestSMTP(t *testing.T) {
+	// given
+	server := newFakeSMTP(t)
+	host, port, _ := net.SplitHostPort(server.listener.Addr().String())
+
+	mailer := NewSMTP(SMTPConfig{
+		Host:     host,
+		Port:     port,
+		Username: "user",
+		Password: "secret",
+		From:     "noreply@recipes.local",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// when
+	err := mailer.Send(ctx, Message{To: "user@mail.com", Subject: "Reset your password", Body: "Use this link."})
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(<-server.auth, "PLAIN "))
+	assert.Equal(t, "FROM:<noreply@recipes.local>", <-server.from)
+	assert.Equal(t, "TO:<user@mail.com>", <-server.to)
+
+	data := <-server.data
+	assert.Contains(t, data, "Subject: Reset your password")
+	assert.True(t, strings.HasSuffix(data, "\nUse this link."))
+}
@@ -0,0 +1,304 @@
+package recipe
+
+import (
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// CreateComment godoc
+//
+//	@Summary		Comment on a recipe
+//	@Description	Post a comment on a recipe, or a reply to a top-level comment when parent_id is set. Replies cannot be replied to.
+//	@Description	Users mentioned with @email are saved to notify them.
+//	@Tags			comments
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string								true	"UUID of a recipe."
+//	@Param			CommentRequest	body		recipe.CommentRequest				true	"Request body with the text of a comment."
+//
+//	@Success		201				{object}	shared.CommonResponse				"Comment saved successfully."
+//	@Failure		400				{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401				{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403				{object}	shared.CommonResponse				"User has not verified the email."
+//	@Failure		404				{object}	shared.CommonResponse				"Recipe or parent comment not found."
+//
+//	@Router			/api/v1/recipes/{id}/comments [POST]
+func (h *handler) CreateComment(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestBody = CommentRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	if _, err := h.getReadableRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	commentId, err := h.recipeService.CreateComment(c.Request().Context(), recipeId, session.FromContext(c).UserID, requestBody)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully saved a comment on a recipe", zap.String("recipeId", recipeId.String()), zap.String("commentId", commentId.String()))
+
+	c.Response().Header().Add("Location", "/api/v1/recipes/"+recipeId.String()+"/comments/"+commentId.String())
+	return c.JSON(http.StatusCreated, shared.CommonResponse{Message: "successfully saved a comment"})
+}
+
+// ListComments godoc
+//
+//	@Summary		List comments on a recipe
+//	@Description	Get a page of top-level comments on a recipe, starting from the newest ones. Deleted comments with replies are listed as "comment removed".
+//	@Tags			comments
+//
+//	@Produce		json
+//
+//	@Param			id		path		string												true	"UUID of a recipe."
+//	@Param			cursor	query		string												false	"Cursor to the next page."
+//	@Param			limit	query		int													false	"Maximum number of comments on a page."	minimum(1)	maximum(100)	default(20)
+//
+//	@Success		200		{object}	shared.PagedDataResponse[recipe.CommentResponse]	"Comments fetched successfully."
+//	@Failure		400		{object}	validator.ValidationErrorResponse					"Invalid data provided."
+//	@Failure		404		{object}	shared.CommonResponse								"Recipe not found."
+//
+//	@Router			/api/v1/recipes/{id}/comments [GET]
+func (h *handler) ListComments(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	requestQuery, err := bindListCommentsRequest(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.getReadableRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	comments, nextCursor, err := h.recipeService.ListComments(c.Request().Context(), recipeId, requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[CommentResponse]{
+		Data: comments,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
+
+// ListCommentReplies godoc
+//
+//	@Summary		List replies to a comment
+//	@Description	Get a page of replies to a top-level comment, starting from the oldest ones.
+//	@Tags			comments
+//
+//	@Produce		json
+//
+//	@Param			id			path		string												true	"UUID of a recipe."
+//	@Param			commentId	path		string												true	"UUID of a top-level comment."
+//	@Param			cursor		query		string												false	"Cursor to the next page."
+//	@Param			limit		query		int													false	"Maximum number of replies on a page."	minimum(1)	maximum(100)	default(20)
+//
+//	@Success		200			{object}	shared.PagedDataResponse[recipe.CommentResponse]	"Replies fetched successfully."
+//	@Failure		400			{object}	validator.ValidationErrorResponse					"Invalid data provided."
+//	@Failure		404			{object}	shared.CommonResponse								"Recipe or comment not found."
+//
+//	@Router			/api/v1/recipes/{id}/comments/{commentId}/replies [GET]
+func (h *handler) ListCommentReplies(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	commentId, err := parseCommentId(c)
+	if err != nil {
+		return err
+	}
+
+	requestQuery, err := bindListCommentsRequest(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.getReadableRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	replies, nextCursor, err := h.recipeService.ListCommentReplies(c.Request().Context(), recipeId, commentId, requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[CommentResponse]{
+		Data: replies,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
+
+// UpdateComment godoc
+//
+//	@Summary		Edit a comment
+//	@Description	Replace the text of a comment. Only the author can edit a comment.
+//	@Tags			comments
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string								true	"UUID of a recipe."
+//	@Param			commentId				path		string								true	"UUID of a comment."
+//	@Param			UpdateCommentRequest	body		recipe.UpdateCommentRequest			true	"Request body with the new text of a comment."
+//
+//	@Success		204						"Comment updated successfully."
+//	@Failure		400						{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401						{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403						{object}	shared.CommonResponse				"User is not the author of the comment or has not verified the email."
+//	@Failure		404						{object}	shared.CommonResponse				"Comment not found."
+//
+//	@Router			/api/v1/recipes/{id}/comments/{commentId} [PUT]
+func (h *handler) UpdateComment(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	commentId, err := parseCommentId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestBody = UpdateCommentRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	if err := h.checkCommentAuthor(c, recipeId, commentId); err != nil {
+		return err
+	}
+
+	if err := h.recipeService.UpdateComment(c.Request().Context(), commentId, requestBody); err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully updated a comment", zap.String("recipeId", recipeId.String()), zap.String("commentId", commentId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteComment godoc
+//
+//	@Summary		Delete a comment
+//	@Description	Delete a comment. Replies to a deleted comment stay visible under a "comment removed" placeholder. Only the author can delete a comment.
+//	@Tags			comments
+//
+//	@Produce		json
+//	@Param			id			path	string	true	"UUID of a recipe."
+//	@Param			commentId	path	string	true	"UUID of a comment."
+//
+//	@Success		204			"Comment deleted successfully."
+//	@Failure		400			{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401			{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		403			{object}	shared.CommonResponse	"User is not the author of the comment."
+//	@Failure		404			{object}	shared.CommonResponse	"Comment not found."
+//
+//	@Router			/api/v1/recipes/{id}/comments/{commentId} [DELETE]
+func (h *handler) DeleteComment(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	commentId, err := parseCommentId(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.checkCommentAuthor(c, recipeId, commentId); err != nil {
+		return err
+	}
+
+	if err := h.recipeService.DeleteComment(c.Request().Context(), commentId); err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully deleted a comment", zap.String("recipeId", recipeId.String()), zap.String("commentId", commentId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseCommentId reads the UUID of a comment from the commentId path param.
+func parseCommentId(c echo.Context) (uuid.UUID, error) {
+	commentId, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		return uuid.UUID{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received comment ID is not a valid UUID"})
+	}
+
+	return commentId, nil
+}
+
+// bindListCommentsRequest reads and validates the pagination of comments, using the default limit when it is omitted.
+func bindListCommentsRequest(c echo.Context) (ListCommentsRequest, error) {
+	var requestQuery = ListCommentsRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return ListCommentsRequest{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return ListCommentsRequest{}, err
+	}
+
+	if requestQuery.Limit == 0 {
+		requestQuery.Limit = defaultListLimit
+	}
+
+	return requestQuery, nil
+}
+
+// checkCommentAuthor fetches a comment and checks if it was written by the signed-in user.
+func (h *handler) checkCommentAuthor(c echo.Context, recipeId, commentId uuid.UUID) error {
+	comment, err := h.recipeService.GetComment(c.Request().Context(), recipeId, commentId)
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID == nil || *comment.UserID != session.FromContext(c).UserID {
+		return echo.NewHTTPError(http.StatusForbidden, shared.CommonResponse{Message: "only the author of the comment can modify it"})
+	}
+
+	return nil
+}
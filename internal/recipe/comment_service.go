@@ -0,0 +1,377 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// deletedCommentBody replaces the text of a deleted comment, which is kept to show its replies.
+const deletedCommentBody = "comment removed"
+
+var (
+	errParentCommentNotFound = errors.New("parent comment does not exist")
+	errNestedReply           = errors.New("parent comment is a reply")
+)
+
+// CreateComment saves a comment on a recipe or a reply to a top-level comment, with the users it mentions.
+func (s *service) CreateComment(ctx context.Context, recipeId, userId uuid.UUID, commentRequest CommentRequest) (uuid.UUID, error) {
+	commentId, err := uuid.NewV7()
+	if err != nil {
+		return uuid.UUID{}, errors.Join(errors.New("failed to generate UUID"), err)
+	}
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		var parentId pgtype.UUID
+
+		if commentRequest.ParentID != nil {
+			parent, err := q.GetCommentById(qCtx, sqlc.GetCommentByIdParams{
+				CommentID: *commentRequest.ParentID,
+				RecipeID:  recipeId,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errParentCommentNotFound
+			}
+			if err != nil {
+				return err
+			}
+
+			if parent.DeletedAt.Valid {
+				return errParentCommentNotFound
+			}
+
+			if parent.ParentID.Valid {
+				return errNestedReply
+			}
+
+			parentId = toPgUUID(parent.CommentID)
+		}
+
+		err := q.CreateComment(qCtx, sqlc.CreateCommentParams{
+			CommentID: commentId,
+			RecipeID:  recipeId,
+			UserID:    userId,
+			ParentID:  parentId,
+			Body:      commentRequest.Body,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.CreateCommentMentions(qCtx, sqlc.CreateCommentMentionsParams{
+			CommentID: commentId,
+			Handles:   parseMentions(commentRequest.Body),
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errParentCommentNotFound):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a parent comment with this ID in the recipe"})
+		case errors.Is(err, errNestedReply):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "replies can only be posted to top-level comments"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("createComment method got uncaught error", zap.Error(err))
+			return uuid.UUID{}, err
+		}
+	}
+
+	return commentId, nil
+}
+
+// GetComment returns a comment on a recipe. Deleted comments are reported as not found.
+func (s *service) GetComment(ctx context.Context, recipeId, commentId uuid.UUID) (CommentResponse, error) {
+	var commentResponse CommentResponse
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		comment, err := q.GetCommentById(qCtx, sqlc.GetCommentByIdParams{
+			CommentID: commentId,
+			RecipeID:  recipeId,
+		})
+		if err != nil {
+			return err
+		}
+
+		if comment.DeletedAt.Valid {
+			return pgx.ErrNoRows
+		}
+
+		commentResponse = newCommentResponse(comment.CommentID, comment.UserID, comment.Body, comment.CreatedAt, comment.UpdatedAt, comment.DeletedAt)
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return CommentResponse{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a comment with this ID in the recipe"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return CommentResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("getComment method got uncaught error", zap.Error(err))
+			return CommentResponse{}, err
+		}
+	}
+
+	return commentResponse, nil
+}
+
+// UpdateComment replaces the text of a comment and the users it mentions.
+func (s *service) UpdateComment(ctx context.Context, commentId uuid.UUID, updateCommentRequest UpdateCommentRequest) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		updatedRows, err := q.UpdateCommentBody(qCtx, sqlc.UpdateCommentBodyParams{
+			CommentID: commentId,
+			Body:      updateCommentRequest.Body,
+		})
+		if err != nil {
+			return err
+		}
+
+		if updatedRows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		if err := q.DeleteCommentMentions(qCtx, commentId); err != nil {
+			return err
+		}
+
+		return q.CreateCommentMentions(qCtx, sqlc.CreateCommentMentionsParams{
+			CommentID: commentId,
+			Handles:   parseMentions(updateCommentRequest.Body),
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a comment with this ID in the recipe"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("updateComment method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteComment removes the text and mentions of a comment, but keeps the comment, so its replies stay visible.
+func (s *service) DeleteComment(ctx context.Context, commentId uuid.UUID) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		deletedRows, err := q.SoftDeleteComment(qCtx, commentId)
+		if err != nil {
+			return err
+		}
+
+		if deletedRows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return q.DeleteCommentMentions(qCtx, commentId)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a comment with this ID in the recipe"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("deleteComment method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commentCursor stores the creation time and ID of the last comment returned on a page.
+type commentCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// ListComments returns a page of top-level comments on a recipe, starting from the newest ones,
+// and a cursor to the next page. Deleted comments are listed only when they have replies.
+// The returned cursor is empty when there are no more comments.
+func (s *service) ListComments(ctx context.Context, recipeId uuid.UUID, listCommentsRequest ListCommentsRequest) ([]CommentResponse, string, error) {
+	var cursor commentCursor
+
+	if listCommentsRequest.Cursor != "" {
+		if err := shared.DecodeCursor(listCommentsRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rowLimit := shared.PageLimit(listCommentsRequest.Limit)
+
+	comments := make([]CommentResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListRecipeComments(qCtx, sqlc.ListRecipeCommentsParams{
+			RecipeID:        recipeId,
+			CursorID:        toPgUUID(cursor.ID),
+			CursorCreatedAt: toPgTimestamp(cursor.CreatedAt),
+			RowLimit:        rowLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			comment := newCommentResponse(row.CommentID, row.UserID, row.Body, row.CreatedAt, row.UpdatedAt, row.DeletedAt)
+			comment.ReplyCount = row.ReplyCount
+
+			comments = append(comments, comment)
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listComments method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return paginateComments(comments, listCommentsRequest.Limit)
+}
+
+// ListCommentReplies returns a page of replies to a top-level comment, starting from the oldest ones,
+// and a cursor to the next page. The returned cursor is empty when there are no more replies.
+func (s *service) ListCommentReplies(ctx context.Context, recipeId, commentId uuid.UUID, listCommentsRequest ListCommentsRequest) ([]CommentResponse, string, error) {
+	var cursor commentCursor
+
+	if listCommentsRequest.Cursor != "" {
+		if err := shared.DecodeCursor(listCommentsRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rowLimit := shared.PageLimit(listCommentsRequest.Limit)
+
+	replies := make([]CommentResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		// Check that the comment belongs to the recipe, so replies are not read through another recipe.
+		if _, err := q.GetCommentById(qCtx, sqlc.GetCommentByIdParams{CommentID: commentId, RecipeID: recipeId}); err != nil {
+			return err
+		}
+
+		rows, err := q.ListCommentReplies(qCtx, sqlc.ListCommentRepliesParams{
+			ParentID:        toPgUUID(commentId),
+			CursorID:        toPgUUID(cursor.ID),
+			CursorCreatedAt: toPgTimestamp(cursor.CreatedAt),
+			RowLimit:        rowLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			replies = append(replies, newCommentResponse(row.CommentID, row.UserID, row.Body, row.CreatedAt, row.UpdatedAt, pgtype.Timestamp{}))
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, "", echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a comment with this ID in the recipe"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listCommentReplies method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return paginateComments(replies, listCommentsRequest.Limit)
+}
+
+// paginateComments trims the comments fetched with one extra row to the limit and encodes a cursor to the next page.
+func paginateComments(comments []CommentResponse, limit int32) ([]CommentResponse, string, error) {
+	return shared.Paginate(comments, limit, func(lastComment CommentResponse) any {
+		return commentCursor{CreatedAt: lastComment.CreatedAt, ID: lastComment.CommentID}
+	})
+}
+
+func newCommentResponse(commentId, userId uuid.UUID, body string, createdAt, updatedAt, deletedAt pgtype.Timestamp) CommentResponse {
+	if deletedAt.Valid {
+		return CommentResponse{
+			CommentID: commentId,
+			Body:      deletedCommentBody,
+			IsDeleted: true,
+			CreatedAt: createdAt.Time,
+			UpdatedAt: deletedAt.Time,
+		}
+	}
+
+	return CommentResponse{
+		CommentID: commentId,
+		UserID:    &userId,
+		Body:      body,
+		CreatedAt: createdAt.Time,
+		UpdatedAt: updatedAt.Time,
+	}
+}
@@ -0,0 +1,183 @@
+package recipe
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/textdiff"
+	"github.com/danielbukowski/recipe-app-backend/internal/units"
+	"github.com/google/uuid"
+)
+
+// ingredientLineRegexp matches lines of content which list an ingredient, like "- 200 g dark chocolate" or "2 eggs".
+// A line has to start with a bullet or an amount, so sentences of the method are not taken for ingredients.
+var ingredientLineRegexp = regexp.MustCompile(`^(?:[-*•]\s*|(\d+(?:[.,]\d+)?(?:\s+\d+/\d+)?|\d+/\d+)\s+)` +
+	`(?:(\d+(?:[.,]\d+)?(?:\s+\d+/\d+)?|\d+/\d+)\s+)?(.+)$`)
+
+// maxIngredientLineLength is the length of the longest line of content which can be an ingredient.
+const maxIngredientLineLength = 80
+
+// maxUnmeasuredIngredientWords is the number of words in the longest ingredient listed without an amount.
+const maxUnmeasuredIngredientWords = 4
+
+// extractedIngredient is an ingredient of a recipe with its quantity as written in the recipe.
+type extractedIngredient struct {
+	name     string
+	quantity string
+}
+
+// compareRecipes aligns two recipes: it diffs their titles and contents word by word
+// and matches their ingredients by normalized names.
+// The similarity is the mean of the similarity of contents and the share of common ingredients.
+func compareRecipes(recipeIdA uuid.UUID, recipeA RecipeResponse, recipeIdB uuid.UUID, recipeB RecipeResponse) RecipeComparisonResponse {
+	contentDiff := textdiff.Words(recipeA.Content, recipeB.Content)
+
+	ingredientsA := extractIngredients(recipeA)
+	ingredientsB := extractIngredients(recipeB)
+
+	ingredients := make([]IngredientComparisonResponse, 0, len(ingredientsA)+len(ingredientsB))
+	commonIngredients := 0
+
+	for _, ingredient := range ingredientsA {
+		quantityA := ingredient.quantity
+		comparison := IngredientComparisonResponse{Name: ingredient.name, QuantityA: &quantityA}
+
+		if i := slices.IndexFunc(ingredientsB, func(other extractedIngredient) bool { return other.name == ingredient.name }); i >= 0 {
+			quantityB := ingredientsB[i].quantity
+			comparison.QuantityB = &quantityB
+			commonIngredients++
+		}
+
+		ingredients = append(ingredients, comparison)
+	}
+
+	for _, ingredient := range ingredientsB {
+		if !slices.ContainsFunc(ingredientsA, func(other extractedIngredient) bool { return other.name == ingredient.name }) {
+			quantityB := ingredient.quantity
+			ingredients = append(ingredients, IngredientComparisonResponse{Name: ingredient.name, QuantityB: &quantityB})
+		}
+	}
+
+	similarity := textdiff.Similarity(contentDiff)
+
+	if len(ingredients) > 0 {
+		similarity = (similarity + float64(commonIngredients)/float64(len(ingredients))) / 2
+	}
+
+	return RecipeComparisonResponse{
+		A:           ComparedRecipeResponse{RecipeID: recipeIdA, UserID: recipeA.UserID, Title: recipeA.Title},
+		B:           ComparedRecipeResponse{RecipeID: recipeIdB, UserID: recipeB.UserID, Title: recipeB.Title},
+		TitleDiff:   textdiff.Words(recipeA.Title, recipeB.Title),
+		ContentDiff: contentDiff,
+		Ingredients: ingredients,
+		Similarity:  math.Round(similarity*100) / 100,
+	}
+}
+
+// extractIngredients returns the structured ingredients of a recipe followed by the ingredient lines found in its content.
+// Every ingredient is listed once, under its normalized name.
+func extractIngredients(recipe RecipeResponse) []extractedIngredient {
+	var ingredients []extractedIngredient
+
+	addIngredient := func(name, quantity string) {
+		name = normalizeIngredientName(name)
+
+		if name != "" && !slices.ContainsFunc(ingredients, func(other extractedIngredient) bool { return other.name == name }) {
+			ingredients = append(ingredients, extractedIngredient{name: name, quantity: quantity})
+		}
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		addIngredient(ingredient.Name, strings.TrimSpace(ingredient.QuantityText+" "+ingredient.Unit))
+	}
+
+	for _, line := range strings.Split(recipe.Content, "\n") {
+		line = strings.TrimSpace(line)
+
+		if len(line) > maxIngredientLineLength {
+			continue
+		}
+
+		match := ingredientLineRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		amount := match[1]
+		if amount == "" {
+			amount = match[2]
+		}
+
+		name := match[3]
+		quantity := amount
+
+		// Bullets without an amount list ingredients like "- salt", but also steps of the method.
+		if amount == "" && len(strings.Fields(name)) > maxUnmeasuredIngredientWords {
+			continue
+		}
+
+		// The first word of the name can be a unit, like in "200 g flour".
+		if unitName, rest, ok := strings.Cut(name, " "); ok && amount != "" {
+			if _, isUnit := units.Parse(unitName); isUnit {
+				name = rest
+				quantity = amount + " " + unitName
+			}
+		}
+
+		addIngredient(name, quantity)
+	}
+
+	return ingredients
+}
+
+// normalizeIngredientName reduces the name of an ingredient to a form which is the same in different recipes,
+// so "Eggs (large), beaten" matches "egg".
+func normalizeIngredientName(name string) string {
+	name = strings.ToLower(name)
+
+	// Drop preparation notes, which follow a comma or are put in parentheses.
+	name, _, _ = strings.Cut(name, ",")
+
+	for {
+		start := strings.Index(name, "(")
+		end := strings.Index(name, ")")
+
+		if start < 0 || end < start {
+			break
+		}
+
+		name = name[:start] + " " + name[end+1:]
+	}
+
+	name = strings.TrimPrefix(strings.TrimSpace(name), "of ")
+
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r > 127)
+	})
+
+	if len(words) == 0 {
+		return ""
+	}
+
+	words[len(words)-1] = singular(words[len(words)-1])
+
+	return strings.Join(words, " ")
+}
+
+// singular turns the regular plural forms of English nouns into singular forms, e.g. "tomatoes" into "tomato".
+func singular(word string) string {
+	switch {
+	case len(word) <= 3 || strings.HasSuffix(word, "ss"):
+		return word
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	default:
+		return word
+	}
+}
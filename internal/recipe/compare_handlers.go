@@ -0,0 +1,61 @@
+package recipe
+
+import (
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// CompareRecipes godoc
+//
+//	@Summary		Compare recipes
+//	@Description	Compare two recipes side by side: word-level diffs of titles and contents, ingredients matched by name with their quantities and a similarity score.
+//	@Tags			recipes
+//
+//	@Produce		json
+//
+//	@Param			a	query		string											true	"UUID of the first recipe."
+//	@Param			b	query		string											true	"UUID of the second recipe."
+//
+//	@Success		200	{object}	shared.DataResponse[recipe.RecipeComparisonResponse]	"Recipes compared successfully."
+//	@Failure		400	{object}	validator.ValidationErrorResponse						"Invalid data provided."
+//	@Failure		404	{object}	shared.CommonResponse									"Recipe is not found."
+//
+//	@Router			/api/v1/recipes/compare [GET]
+func (h *handler) CompareRecipes(c echo.Context) error {
+	var requestQuery = CompareRecipesRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	recipeIdA, err := uuid.Parse(requestQuery.A)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received ID of the first recipe is not a valid UUID"})
+	}
+
+	recipeIdB, err := uuid.Parse(requestQuery.B)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received ID of the second recipe is not a valid UUID"})
+	}
+
+	recipeA, err := h.getReadableRecipe(c, recipeIdA)
+	if err != nil {
+		return err
+	}
+
+	recipeB, err := h.getReadableRecipe(c, recipeIdB)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[RecipeComparisonResponse]{
+		Data: compareRecipes(recipeIdA, recipeA, recipeIdB, recipeB),
+	})
+}
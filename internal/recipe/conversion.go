@@ -0,0 +1,64 @@
+package recipe
+
+import (
+	"github.com/danielbukowski/recipe-app-backend/internal/units"
+)
+
+// measurementSystems maps the measurement systems users can choose to systems of units.
+// Imperial quantities are written in US customary units, which is what our imperial users cook with.
+var measurementSystems = map[string]units.System{
+	"metric":   units.Metric,
+	"imperial": units.USCustomary,
+}
+
+// convertRecipe returns the recipe with quantities and oven temperatures converted to the system,
+// both in ingredients and steps and in the text of the recipe.
+func convertRecipe(recipe RecipeResponse, system units.System) RecipeResponse {
+	recipe.Content = units.ConvertText(recipe.Content, system)
+
+	convertedIngredients := make([]IngredientResponse, 0, len(recipe.Ingredients))
+
+	for _, ingredient := range recipe.Ingredients {
+		ingredient.Note = units.ConvertText(ingredient.Note, system)
+
+		unit, ok := units.Parse(ingredient.Unit)
+		if ingredient.Quantity == nil || !ok || unit.System == system {
+			convertedIngredients = append(convertedIngredients, ingredient)
+			continue
+		}
+
+		convertedQuantity := units.Quantity{Amount: *ingredient.Quantity, Unit: unit}.ConvertTo(system)
+		quantity := roundQuantity(convertedQuantity.Amount)
+
+		ingredient.Quantity = &quantity
+		ingredient.Unit = convertedQuantity.Unit.Name(quantity)
+		ingredient.QuantityText = units.FormatAmount(quantity, system)
+
+		convertedIngredients = append(convertedIngredients, ingredient)
+	}
+
+	convertedSteps := make([]StepResponse, 0, len(recipe.Steps))
+
+	for _, step := range recipe.Steps {
+		step.Instruction = units.ConvertText(step.Instruction, system)
+
+		if step.Temperature != nil && step.TemperatureUnit != nil {
+			if scale, ok := units.ParseTemperatureScale(*step.TemperatureUnit); ok && scale != units.ScaleOf(system) {
+				convertedTemperature := units.Temperature{Degrees: float64(*step.Temperature), Scale: scale}.ConvertTo(units.ScaleOf(system))
+
+				temperature := int32(convertedTemperature.Degrees)
+				temperatureUnit := convertedTemperature.Scale.Symbol()
+
+				step.Temperature = &temperature
+				step.TemperatureUnit = &temperatureUnit
+			}
+		}
+
+		convertedSteps = append(convertedSteps, step)
+	}
+
+	recipe.Ingredients = convertedIngredients
+	recipe.Steps = convertedSteps
+
+	return recipe
+}
@@ -0,0 +1,138 @@
+package recipe
+
+import (
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// AddFavoriteRecipe godoc
+//
+//	@Summary		Add a recipe to favorites
+//	@Description	Save a recipe to the favorites of the signed-in user. Adding a favorite recipe again does nothing.
+//	@Tags			favorites
+//
+//	@Produce		json
+//	@Param			recipeId	path	string	true	"UUID of a recipe."
+//
+//	@Success		204			"Recipe added to favorites successfully."
+//	@Failure		400			{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401			{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		404			{object}	shared.CommonResponse	"Recipe not found."
+//
+//	@Router			/api/v1/me/favorites/{recipeId} [PUT]
+func (h *handler) AddFavoriteRecipe(c echo.Context) error {
+	recipeId, err := parseFavoriteRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.getReadableRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	userId := session.FromContext(c).UserID
+
+	if err := h.recipeService.AddFavoriteRecipe(c.Request().Context(), userId, recipeId); err != nil {
+		return err
+	}
+
+	// The cached recipe holds the favorite count, which may have just changed.
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully added a recipe to favorites", zap.String("recipeId", recipeId.String()), zap.String("userId", userId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RemoveFavoriteRecipe godoc
+//
+//	@Summary		Remove a recipe from favorites
+//	@Description	Remove a recipe from the favorites of the signed-in user. Removing a recipe which is not a favorite one does nothing.
+//	@Tags			favorites
+//
+//	@Produce		json
+//	@Param			recipeId	path	string	true	"UUID of a recipe."
+//
+//	@Success		204			"Recipe removed from favorites successfully."
+//	@Failure		400			{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401			{object}	shared.CommonResponse	"User is not signed in."
+//
+//	@Router			/api/v1/me/favorites/{recipeId} [DELETE]
+func (h *handler) RemoveFavoriteRecipe(c echo.Context) error {
+	recipeId, err := parseFavoriteRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	userId := session.FromContext(c).UserID
+
+	if err := h.recipeService.RemoveFavoriteRecipe(c.Request().Context(), userId, recipeId); err != nil {
+		return err
+	}
+
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully removed a recipe from favorites", zap.String("recipeId", recipeId.String()), zap.String("userId", userId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListFavoriteRecipes godoc
+//
+//	@Summary		List favorite recipes
+//	@Description	Get a page of recipes saved to the favorites of the signed-in user, starting from the most recently saved ones.
+//	@Tags			favorites
+//
+//	@Produce		json
+//
+//	@Param			cursor	query		string													false	"Cursor to the next page."
+//	@Param			limit	query		int														false	"Maximum number of recipes on a page."	minimum(1)	maximum(100)	default(20)
+//
+//	@Success		200		{object}	shared.PagedDataResponse[recipe.FavoriteRecipeResponse]	"Favorite recipes fetched successfully."
+//	@Failure		400		{object}	validator.ValidationErrorResponse						"Invalid data provided."
+//	@Failure		401		{object}	shared.CommonResponse									"User is not signed in."
+//
+//	@Router			/api/v1/me/favorites [GET]
+func (h *handler) ListFavoriteRecipes(c echo.Context) error {
+	var requestQuery = ListFavoritesRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	if requestQuery.Limit == 0 {
+		requestQuery.Limit = defaultListLimit
+	}
+
+	recipes, nextCursor, err := h.recipeService.ListFavoriteRecipes(c.Request().Context(), session.FromContext(c).UserID, requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[FavoriteRecipeResponse]{
+		Data: recipes,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
+
+// parseFavoriteRecipeId reads the UUID of a recipe from the recipeId path param.
+func parseFavoriteRecipeId(c echo.Context) (uuid.UUID, error) {
+	recipeId, err := uuid.Parse(c.Param("recipeId"))
+	if err != nil {
+		return uuid.UUID{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received recipe ID is not a valid UUID"})
+	}
+
+	return recipeId, nil
+}
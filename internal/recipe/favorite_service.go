@@ -0,0 +1,203 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// AddFavoriteRecipe saves a recipe to the favorites of a user. Adding a favorite recipe again does nothing.
+func (s *service) AddFavoriteRecipe(ctx context.Context, userId, recipeId uuid.UUID) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		createdRows, err := q.CreateFavorite(qCtx, sqlc.CreateFavoriteParams{
+			UserID:   userId,
+			RecipeID: recipeId,
+		})
+		if err != nil {
+			return err
+		}
+
+		if createdRows == 0 {
+			return nil
+		}
+
+		return q.UpdateRecipeFavoriteCount(qCtx, sqlc.UpdateRecipeFavoriteCountParams{
+			RecipeID:           recipeId,
+			FavoriteCountDelta: 1,
+		})
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		switch {
+		case errors.As(err, &pgErr) && pgErr.Code == "23503":
+			// The recipe has been deleted in the meantime.
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a recipe with this ID"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("addFavoriteRecipe method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveFavoriteRecipe removes a recipe from the favorites of a user.
+// Removing a recipe which is not a favorite one does nothing.
+func (s *service) RemoveFavoriteRecipe(ctx context.Context, userId, recipeId uuid.UUID) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		deletedRows, err := q.DeleteFavorite(qCtx, sqlc.DeleteFavoriteParams{
+			UserID:   userId,
+			RecipeID: recipeId,
+		})
+		if err != nil {
+			return err
+		}
+
+		if deletedRows == 0 {
+			return nil
+		}
+
+		return q.UpdateRecipeFavoriteCount(qCtx, sqlc.UpdateRecipeFavoriteCountParams{
+			RecipeID:           recipeId,
+			FavoriteCountDelta: -1,
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("removeFavoriteRecipe method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsFavoriteRecipe checks if a user has saved a recipe to their favorites.
+func (s *service) IsFavoriteRecipe(ctx context.Context, userId, recipeId uuid.UUID) (bool, error) {
+	var isFavorite bool
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		exists, err := q.IsFavoriteRecipe(qCtx, sqlc.IsFavoriteRecipeParams{
+			UserID:   userId,
+			RecipeID: recipeId,
+		})
+		isFavorite = exists
+
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return false, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("isFavoriteRecipe method got uncaught error", zap.Error(err))
+			return false, err
+		}
+	}
+
+	return isFavorite, nil
+}
+
+// favoriteCursor stores the time the last recipe on a page was saved to favorites and its ID.
+type favoriteCursor struct {
+	FavoritedAt time.Time `json:"f"`
+	ID          uuid.UUID `json:"id"`
+}
+
+// ListFavoriteRecipes returns a page of favorite recipes of a user, starting from the most recently saved ones,
+// and a cursor to the next page. The returned cursor is empty when there are no more recipes.
+func (s *service) ListFavoriteRecipes(ctx context.Context, userId uuid.UUID, listFavoritesRequest ListFavoritesRequest) ([]FavoriteRecipeResponse, string, error) {
+	var cursor favoriteCursor
+
+	if listFavoritesRequest.Cursor != "" {
+		if err := shared.DecodeCursor(listFavoritesRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rowLimit := shared.PageLimit(listFavoritesRequest.Limit)
+
+	recipes := make([]FavoriteRecipeResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListFavoriteRecipes(qCtx, sqlc.ListFavoriteRecipesParams{
+			UserID:            userId,
+			CursorID:          toPgUUID(cursor.ID),
+			CursorFavoritedAt: toPgTimestamp(cursor.FavoritedAt),
+			RowLimit:          rowLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			recipes = append(recipes, FavoriteRecipeResponse{
+				RecipeSummaryResponse: newRecipeSummaryResponse(row.RecipeID, row.UserID, row.Title, row.FavoriteCount, row.CreatedAt, row.UpdatedAt),
+				FavoritedAt:           row.FavoritedAt.Time,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listFavoriteRecipes method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return shared.Paginate(recipes, listFavoritesRequest.Limit, func(lastRecipe FavoriteRecipeResponse) any {
+		return favoriteCursor{FavoritedAt: lastRecipe.FavoritedAt, ID: lastRecipe.RecipeID}
+	})
+}
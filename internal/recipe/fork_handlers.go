@@ -0,0 +1,105 @@
+package recipe
+
+import (
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// ForkRecipe godoc
+//
+//	@Summary		Fork a recipe
+//	@Description	Copy a recipe with its ingredients, steps and tags into the account of the signed-in user. Images are not copied.
+//	@Description	The fork is saved as a draft and keeps a link to the original recipe, which stays visible even if the original is deleted.
+//	@Tags			recipes
+//
+//	@Produce		json
+//	@Param			id	path		string					true	"UUID of a recipe."
+//
+//	@Success		201	{object}	shared.CommonResponse	"Recipe forked successfully."
+//	@Failure		400	{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401	{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		404	{object}	shared.CommonResponse	"Recipe not found."
+//
+//	@Router			/api/v1/recipes/{id}/fork [POST]
+func (h *handler) ForkRecipe(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.getReadableRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	forkId, err := h.recipeService.ForkRecipe(c.Request().Context(), recipeId, session.FromContext(c).UserID)
+	if err != nil {
+		return err
+	}
+
+	// The cached original recipe holds an outdated fork count.
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully forked a recipe", zap.String("recipeId", recipeId.String()), zap.String("forkId", forkId.String()))
+
+	c.Response().Header().Add("Location", "/api/v1/recipes/"+forkId.String())
+	return c.JSON(http.StatusCreated, shared.CommonResponse{Message: "successfully forked a recipe"})
+}
+
+// ListRecipeForks godoc
+//
+//	@Summary		List forks of a recipe
+//	@Description	Get a page of published, public forks of a recipe, starting from the newest ones.
+//	@Tags			recipes
+//
+//	@Produce		json
+//
+//	@Param			id		path		string													true	"UUID of a recipe."
+//	@Param			cursor	query		string													false	"Cursor to the next page."
+//	@Param			limit	query		int														false	"Maximum number of forks on a page."	minimum(1)	maximum(100)	default(20)
+//
+//	@Success		200		{object}	shared.PagedDataResponse[recipe.RecipeSummaryResponse]	"Forks fetched successfully."
+//	@Failure		400		{object}	validator.ValidationErrorResponse						"Invalid data provided."
+//	@Failure		404		{object}	shared.CommonResponse									"Recipe not found."
+//
+//	@Router			/api/v1/recipes/{id}/forks [GET]
+func (h *handler) ListRecipeForks(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestQuery = ListForksRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	if requestQuery.Limit == 0 {
+		requestQuery.Limit = defaultListLimit
+	}
+
+	if _, err := h.getReadableRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	forks, nextCursor, err := h.recipeService.ListRecipeForks(c.Request().Context(), recipeId, requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[RecipeSummaryResponse]{
+		Data: forks,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
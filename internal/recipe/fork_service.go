@@ -0,0 +1,173 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// forkCursor stores the creation time and ID of the last fork returned on a page.
+type forkCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// ForkRecipe copies a recipe with its ingredients, steps and tags into the account of a user and returns the ID of the copy.
+// The copy is saved as a draft which points back to the original recipe. Images are not copied,
+// because they are stored once per recipe and would be deleted with the recipe that is purged first.
+func (s *service) ForkRecipe(ctx context.Context, recipeId, userId uuid.UUID) (uuid.UUID, error) {
+	forkId, err := uuid.NewV7()
+	if err != nil {
+		return uuid.UUID{}, errors.Join(errors.New("failed to generate UUID"), err)
+	}
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		if _, err := q.ForkRecipe(qCtx, sqlc.ForkRecipeParams{
+			ForkID:   forkId,
+			UserID:   userId,
+			RecipeID: recipeId,
+		}); err != nil {
+			return err
+		}
+
+		if err := q.CopyRecipeIngredients(qCtx, sqlc.CopyRecipeIngredientsParams{ToRecipeID: forkId, FromRecipeID: recipeId}); err != nil {
+			return err
+		}
+
+		steps, err := q.GetRecipeStepsByRecipeId(qCtx, recipeId)
+		if err != nil {
+			return err
+		}
+
+		// Steps are identified by their own IDs, so every step gets a new one.
+		for _, step := range steps {
+			stepId, err := uuid.NewV7()
+			if err != nil {
+				return errors.Join(errors.New("failed to generate UUID"), err)
+			}
+
+			err = q.CreateRecipeStep(qCtx, sqlc.CreateRecipeStepParams{
+				StepID:          stepId,
+				RecipeID:        forkId,
+				Position:        step.Position,
+				Instruction:     step.Instruction,
+				DurationMinutes: step.DurationMinutes,
+				Temperature:     step.Temperature,
+				TemperatureUnit: step.TemperatureUnit,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := q.CopyRecipeTags(qCtx, sqlc.CopyRecipeTagsParams{ToRecipeID: forkId, FromRecipeID: recipeId}); err != nil {
+			return err
+		}
+
+		return q.CreateRecipeRevision(qCtx, forkId)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a recipe with this ID"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("forkRecipe method got uncaught error", zap.Error(err))
+			return uuid.UUID{}, err
+		}
+	}
+
+	return forkId, nil
+}
+
+// ListRecipeForks returns a page of public forks of a recipe, starting from the newest ones,
+// and a cursor to the next page. The returned cursor is empty when there are no more forks.
+func (s *service) ListRecipeForks(ctx context.Context, recipeId uuid.UUID, listForksRequest ListForksRequest) ([]RecipeSummaryResponse, string, error) {
+	var cursor forkCursor
+
+	if listForksRequest.Cursor != "" {
+		if err := shared.DecodeCursor(listForksRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rowLimit := shared.PageLimit(listForksRequest.Limit)
+
+	forks := make([]RecipeSummaryResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListRecipeForks(qCtx, sqlc.ListRecipeForksParams{
+			ForkedFrom:      toPgUUID(recipeId),
+			CursorID:        toPgUUID(cursor.ID),
+			CursorCreatedAt: toPgTimestamp(cursor.CreatedAt),
+			RowLimit:        rowLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			forks = append(forks, newRecipeSummaryResponse(row.RecipeID, row.UserID, row.Title, row.FavoriteCount, row.CreatedAt, row.UpdatedAt))
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listRecipeForks method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return shared.Paginate(forks, listForksRequest.Limit, func(lastFork RecipeSummaryResponse) any {
+		return forkCursor{CreatedAt: lastFork.CreatedAt, ID: lastFork.RecipeID}
+	})
+}
+
+// newForkAttributionResponse returns the original recipe of a fork, or nil for recipes which are not forks.
+func newForkAttributionResponse(recipe sqlc.GetRecipeByIdRow) *ForkAttributionResponse {
+	if !recipe.ForkedFrom.Valid {
+		return nil
+	}
+
+	attribution := &ForkAttributionResponse{
+		RecipeID: recipe.ForkedFrom.Bytes,
+		Title:    recipe.ForkedFromTitle.String,
+	}
+
+	if recipe.ForkedFromUserID.Valid {
+		userId := uuid.UUID(recipe.ForkedFromUserID.Bytes)
+		attribution.UserID = &userId
+	}
+
+	return attribution
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
 	"github.com/danielbukowski/recipe-app-backend/internal/shared"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -15,6 +17,16 @@ import (
 
 var cachedRecipeKeyPrefix = "recipe_"
 
+const defaultServings = 1
+
+// Visibility levels of a recipe. Private recipes are visible only to their owner.
+// Unlisted recipes can be read by anyone who knows their ID, but only public recipes are listed and searched.
+const (
+	visibilityPrivate  = "private"
+	visibilityUnlisted = "unlisted"
+	visibilityPublic   = "public"
+)
+
 type handler struct {
 	logger        *zap.Logger
 	cache         cacheStorage
@@ -24,8 +36,41 @@ type handler struct {
 type recipeService interface {
 	GetRecipeById(context.Context, uuid.UUID) (RecipeResponse, error)
 	DeleteRecipeById(context.Context, uuid.UUID) error
-	CreateNewRecipe(context.Context, NewRecipeRequest) (uuid.UUID, error)
+	CreateNewRecipe(context.Context, uuid.UUID, NewRecipeRequest) (uuid.UUID, error)
 	UpdateRecipeById(context.Context, uuid.UUID, time.Time, UpdateRecipeRequest) error
+	CreateRecipeStep(context.Context, uuid.UUID, time.Time, InsertStepRequest) (uuid.UUID, error)
+	ReorderRecipeSteps(context.Context, uuid.UUID, time.Time, []uuid.UUID) error
+	DeleteRecipeStep(context.Context, uuid.UUID, time.Time, uuid.UUID) error
+	ListRecipes(context.Context, ListRecipesRequest) ([]RecipeSummaryResponse, string, error)
+	SearchRecipes(context.Context, SearchRecipesRequest) ([]RecipeSearchResultResponse, string, error)
+	ListTags(context.Context) ([]TagResponse, error)
+	AddRecipeImage(context.Context, uuid.UUID, io.Reader, int64, string) (ImageResponse, error)
+	SaveRecipeReview(context.Context, uuid.UUID, uuid.UUID, ReviewRequest) (bool, error)
+	ListRecipeReviews(context.Context, uuid.UUID, ListReviewsRequest) ([]ReviewResponse, string, error)
+	AddFavoriteRecipe(context.Context, uuid.UUID, uuid.UUID) error
+	RemoveFavoriteRecipe(context.Context, uuid.UUID, uuid.UUID) error
+	IsFavoriteRecipe(context.Context, uuid.UUID, uuid.UUID) (bool, error)
+	ListFavoriteRecipes(context.Context, uuid.UUID, ListFavoritesRequest) ([]FavoriteRecipeResponse, string, error)
+	CreateComment(context.Context, uuid.UUID, uuid.UUID, CommentRequest) (uuid.UUID, error)
+	GetComment(context.Context, uuid.UUID, uuid.UUID) (CommentResponse, error)
+	UpdateComment(context.Context, uuid.UUID, UpdateCommentRequest) error
+	DeleteComment(context.Context, uuid.UUID) error
+	ListComments(context.Context, uuid.UUID, ListCommentsRequest) ([]CommentResponse, string, error)
+	ListCommentReplies(context.Context, uuid.UUID, uuid.UUID, ListCommentsRequest) ([]CommentResponse, string, error)
+	ListRecipeRevisions(context.Context, uuid.UUID, ListRevisionsRequest) ([]RevisionSummaryResponse, string, error)
+	GetRecipeRevision(context.Context, uuid.UUID, int32) (RevisionResponse, error)
+	DiffRecipeRevisions(context.Context, uuid.UUID, int32, int32) (RevisionDiffResponse, error)
+	RevertRecipeRevision(context.Context, uuid.UUID, time.Time, UpdateRecipeRequest, []StepRequest) error
+	ListDeletedRecipes(context.Context, uuid.UUID, ListTrashRequest) ([]TrashedRecipeResponse, string, error)
+	RestoreRecipe(context.Context, uuid.UUID, uuid.UUID) error
+	PublishRecipe(context.Context, uuid.UUID, time.Time) (string, error)
+	ListOwnRecipes(context.Context, uuid.UUID, ListOwnRecipesRequest) ([]OwnRecipeResponse, string, error)
+	CreateShareLink(context.Context, uuid.UUID, time.Time) (CreatedShareLinkResponse, error)
+	ListShareLinks(context.Context, uuid.UUID) ([]ShareLinkResponse, error)
+	RevokeShareLink(context.Context, uuid.UUID, uuid.UUID) error
+	UseShareLink(context.Context, string) (uuid.UUID, error)
+	ForkRecipe(context.Context, uuid.UUID, uuid.UUID) (uuid.UUID, error)
+	ListRecipeForks(context.Context, uuid.UUID, ListForksRequest) ([]RecipeSummaryResponse, string, error)
 }
 
 type cacheStorage interface {
@@ -45,7 +90,9 @@ func NewHandler(logger *zap.Logger, cacheStorage cacheStorage, recipeService rec
 // CreateRecipe godoc
 //
 //	@Summary		Create a new recipe
-//	@Description	Insert a new recipe by providing a request body with title and content for the recipe you want to save.
+//	@Description	Insert a new recipe by providing a request body with title, content and an ordered list of ingredients for the recipe you want to save.
+//	@Description	A new recipe is saved as a draft, visible only to its owner until it is published.
+//	@Description	A private recipe stays visible only to its owner, an unlisted one can be read by anyone with its ID, and only a public one is listed and searched.
 //	@Tags			recipes
 //
 //	@Accept			json
@@ -54,6 +101,7 @@ func NewHandler(logger *zap.Logger, cacheStorage cacheStorage, recipeService rec
 //
 //	@Success		201					{object}	shared.CommonResponse				"Recipe saved successfully."
 //	@Failure		400					{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401					{object}	shared.CommonResponse				"User is not signed in."
 //	@Failure		404					{object}	shared.CommonResponse				"Recipe not found."
 //
 //	@Router			/api/v1/recipes [POST]
@@ -72,7 +120,15 @@ func (h *handler) CreateRecipe(c echo.Context) error {
 		return err
 	}
 
-	recipeId, err := h.recipeService.CreateNewRecipe(c.Request().Context(), requestBody)
+	if requestBody.Servings == 0 {
+		requestBody.Servings = defaultServings
+	}
+
+	if requestBody.Visibility == "" {
+		requestBody.Visibility = visibilityPublic
+	}
+
+	recipeId, err := h.recipeService.CreateNewRecipe(c.Request().Context(), session.FromContext(c).UserID, requestBody)
 	if err != nil {
 		return err
 	}
@@ -86,7 +142,7 @@ func (h *handler) CreateRecipe(c echo.Context) error {
 // UpdateRecipeByID godoc
 //
 //	@Summary		Update a recipe
-//	@Description	Update title or content of a recipe by UUID.
+//	@Description	Update title, content, servings, ingredients, tags or visibility of a recipe by UUID. Omitted fields keep their current values.
 //	@Tags			recipes
 //
 //	@Accept			json
@@ -97,6 +153,8 @@ func (h *handler) CreateRecipe(c echo.Context) error {
 //
 //	@Success		204					"Recipe  	updated successfully."
 //	@Failure		400					{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401					{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403					{object}	shared.CommonResponse				"User is not the owner of the recipe."
 //	@Failure		409					{object}	shared.CommonResponse				"Database conflict occurred when trying to saving a recipe."
 //
 //	@Router			/api/v1/recipes/{id} [PUT]
@@ -105,15 +163,9 @@ func (h *handler) UpdateRecipeById(c echo.Context) error {
 		return err
 	}
 
-	recipeIdParam := c.Param("id")
-
-	if recipeIdParam == "" {
-		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing ID param for recipe"})
-	}
-
-	recipeId, err := uuid.Parse(recipeIdParam)
+	recipeId, err := parseRecipeId(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received ID is not a valid UUID"})
+		return err
 	}
 
 	var requestBody = UpdateRecipeRequest{}
@@ -122,9 +174,9 @@ func (h *handler) UpdateRecipeById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
 	}
 
-	recipeFromDb, err := h.recipeService.GetRecipeById(c.Request().Context(), recipeId)
+	recipeFromDb, err := h.getOwnedRecipe(c, recipeId)
 	if err != nil {
-		return nil
+		return err
 	}
 
 	if requestBody.Title == "" {
@@ -135,6 +187,31 @@ func (h *handler) UpdateRecipeById(c echo.Context) error {
 		requestBody.Content = recipeFromDb.Content
 	}
 
+	if requestBody.Servings == 0 {
+		requestBody.Servings = recipeFromDb.Servings
+	}
+
+	if requestBody.Ingredients == nil {
+		requestBody.Ingredients = make([]IngredientRequest, 0, len(recipeFromDb.Ingredients))
+
+		for _, ingredient := range recipeFromDb.Ingredients {
+			requestBody.Ingredients = append(requestBody.Ingredients, IngredientRequest{
+				Quantity: ingredient.Quantity,
+				Unit:     ingredient.Unit,
+				Name:     ingredient.Name,
+				Note:     ingredient.Note,
+			})
+		}
+	}
+
+	if requestBody.Tags == nil {
+		requestBody.Tags = recipeFromDb.Tags
+	}
+
+	if requestBody.Visibility == "" {
+		requestBody.Visibility = recipeFromDb.Visibility
+	}
+
 	if err := c.Validate(requestBody); err != nil {
 		return err
 	}
@@ -144,9 +221,7 @@ func (h *handler) UpdateRecipeById(c echo.Context) error {
 		return err
 	}
 
-	if err = h.cache.DeleteItem(cachedRecipeKeyPrefix + recipeId.String()); err != nil {
-		h.logger.Error("failed to delete a recipe from the cache", zap.String("recipe_id", recipeId.String()), zap.Error(err))
-	}
+	h.deleteCachedRecipe(recipeId)
 
 	h.logger.Info("successfully updated a recipe", zap.String("recipeId", recipeId.String()))
 
@@ -156,7 +231,7 @@ func (h *handler) UpdateRecipeById(c echo.Context) error {
 // DeleteRecipeByID godoc
 //
 //	@Summary		Delete a recipe
-//	@Description	Delete a recipe by ID.
+//	@Description	Move a recipe to the trash of its owner. A deleted recipe is hidden from everyone and can be restored until it is purged after the retention period.
 //	@Tags			recipes
 //
 //	@Produce		json
@@ -164,29 +239,28 @@ func (h *handler) UpdateRecipeById(c echo.Context) error {
 //
 //	@Success		204	"Recipe deleted successfully."
 //	@Failure		400	{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401	{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403	{object}	shared.CommonResponse				"User is not the owner of the recipe."
+//	@Failure		404	{object}	shared.CommonResponse				"Recipe not found."
 //
 //	@Router			/api/v1/recipes/{id} [DELETE]
 func (h *handler) DeleteRecipeById(c echo.Context) error {
-	recipeIdParam := c.Param("id")
-
-	if recipeIdParam == "" {
-		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing ID param for recipe"})
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
 	}
 
-	recipeId, err := uuid.Parse(recipeIdParam)
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "the received ID is not a valid UUID"})
+	if _, err := h.getOwnedRecipe(c, recipeId); err != nil {
+		return err
 	}
 
 	if err := h.recipeService.DeleteRecipeById(c.Request().Context(), recipeId); err != nil {
 		return err
 	}
 
-	if err = h.cache.DeleteItem(cachedRecipeKeyPrefix + recipeId.String()); err != nil {
-		h.logger.Error("failed to delete a recipe from the cache", zap.String("recipe_id", recipeId.String()), zap.Error(err))
-	}
+	h.deleteCachedRecipe(recipeId)
 
-	h.logger.Info("successfully deleted a recipe from database", zap.String("recipeId", recipeId.String()))
+	h.logger.Info("successfully moved a recipe to the trash", zap.String("recipeId", recipeId.String()))
 
 	return c.NoContent(http.StatusNoContent)
 }
@@ -194,42 +268,240 @@ func (h *handler) DeleteRecipeById(c echo.Context) error {
 // GetRecipeByID godoc
 //
 //	@Summary		Get a recipe
-//	@Description	Get a recipe by ID.
+//	@Description	Get a recipe by ID. Pass servings to get the quantities of ingredients scaled to a different number of servings.
+//	@Description	Quantities and oven temperatures are converted to the measurement system preferred by the signed-in user.
+//	@Description	The is_favorite flag tells whether the signed-in user has saved the recipe to their favorites.
+//	@Description	Drafts, scheduled and private recipes are visible only to their owner.
 //	@Tags			recipes
 //
 //	@Produce		json
 //
-//	@Param			id	path		string										true	"UUID for a recipe"
+//	@Param			id			path		string										true	"UUID for a recipe"
+//	@Param			servings	query		int											false	"Number of servings to scale the recipe to."	minimum(1)	maximum(1000)
 //
-//	@Success		200	{object}	shared.DataResponse[recipe.RecipeResponse]	"Recipe fetched successfully."
-//	@Failure		400	{object}	validator.ValidationErrorResponse			"Invalid data provided."
-//	@Failure		404	{object}	shared.CommonResponse						"Recipe is not found."
+//	@Success		200			{object}	shared.DataResponse[recipe.RecipeResponse]	"Recipe fetched successfully."
+//	@Failure		400			{object}	validator.ValidationErrorResponse			"Invalid data provided."
+//	@Failure		404			{object}	shared.CommonResponse						"Recipe is not found."
 //
 //	@Router			/api/v1/recipes/{id} [GET]
 func (h *handler) GetRecipeById(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestQuery = GetRecipeRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	recipe, err := h.getReadableRecipe(c, recipeId)
+	if err != nil {
+		return err
+	}
+
+	if requestQuery.Servings != nil && *requestQuery.Servings != recipe.Servings {
+		recipe = scaleRecipe(recipe, *requestQuery.Servings)
+	}
+
+	// The cached recipe stays as it was written, so it is converted for every reader separately.
+	if system, ok := measurementSystems[session.FromContext(c).MeasurementSystem]; ok {
+		recipe = convertRecipe(recipe, system)
+	}
+
+	// The cached recipe is shared by all users, so the favorite flag is checked for every reader separately.
+	if userSession := session.FromContext(c); userSession.IsAuthenticated() {
+		isFavorite, err := h.recipeService.IsFavoriteRecipe(c.Request().Context(), userSession.UserID, recipeId)
+		if err != nil {
+			return err
+		}
+
+		recipe.IsFavorite = isFavorite
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[RecipeResponse]{Data: recipe})
+}
+
+// ListRecipes godoc
+//
+//	@Summary		List recipes
+//	@Description	Get a page of public recipes. Use the next_cursor value from the response to fetch the next page.
+//	@Tags			recipes
+//
+//	@Produce		json
+//
+//	@Param			cursor			query		string											false	"Cursor to the next page."
+//	@Param			limit			query		int												false	"Maximum number of recipes on a page."	minimum(1)	maximum(100)	default(20)
+//	@Param			sort			query		string											false	"Sort order of recipes."				Enums(created_at, updated_at, title, favorite_count)	default(created_at)
+//	@Param			author_id		query		string											false	"UUID of a recipe author."
+//	@Param			created_after	query		string											false	"Only recipes created at or after this RFC 3339 time."
+//	@Param			created_before	query		string											false	"Only recipes created before this RFC 3339 time."
+//	@Param			tag				query		[]string										false	"Only recipes with these tags."	collectionFormat(multi)
+//	@Param			tag_match		query		string											false	"Whether recipes need any or all of the tags."	Enums(any, all)	default(any)
+//
+//	@Success		200				{object}	shared.PagedDataResponse[recipe.RecipeSummaryResponse]	"Recipes fetched successfully."
+//	@Failure		400				{object}	validator.ValidationErrorResponse						"Invalid data provided."
+//
+//	@Router			/api/v1/recipes [GET]
+func (h *handler) ListRecipes(c echo.Context) error {
+	var requestQuery = ListRecipesRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	if requestQuery.Sort == "" {
+		requestQuery.Sort = sortByCreatedAt
+	}
+
+	if requestQuery.Limit == 0 {
+		requestQuery.Limit = defaultListLimit
+	}
+
+	if requestQuery.TagMatch == "" {
+		requestQuery.TagMatch = tagMatchAny
+	}
+
+	recipes, nextCursor, err := h.recipeService.ListRecipes(c.Request().Context(), requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[RecipeSummaryResponse]{
+		Data: recipes,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
+
+// SearchRecipes godoc
+//
+//	@Summary		Search recipes
+//	@Description	Full-text search over titles and contents of public recipes, ordered by relevance. The text of recipes is HTML-escaped and matched words are wrapped in <mark> tags.
+//	@Tags			recipes
+//
+//	@Produce		json
+//
+//	@Param			q		query		string														true	"Search query. Supports quoted phrases, OR and -exclusions."
+//	@Param			cursor	query		string														false	"Cursor to the next page."
+//	@Param			limit	query		int															false	"Maximum number of recipes on a page."	minimum(1)	maximum(100)	default(20)
+//
+//	@Success		200		{object}	shared.PagedDataResponse[recipe.RecipeSearchResultResponse]	"Recipes found successfully."
+//	@Failure		400		{object}	validator.ValidationErrorResponse							"Invalid data provided."
+//
+//	@Router			/api/v1/recipes/search [GET]
+func (h *handler) SearchRecipes(c echo.Context) error {
+	var requestQuery = SearchRecipesRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	if requestQuery.Limit == 0 {
+		requestQuery.Limit = defaultListLimit
+	}
+
+	results, nextCursor, err := h.recipeService.SearchRecipes(c.Request().Context(), requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[RecipeSearchResultResponse]{
+		Data: results,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
+
+// parseRecipeId reads the UUID of a recipe from the ID path param.
+func parseRecipeId(c echo.Context) (uuid.UUID, error) {
 	recipeIdParam := c.Param("id")
 
 	if recipeIdParam == "" {
-		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing ID param for recipe"})
+		return uuid.UUID{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "missing ID param for recipe"})
 	}
 
 	recipeId, err := uuid.Parse(recipeIdParam)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "the received ID is not a valid UUID"})
+		return uuid.UUID{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received ID is not a valid UUID"})
+	}
+
+	return recipeId, nil
+}
+
+// getOwnedRecipe fetches a recipe from the database and checks if it belongs to the signed-in user.
+func (h *handler) getOwnedRecipe(c echo.Context, recipeId uuid.UUID) (RecipeResponse, error) {
+	recipe, err := h.recipeService.GetRecipeById(c.Request().Context(), recipeId)
+	if err != nil {
+		return RecipeResponse{}, err
+	}
+
+	if recipe.UserID != session.FromContext(c).UserID {
+		return RecipeResponse{}, echo.NewHTTPError(http.StatusForbidden, shared.CommonResponse{Message: "only the owner of the recipe can modify it"})
+	}
+
+	return recipe, nil
+}
+
+// getReadableRecipe returns a recipe the signed-in user is allowed to read.
+// Every endpoint which shows recipes to users has to fetch them with it.
+// Private recipes and recipes which are not published yet are visible only to their owner.
+func (h *handler) getReadableRecipe(c echo.Context, recipeId uuid.UUID) (RecipeResponse, error) {
+	recipe, err := h.getRecipe(c.Request().Context(), recipeId)
+	if err != nil {
+		return RecipeResponse{}, err
 	}
 
+	// Do not reveal that a recipe hidden from the user exists.
+	if !isSharedRecipe(recipe) && recipe.UserID != session.FromContext(c).UserID {
+		return RecipeResponse{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a recipe with this ID"})
+	}
+
+	return recipe, nil
+}
+
+// isSharedRecipe tells whether a recipe can be read by users other than its owner.
+func isSharedRecipe(recipe RecipeResponse) bool {
+	return recipe.Status == statusPublished && recipe.Visibility != visibilityPrivate
+}
+
+// getRecipe reads a recipe from the cache and falls back to the database on a cache miss.
+// The cache is shared by all users, so only recipes which everyone can read are saved to it.
+// Drafts and private recipes are always read from the database.
+func (h *handler) getRecipe(ctx context.Context, recipeId uuid.UUID) (RecipeResponse, error) {
 	// Check for a recipe in the cache.
 	if cachedRecipe, err := h.cache.GetItem(cachedRecipeKeyPrefix + recipeId.String()); err == nil {
 		var recipe RecipeResponse
 
-		if err := json.Unmarshal(cachedRecipe, &recipe); err == nil {
-			return c.JSON(http.StatusOK, shared.DataResponse[RecipeResponse]{Data: recipe})
+		if err := json.Unmarshal(cachedRecipe, &recipe); err == nil && isSharedRecipe(recipe) {
+			return recipe, nil
 		}
 	}
 
-	recipe, err := h.recipeService.GetRecipeById(c.Request().Context(), recipeId)
+	recipe, err := h.recipeService.GetRecipeById(ctx, recipeId)
 	if err != nil {
-		return err
+		return RecipeResponse{}, err
+	}
+
+	if !isSharedRecipe(recipe) {
+		return recipe, nil
 	}
 
 	// Save the recipe to the cache.
@@ -237,12 +509,19 @@ func (h *handler) GetRecipeById(c echo.Context) error {
 	if err != nil {
 		h.logger.Error("failed to encoded a recipe to the cache", zap.Error(err))
 
-		return c.JSON(http.StatusOK, shared.DataResponse[RecipeResponse]{Data: recipe})
+		return recipe, nil
 	}
 
 	if err = h.cache.InsertItem(cachedRecipeKeyPrefix+recipeId.String(), encodedRecipe, 60*15); err != nil {
 		h.logger.Error("failed to insert a recipe to the cache", zap.Error(err))
 	}
 
-	return c.JSON(http.StatusOK, shared.DataResponse[RecipeResponse]{Data: recipe})
+	return recipe, nil
+}
+
+// deleteCachedRecipe removes a recipe from the cache, so the next read fetches it from the database.
+func (h *handler) deleteCachedRecipe(recipeId uuid.UUID) {
+	if err := h.cache.DeleteItem(cachedRecipeKeyPrefix + recipeId.String()); err != nil {
+		h.logger.Error("failed to delete a recipe from the cache", zap.String("recipe_id", recipeId.String()), zap.Error(err))
+	}
 }
@@ -1,13 +1,22 @@
 package recipe_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	mock_recipe "github.com/danielbukowski/recipe-app-backend/gen/_mocks/recipe"
 	"github.com/danielbukowski/recipe-app-backend/internal/recipe"
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
 	"github.com/danielbukowski/recipe-app-backend/internal/validator"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -15,29 +24,241 @@ import (
 )
 
 func TestCreateRecipeHandler(t *testing.T) {
+	signedInSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com"}
+
 	testCases := []struct {
 		name           string
+		session        *session.Session
 		requestBody    string
 		wantStatusCode int
 	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			requestBody:    "{}",
+			wantStatusCode: http.StatusUnauthorized,
+		},
 		{
 			name:           "no request body",
+			session:        signedInSession,
 			requestBody:    "",
 			wantStatusCode: http.StatusBadRequest,
 		},
 		{
 			name:           "request body is empty json",
+			session:        signedInSession,
 			requestBody:    "{}",
 			wantStatusCode: http.StatusBadRequest,
 		},
 		{
-			name: "content length of title and content in request body are too short",
+			name:    "content length of title and content in request body are too short",
+			session: signedInSession,
 			requestBody: `{
 							"title": "cake",
 							"content": "cook it"
 						}`,
 			wantStatusCode: http.StatusBadRequest,
 		},
+		{
+			name:    "ingredient in request body has no name",
+			session: signedInSession,
+			requestBody: `{
+							"title": "Chocolate Cookies",
+							"content": "Having all your ingredients the same temperature really helps here",
+							"ingredients": [{"quantity": 200, "unit": "g"}]
+						}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes", strings.NewReader(tc.requestBody))
+			req.Header.Add("content-type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestDeleteRecipeHandler(t *testing.T) {
+	ownerId := uuid.Must(uuid.NewV7())
+	recipeId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "user is not the owner of the recipe",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			wantStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/recipes/"+recipeId.String(), nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			recipeService.EXPECT().
+				GetRecipeById(gomock.Any(), recipeId).
+				Return(recipe.RecipeResponse{UserID: ownerId}, nil).
+				AnyTimes()
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestListRecipesHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		wantStatusCode int
+	}{
+		{
+			name:           "unknown sort order",
+			query:          "?sort=rating",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "limit is too big",
+			query:          "?limit=1000",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "author ID is not a valid UUID",
+			query:          "?author_id=123",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown tag match",
+			query:          "?tag=vegan&tag_match=some",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "empty tag",
+			query:          "?tag=",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "recipes with all tags",
+			query:          "?tag=vegan&tag=Gluten%20Free&tag_match=all",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "valid query parameters",
+			query:          "?sort=title&limit=10&created_after=2025-01-01T00:00:00Z",
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/recipes"+tc.query, nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			recipeService.EXPECT().
+				ListRecipes(gomock.Any(), gomock.Any()).
+				Return([]recipe.RecipeSummaryResponse{}, "", nil).
+				AnyTimes()
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestCreateRecipeStepHandler(t *testing.T) {
+	signedInSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com"}
+	recipeId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		wantStatusCode int
+	}{
+		{
+			name:           "temperature without a unit",
+			requestBody:    `{"instruction": "Bake the cookies.", "temperature": 180}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "unit without a temperature",
+			requestBody:    `{"instruction": "Bake the cookies.", "temperature_unit": "C"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "position is not positive",
+			requestBody:    `{"instruction": "Bake the cookies.", "position": 0}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -47,9 +268,10 @@ func TestCreateRecipeHandler(t *testing.T) {
 			// given
 			e := echo.New()
 			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(signedInSession))
 			server := &http.Server{Handler: e}
 
-			req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes", nil)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes/"+recipeId.String()+"/steps", strings.NewReader(tc.requestBody))
 			req.Header.Add("content-type", "application/json")
 
 			rec := httptest.NewRecorder()
@@ -69,3 +291,1285 @@ func TestCreateRecipeHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRecipeHandler(t *testing.T) {
+	recipeId := uuid.Must(uuid.NewV7())
+	quantity := 24.0
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		baseServings   int32
+		query          string
+		wantStatusCode int
+		wantQuantity   string
+		wantUnit       string
+	}{
+		{
+			name:           "servings are not positive",
+			baseServings:   2,
+			query:          "?servings=0",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "servings are not a number",
+			baseServings:   2,
+			query:          "?servings=two",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "recipe without servings",
+			baseServings:   2,
+			query:          "",
+			wantStatusCode: http.StatusOK,
+			wantQuantity:   "24",
+			wantUnit:       "tsp",
+		},
+		{
+			name:           "recipe scaled to a normalized unit",
+			baseServings:   2,
+			query:          "?servings=4",
+			wantStatusCode: http.StatusOK,
+			wantQuantity:   "1",
+			wantUnit:       "cup",
+		},
+		{
+			name:           "recipe converted to the preferred measurement system",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7()), MeasurementSystem: "metric"},
+			baseServings:   2,
+			query:          "",
+			wantStatusCode: http.StatusOK,
+			wantQuantity:   "118",
+			wantUnit:       "ml",
+		},
+		{
+			name:           "recipe scaled to a fraction",
+			baseServings:   2,
+			query:          "?servings=3",
+			wantStatusCode: http.StatusOK,
+			wantQuantity:   "3/4",
+			wantUnit:       "cup",
+		},
+		{
+			name:           "recipe scaled down to a quarter",
+			baseServings:   2,
+			query:          "?servings=1",
+			wantStatusCode: http.StatusOK,
+			wantQuantity:   "1/4",
+			wantUnit:       "cup",
+		},
+		{
+			name:           "recipe cached before it had base servings is not scaled",
+			baseServings:   0,
+			query:          "?servings=4",
+			wantStatusCode: http.StatusOK,
+			wantQuantity:   "24",
+			wantUnit:       "tsp",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			cachedRecipe, _ := json.Marshal(recipe.RecipeResponse{
+				Title:       "Chocolate Cookies",
+				Servings:    tc.baseServings,
+				Ingredients: []recipe.IngredientResponse{{Quantity: &quantity, QuantityText: "24", Unit: "tsp", Name: "sugar"}},
+				Status:      "published",
+			})
+
+			e := echo.New()
+			e.Validator = validator.New()
+			if tc.session != nil {
+				e.Use(session.WithSessionForTest(tc.session))
+			}
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeId.String()+tc.query, nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			cacheStorage.EXPECT().
+				GetItem(gomock.Any()).
+				Return(cachedRecipe, nil).
+				AnyTimes()
+
+			recipeService.EXPECT().
+				IsFavoriteRecipe(gomock.Any(), gomock.Any(), recipeId).
+				Return(true, nil).
+				AnyTimes()
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+
+			if tc.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var responseBody shared.DataResponse[recipe.RecipeResponse]
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseBody))
+			assert.Equal(t, tc.wantQuantity, responseBody.Data.Ingredients[0].QuantityText)
+			assert.Equal(t, tc.wantUnit, responseBody.Data.Ingredients[0].Unit)
+			// Only signed-in users have favorite recipes.
+			assert.Equal(t, tc.session != nil, responseBody.Data.IsFavorite)
+		})
+	}
+}
+
+func TestUploadRecipeImageHandler(t *testing.T) {
+	signedInSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com"}
+	recipeId := uuid.Must(uuid.NewV7())
+	pngHeader := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+	testCases := []struct {
+		name            string
+		contentType     string
+		fileContent     string
+		wantContentType string
+		wantStatusCode  int
+	}{
+		{
+			name:           "request body is json",
+			contentType:    "application/json",
+			wantStatusCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "file is not an image",
+			fileContent:    "<html><body>not an image</body></html>",
+			wantStatusCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "image is too large",
+			fileContent:    pngHeader + strings.Repeat("\x00", 6<<20),
+			wantStatusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:            "png image",
+			fileContent:     pngHeader,
+			wantContentType: "image/png",
+			wantStatusCode:  http.StatusCreated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(signedInSession))
+			server := &http.Server{Handler: e}
+
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			part, _ := form.CreateFormFile("image", "cookies.png")
+			_, _ = part.Write([]byte(tc.fileContent))
+			_ = form.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes/"+recipeId.String()+"/images", &body)
+			req.Header.Add("content-type", form.FormDataContentType())
+			if tc.contentType != "" {
+				req.Header.Set("content-type", tc.contentType)
+			}
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			recipeService.EXPECT().
+				GetRecipeById(gomock.Any(), recipeId).
+				Return(recipe.RecipeResponse{UserID: signedInSession.UserID}, nil).
+				AnyTimes()
+
+			if tc.wantContentType != "" {
+				recipeService.EXPECT().
+					AddRecipeImage(gomock.Any(), recipeId, gomock.Any(), int64(len(tc.fileContent)), tc.wantContentType).
+					Return(recipe.ImageResponse{ImageID: uuid.Must(uuid.NewV7())}, nil)
+
+				cacheStorage.EXPECT().
+					DeleteItem(gomock.Any()).
+					Return(nil)
+			}
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestCompareRecipesHandler(t *testing.T) {
+	recipeIdA := uuid.Must(uuid.NewV7())
+	recipeIdB := uuid.Must(uuid.NewV7())
+
+	cachedRecipeA, _ := json.Marshal(recipe.RecipeResponse{
+		Title:   "Chocolate Cookies",
+		Content: "- 200 g dark chocolate\n- 2 eggs\n\nMelt the chocolate and mix it with eggs.",
+		Status:  "published",
+	})
+	cachedRecipeB, _ := json.Marshal(recipe.RecipeResponse{
+		Title:   "Milk Chocolate Cookies",
+		Content: "- 150 g dark chocolate (chopped)\n- 1 egg\n- 100 g butter\n\nMelt the chocolate and mix it with egg.",
+		Status:  "published",
+	})
+
+	testCases := []struct {
+		name            string
+		query           string
+		wantStatusCode  int
+		wantIngredients []recipe.IngredientComparisonResponse
+	}{
+		{
+			name:           "missing second recipe",
+			query:          "?a=" + recipeIdA.String(),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "recipe ID is not a valid UUID",
+			query:          "?a=" + recipeIdA.String() + "&b=123",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "two recipes",
+			query:          "?a=" + recipeIdA.String() + "&b=" + recipeIdB.String(),
+			wantStatusCode: http.StatusOK,
+			wantIngredients: []recipe.IngredientComparisonResponse{
+				{Name: "dark chocolate", QuantityA: ptr("200 g"), QuantityB: ptr("150 g")},
+				{Name: "egg", QuantityA: ptr("2"), QuantityB: ptr("1")},
+				{Name: "butter", QuantityB: ptr("100 g")},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/recipes/compare"+tc.query, nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			cacheStorage.EXPECT().
+				GetItem("recipe_"+recipeIdA.String()).
+				Return(cachedRecipeA, nil).
+				AnyTimes()
+
+			cacheStorage.EXPECT().
+				GetItem("recipe_"+recipeIdB.String()).
+				Return(cachedRecipeB, nil).
+				AnyTimes()
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+
+			if tc.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var responseBody shared.DataResponse[recipe.RecipeComparisonResponse]
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseBody))
+			assert.Equal(t, tc.wantIngredients, responseBody.Data.Ingredients)
+			assert.Equal(t, recipeIdB, responseBody.Data.B.RecipeID)
+			assert.Equal(t, 0.66, responseBody.Data.Similarity)
+		})
+	}
+}
+
+func TestSaveRecipeReviewHandler(t *testing.T) {
+	ownerId := uuid.Must(uuid.NewV7())
+	reviewerSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com"}
+	recipeId := uuid.Must(uuid.NewV7())
+
+	cachedRecipe, _ := json.Marshal(recipe.RecipeResponse{UserID: ownerId, Title: "Chocolate Cookies", Status: "published"})
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		requestBody    string
+		existingReview bool
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			requestBody:    `{"rating": 5}`,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "rating is out of range",
+			session:        reviewerSession,
+			requestBody:    `{"rating": 6}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "user has not verified the email",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7()), UnverifiedEmail: true},
+			requestBody:    `{"rating": 4, "body": "Visit my-spam-site.com for more!"}`,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "owner reviews their own recipe",
+			session:        &session.Session{UserID: ownerId},
+			requestBody:    `{"rating": 5}`,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "first review of a user",
+			session:        reviewerSession,
+			requestBody:    `{"rating": 4, "body": "Crispy edges and a soft middle."}`,
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "user replaces their review",
+			session:        reviewerSession,
+			requestBody:    `{"rating": 2}`,
+			existingReview: true,
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes/"+recipeId.String()+"/reviews", strings.NewReader(tc.requestBody))
+			req.Header.Add("content-type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			cacheStorage.EXPECT().
+				GetItem("recipe_"+recipeId.String()).
+				Return(cachedRecipe, nil).
+				AnyTimes()
+
+			if tc.wantStatusCode == http.StatusOK || tc.wantStatusCode == http.StatusCreated {
+				recipeService.EXPECT().
+					SaveRecipeReview(gomock.Any(), recipeId, tc.session.UserID, gomock.Any()).
+					Return(!tc.existingReview, nil)
+
+				// The rating aggregate of the cached recipe is stale after a review.
+				cacheStorage.EXPECT().
+					DeleteItem("recipe_" + recipeId.String()).
+					Return(nil)
+			}
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestAddFavoriteRecipeHandler(t *testing.T) {
+	signedInSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com"}
+	recipeId := uuid.Must(uuid.NewV7())
+
+	cachedRecipe, _ := json.Marshal(recipe.RecipeResponse{Title: "Chocolate Cookies", Status: "published"})
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		recipeId       string
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			recipeId:       recipeId.String(),
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "recipe ID is not a valid UUID",
+			session:        signedInSession,
+			recipeId:       "123",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "recipe added to favorites",
+			session:        signedInSession,
+			recipeId:       recipeId.String(),
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/me/favorites/"+tc.recipeId, nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			cacheStorage.EXPECT().
+				GetItem("recipe_"+recipeId.String()).
+				Return(cachedRecipe, nil).
+				AnyTimes()
+
+			if tc.wantStatusCode == http.StatusNoContent {
+				recipeService.EXPECT().
+					AddFavoriteRecipe(gomock.Any(), signedInSession.UserID, recipeId).
+					Return(nil)
+
+				// The favorite count of the cached recipe is stale after adding a favorite.
+				cacheStorage.EXPECT().
+					DeleteItem("recipe_" + recipeId.String()).
+					Return(nil)
+			}
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestUpdateCommentHandler(t *testing.T) {
+	authorId := uuid.Must(uuid.NewV7())
+	recipeId := uuid.Must(uuid.NewV7())
+	commentId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		requestBody    string
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			requestBody:    `{"body": "Updated comment"}`,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "comment is empty",
+			session:        &session.Session{UserID: authorId},
+			requestBody:    `{"body": ""}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "author has not verified the email",
+			session:        &session.Session{UserID: authorId, UnverifiedEmail: true},
+			requestBody:    `{"body": "Updated comment"}`,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "user is not the author of the comment",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			requestBody:    `{"body": "Updated comment"}`,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "author updates the comment",
+			session:        &session.Session{UserID: authorId},
+			requestBody:    `{"body": "Updated comment"}`,
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/recipes/"+recipeId.String()+"/comments/"+commentId.String(), strings.NewReader(tc.requestBody))
+			req.Header.Add("content-type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			recipeService.EXPECT().
+				GetComment(gomock.Any(), recipeId, commentId).
+				Return(recipe.CommentResponse{CommentID: commentId, UserID: &authorId}, nil).
+				AnyTimes()
+
+			if tc.wantStatusCode == http.StatusNoContent {
+				recipeService.EXPECT().
+					UpdateComment(gomock.Any(), commentId, recipe.UpdateCommentRequest{Body: "Updated comment"}).
+					Return(nil)
+			}
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestRevertRecipeRevisionHandler(t *testing.T) {
+	ownerId := uuid.Must(uuid.NewV7())
+	recipeId := uuid.Must(uuid.NewV7())
+	updatedAt := time.Date(2025, time.May, 5, 12, 0, 0, 0, time.UTC)
+
+	revision := recipe.RevisionResponse{
+		RevisionNumber: 2,
+		Title:          "Chocolate Cookies",
+		Content:        "Melt the chocolate.",
+		Servings:       4,
+		Ingredients:    []recipe.IngredientResponse{{Quantity: ptr(200.0), QuantityText: "200", Unit: "g", Name: "dark chocolate"}},
+		Steps:          []recipe.RevisionStepResponse{{Position: 1, Instruction: "Bake the cookies.", Temperature: ptr(int32(180)), TemperatureUnit: ptr("C")}},
+		Tags:           []string{"dessert"},
+		Visibility:     "public",
+	}
+
+	// Revisions saved before revisions kept steps have no steps.
+	revisionWithoutSteps := revision
+	revisionWithoutSteps.Steps = nil
+	revisionWithoutSteps.Visibility = ""
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		revisionNumber string
+		revision       recipe.RevisionResponse
+		revisionErr    error
+		wantSteps      []recipe.StepRequest
+		wantStatusCode int
+	}{
+		{
+			name:           "revision number is not a positive integer",
+			session:        &session.Session{UserID: ownerId},
+			revisionNumber: "0",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "user is not the owner of the recipe",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			revisionNumber: "2",
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "revision does not exist",
+			session:        &session.Session{UserID: ownerId},
+			revisionNumber: "2",
+			revisionErr:    echo.NewHTTPError(http.StatusNotFound),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "owner reverts the recipe",
+			session:        &session.Session{UserID: ownerId},
+			revisionNumber: "2",
+			revision:       revision,
+			wantSteps:      []recipe.StepRequest{{Instruction: "Bake the cookies.", Temperature: ptr(int32(180)), TemperatureUnit: "C"}},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "owner reverts the recipe to a revision without steps",
+			session:        &session.Session{UserID: ownerId},
+			revisionNumber: "2",
+			revision:       revisionWithoutSteps,
+			wantSteps:      nil,
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes/"+recipeId.String()+"/revisions/"+tc.revisionNumber+"/revert", nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			recipeService.EXPECT().
+				GetRecipeById(gomock.Any(), recipeId).
+				Return(recipe.RecipeResponse{UserID: ownerId, UpdatedAt: updatedAt, Visibility: "private"}, nil).
+				AnyTimes()
+
+			recipeService.EXPECT().
+				GetRecipeRevision(gomock.Any(), recipeId, int32(2)).
+				Return(tc.revision, tc.revisionErr).
+				AnyTimes()
+
+			if tc.wantStatusCode == http.StatusNoContent {
+				// The recipe stays private, even though it was public in the revision.
+				recipeService.EXPECT().
+					RevertRecipeRevision(gomock.Any(), recipeId, updatedAt, recipe.UpdateRecipeRequest{
+						Title:       "Chocolate Cookies",
+						Content:     "Melt the chocolate.",
+						Servings:    4,
+						Ingredients: []recipe.IngredientRequest{{Quantity: ptr(200.0), Unit: "g", Name: "dark chocolate"}},
+						Tags:        []string{"dessert"},
+						Visibility:  "private",
+					}, tc.wantSteps).
+					Return(nil)
+
+				cacheStorage.EXPECT().
+					DeleteItem("recipe_" + recipeId.String()).
+					Return(nil)
+			}
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestRestoreRecipeHandler(t *testing.T) {
+	ownerId := uuid.Must(uuid.NewV7())
+	recipeId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		restoreErr     error
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "recipe is not in the trash of the user",
+			session:        &session.Session{UserID: ownerId},
+			restoreErr:     echo.NewHTTPError(http.StatusNotFound),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "owner restores the recipe",
+			session:        &session.Session{UserID: ownerId},
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes/"+recipeId.String()+"/restore", nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			if tc.session.UserID != uuid.Nil {
+				recipeService.EXPECT().
+					RestoreRecipe(gomock.Any(), recipeId, ownerId).
+					Return(tc.restoreErr)
+			}
+
+			if tc.wantStatusCode == http.StatusNoContent {
+				cacheStorage.EXPECT().
+					DeleteItem("recipe_" + recipeId.String()).
+					Return(nil)
+			}
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestPublishRecipeHandler(t *testing.T) {
+	ownerId := uuid.Must(uuid.NewV7())
+	recipeId := uuid.Must(uuid.NewV7())
+	publishAt := time.Date(2099, time.January, 1, 8, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		body           string
+		recipeStatus   string
+		wantStatusCode int
+		wantStatus     string
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "owner has not verified the email",
+			session:        &session.Session{UserID: ownerId, UnverifiedEmail: true},
+			recipeStatus:   "draft",
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "user is not the owner of the recipe",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			recipeStatus:   "draft",
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "request body is not valid JSON",
+			session:        &session.Session{UserID: ownerId},
+			body:           `{"publish_at":`,
+			recipeStatus:   "draft",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "recipe is already published",
+			session:        &session.Session{UserID: ownerId},
+			recipeStatus:   "published",
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name:           "owner publishes a draft",
+			session:        &session.Session{UserID: ownerId},
+			recipeStatus:   "draft",
+			wantStatusCode: http.StatusOK,
+			wantStatus:     "published",
+		},
+		{
+			name:           "owner schedules a draft",
+			session:        &session.Session{UserID: ownerId},
+			body:           `{"publish_at":"2099-01-01T08:00:00Z"}`,
+			recipeStatus:   "draft",
+			wantStatusCode: http.StatusOK,
+			wantStatus:     "scheduled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes/"+recipeId.String()+"/publish", strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			}
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			recipeService.EXPECT().
+				GetRecipeById(gomock.Any(), recipeId).
+				Return(recipe.RecipeResponse{UserID: ownerId, Status: tc.recipeStatus}, nil).
+				AnyTimes()
+
+			switch tc.wantStatus {
+			case "published":
+				recipeService.EXPECT().
+					PublishRecipe(gomock.Any(), recipeId, gomock.Any()).
+					Return("published", nil)
+			case "scheduled":
+				recipeService.EXPECT().
+					PublishRecipe(gomock.Any(), recipeId, publishAt).
+					Return("scheduled", nil)
+			}
+
+			if tc.wantStatusCode == http.StatusOK {
+				cacheStorage.EXPECT().
+					DeleteItem("recipe_" + recipeId.String()).
+					Return(nil)
+			}
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+
+			if tc.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var responseBody recipe.PublishRecipeResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseBody))
+			assert.Equal(t, tc.wantStatus, responseBody.Status)
+		})
+	}
+}
+
+func TestGetHiddenRecipeHandler(t *testing.T) {
+	ownerId := uuid.Must(uuid.NewV7())
+	recipeId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		status         string
+		visibility     string
+		inCache        bool
+		wantCached     bool
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			status:         "draft",
+			visibility:     "public",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "user is not the owner of a draft",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			status:         "draft",
+			visibility:     "public",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "owner reads their draft",
+			session:        &session.Session{UserID: ownerId},
+			status:         "draft",
+			visibility:     "public",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "user is not the owner of a private recipe",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			status:         "published",
+			visibility:     "private",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "owner reads their private recipe",
+			session:        &session.Session{UserID: ownerId},
+			status:         "published",
+			visibility:     "private",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "private recipe left in the cache",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			status:         "published",
+			visibility:     "private",
+			inCache:        true,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "user reads an unlisted recipe by its ID",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			status:         "published",
+			visibility:     "unlisted",
+			wantCached:     true,
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			if tc.session != nil {
+				e.Use(session.WithSessionForTest(tc.session))
+			}
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeId.String(), nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			recipeFromDb := recipe.RecipeResponse{UserID: ownerId, Title: "Chocolate Cookies", Status: tc.status, Visibility: tc.visibility}
+
+			if tc.inCache {
+				// A hidden recipe found in the cache is read again from the database.
+				cachedRecipe, _ := json.Marshal(recipeFromDb)
+
+				cacheStorage.EXPECT().
+					GetItem("recipe_"+recipeId.String()).
+					Return(cachedRecipe, nil)
+			} else {
+				cacheStorage.EXPECT().
+					GetItem("recipe_"+recipeId.String()).
+					Return(nil, errors.New("cache miss"))
+			}
+
+			recipeService.EXPECT().
+				GetRecipeById(gomock.Any(), recipeId).
+				Return(recipeFromDb, nil)
+
+			// The cache is shared by all users, so a recipe hidden from some of them is never saved to it.
+			if tc.wantCached {
+				cacheStorage.EXPECT().
+					InsertItem("recipe_"+recipeId.String(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			}
+
+			recipeService.EXPECT().
+				IsFavoriteRecipe(gomock.Any(), gomock.Any(), recipeId).
+				Return(false, nil).
+				AnyTimes()
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestCreateShareLinkHandler(t *testing.T) {
+	ownerId := uuid.Must(uuid.NewV7())
+	recipeId := uuid.Must(uuid.NewV7())
+	shareLinkId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		body           string
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "user is not the owner of the recipe",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "expiration time is in the past",
+			session:        &session.Session{UserID: ownerId},
+			body:           `{"expires_at":"2000-01-01T00:00:00Z"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "owner creates a link without an expiration time",
+			session:        &session.Session{UserID: ownerId},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "owner creates an expiring link",
+			session:        &session.Session{UserID: ownerId},
+			body:           `{"expires_at":"2099-01-01T00:00:00Z"}`,
+			wantStatusCode: http.StatusCreated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes/"+recipeId.String()+"/share-links", strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			}
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			recipeService.EXPECT().
+				GetRecipeById(gomock.Any(), recipeId).
+				Return(recipe.RecipeResponse{UserID: ownerId}, nil).
+				AnyTimes()
+
+			if tc.wantStatusCode == http.StatusCreated {
+				recipeService.EXPECT().
+					CreateShareLink(gomock.Any(), recipeId, gomock.Any()).
+					Return(recipe.CreatedShareLinkResponse{ShareLinkID: shareLinkId, Token: "token", URL: "/api/v1/shared/token"}, nil)
+			}
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+
+			if tc.wantStatusCode == http.StatusCreated {
+				assert.Equal(t, "/api/v1/shared/token", rec.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestGetSharedRecipeHandler(t *testing.T) {
+	recipeId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		token          string
+		useErr         error
+		wantStatusCode int
+	}{
+		{
+			name:           "link is revoked or has expired",
+			token:          "revoked",
+			useErr:         echo.NewHTTPError(http.StatusNotFound),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "link to a private recipe",
+			token:          "valid",
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/shared/"+tc.token, nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			recipeService.EXPECT().
+				UseShareLink(gomock.Any(), tc.token).
+				Return(recipeId, tc.useErr)
+
+			// The private recipe is read from the database and is not saved to the shared cache.
+			cacheStorage.EXPECT().
+				GetItem("recipe_"+recipeId.String()).
+				Return(nil, errors.New("cache miss")).
+				AnyTimes()
+
+			recipeService.EXPECT().
+				GetRecipeById(gomock.Any(), recipeId).
+				Return(recipe.RecipeResponse{UserID: uuid.Must(uuid.NewV7()), Title: "Chocolate Cookies", Status: "published", Visibility: "private"}, nil).
+				AnyTimes()
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestForkRecipeHandler(t *testing.T) {
+	ownerId := uuid.Must(uuid.NewV7())
+	recipeId := uuid.Must(uuid.NewV7())
+	forkId := uuid.Must(uuid.NewV7())
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		status         string
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			status:         "published",
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "user forks a draft of another user",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			status:         "draft",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "user forks a published recipe",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			status:         "published",
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "owner forks their draft",
+			session:        &session.Session{UserID: ownerId},
+			status:         "draft",
+			wantStatusCode: http.StatusCreated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes/"+recipeId.String()+"/fork", nil)
+
+			rec := httptest.NewRecorder()
+
+			logger := zap.NewNop()
+			recipeService := mock_recipe.NewMockRecipeService(gomock.NewController(t))
+			cacheStorage := mock_recipe.NewMockCacheStorage(gomock.NewController(t))
+
+			cacheStorage.EXPECT().
+				GetItem("recipe_"+recipeId.String()).
+				Return(nil, errors.New("cache miss")).
+				AnyTimes()
+
+			cacheStorage.EXPECT().
+				InsertItem("recipe_"+recipeId.String(), gomock.Any(), gomock.Any()).
+				Return(nil).
+				AnyTimes()
+
+			recipeService.EXPECT().
+				GetRecipeById(gomock.Any(), recipeId).
+				Return(recipe.RecipeResponse{UserID: ownerId, Title: "Chocolate Cookies", Status: tc.status, Visibility: "public"}, nil).
+				AnyTimes()
+
+			if tc.wantStatusCode == http.StatusCreated {
+				recipeService.EXPECT().
+					ForkRecipe(gomock.Any(), recipeId, tc.session.UserID).
+					Return(forkId, nil)
+
+				// The fork count of the original recipe has changed.
+				cacheStorage.EXPECT().
+					DeleteItem("recipe_" + recipeId.String()).
+					Return(nil)
+			}
+
+			handler := recipe.NewHandler(logger, cacheStorage, recipeService)
+			handler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+
+			if tc.wantStatusCode == http.StatusCreated {
+				assert.Equal(t, "/api/v1/recipes/"+forkId.String(), rec.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
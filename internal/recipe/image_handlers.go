@@ -0,0 +1,108 @@
+package recipe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// multipartOverhead is the space left in a request body for the headers and boundaries of the form.
+const multipartOverhead = 64 << 10
+
+var imageTooLargeMessage = fmt.Sprintf("the image must not be larger than %d MB", maxImageSize>>20)
+
+// UploadRecipeImage godoc
+//
+//	@Summary		Upload an image
+//	@Description	Attach a JPEG, PNG or WebP image of at most 5 MB to a recipe. The type of the image is detected from its content.
+//	@Tags			images
+//
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		string										true	"UUID of a recipe."
+//	@Param			image	formData	file										true	"Image file."
+//
+//	@Success		201		{object}	shared.DataResponse[recipe.ImageResponse]	"Image saved successfully."
+//	@Failure		400		{object}	shared.CommonResponse						"Invalid data provided."
+//	@Failure		401		{object}	shared.CommonResponse						"User is not signed in."
+//	@Failure		403		{object}	shared.CommonResponse						"User is not the owner of the recipe."
+//	@Failure		404		{object}	shared.CommonResponse						"Recipe not found."
+//	@Failure		409		{object}	shared.CommonResponse						"Recipe has reached the limit of images."
+//	@Failure		413		{object}	shared.CommonResponse						"Image is too large."
+//	@Failure		415		{object}	shared.CommonResponse						"Image type is not supported."
+//
+//	@Router			/api/v1/recipes/{id}/images [POST]
+func (h *handler) UploadRecipeImage(c echo.Context) error {
+	if err := shared.ValidateMultipartFormContentType(c); err != nil {
+		return err
+	}
+
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.getOwnedRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxImageSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+
+		switch {
+		case errors.As(err, &maxBytesErr):
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, shared.CommonResponse{Message: imageTooLargeMessage})
+		case errors.Is(err, http.ErrMissingFile):
+			return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "missing an image in the 'image' form field"})
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid multipart form request body"})
+		}
+	}
+
+	if fileHeader.Size > maxImageSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, shared.CommonResponse{Message: imageTooLargeMessage})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Trust the content of the file rather than the content type sent by the client.
+	sniffedBytes := make([]byte, 512)
+
+	n, err := io.ReadFull(file, sniffedBytes)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received image is empty"})
+	}
+
+	contentType := http.DetectContentType(sniffedBytes[:n])
+
+	if _, ok := imageExtensions[contentType]; !ok {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, shared.CommonResponse{Message: "only JPEG, PNG and WebP images are allowed"})
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	image, err := h.recipeService.AddRecipeImage(c.Request().Context(), recipeId, file, fileHeader.Size, contentType)
+	if err != nil {
+		return err
+	}
+
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully saved an image of a recipe", zap.String("recipeId", recipeId.String()), zap.String("imageId", image.ImageID.String()))
+
+	return c.JSON(http.StatusCreated, shared.DataResponse[ImageResponse]{Data: image})
+}
@@ -0,0 +1,139 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxImageSize is the maximum size of an uploaded image in bytes.
+	maxImageSize = 5 << 20
+	// maxRecipeImages is the maximum number of images attached to a recipe.
+	maxRecipeImages = 10
+	// blobUploadTimeout is longer than the database timeouts, because images are sent to a remote storage.
+	blobUploadTimeout = 30 * time.Second
+)
+
+// imageExtensions are the file extensions of the accepted content types of images.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+var errTooManyImages = errors.New("recipe has reached the limit of images")
+
+// AddRecipeImage saves an image in the blob store and attaches it to a recipe.
+// The content type has to be one of the imageExtensions.
+func (s *service) AddRecipeImage(ctx context.Context, recipeId uuid.UUID, image io.Reader, size int64, contentType string) (ImageResponse, error) {
+	imageId, err := uuid.NewV7()
+	if err != nil {
+		return ImageResponse{}, errors.Join(errors.New("failed to generate UUID"), err)
+	}
+
+	objectKey := fmt.Sprintf("recipes/%s/%s%s", recipeId, imageId, imageExtensions[contentType])
+
+	err = s.saveRecipeImage(ctx, recipeId, imageId, objectKey, image, size, contentType)
+	if err != nil {
+		switch {
+		case errors.Is(err, errTooManyImages):
+			return ImageResponse{}, echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: fmt.Sprintf("a recipe can have at most %d images", maxRecipeImages)})
+		case errors.Is(err, context.DeadlineExceeded):
+			return ImageResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("addRecipeImage method got uncaught error", zap.Error(err))
+			return ImageResponse{}, err
+		}
+	}
+
+	return ImageResponse{
+		ImageID: imageId,
+		URL:     s.blobStore.URL(objectKey),
+	}, nil
+}
+
+// saveRecipeImage checks the limit of images, uploads an image to the blob store and saves it in the database.
+// A connection is held only for the queries, so slow uploads do not keep connections of the pool busy.
+func (s *service) saveRecipeImage(ctx context.Context, recipeId, imageId uuid.UUID, objectKey string, image io.Reader, size int64, contentType string) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		// Concurrent uploads can exceed the limit by a few images, which is fine for a limit on storage use.
+		imagesCount, err := q.CountRecipeImages(qCtx, recipeId)
+		if err != nil {
+			return err
+		}
+
+		if imagesCount >= maxRecipeImages {
+			return errTooManyImages
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	blobCtx, cancelBlobCtx := context.WithTimeout(ctx, blobUploadTimeout)
+	defer cancelBlobCtx()
+
+	if err := s.blobStore.Put(blobCtx, objectKey, image, contentType); err != nil {
+		return errors.Join(errors.New("failed to save an image in the blob store"), err)
+	}
+
+	connCtx, cancelConnCtx = context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		return sqlc.New(c).CreateRecipeImage(qCtx, sqlc.CreateRecipeImageParams{
+			ImageID:     imageId,
+			RecipeID:    recipeId,
+			ObjectKey:   objectKey,
+			ContentType: contentType,
+			SizeBytes:   size,
+		})
+	})
+	if err != nil {
+		// Do not leave an image nobody links to in the blob store.
+		if deleteErr := s.blobStore.Delete(context.WithoutCancel(ctx), objectKey); deleteErr != nil {
+			s.logger.Error("failed to delete an orphaned image", zap.String("objectKey", objectKey), zap.Error(deleteErr))
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) newImageResponses(imagesFromDb []sqlc.RecipeImage) []ImageResponse {
+	images := make([]ImageResponse, 0, len(imagesFromDb))
+
+	for _, image := range imagesFromDb {
+		images = append(images, ImageResponse{
+			ImageID: image.ImageID,
+			URL:     s.blobStore.URL(image.ObjectKey),
+		})
+	}
+
+	return images
+}
@@ -0,0 +1,37 @@
+package recipe
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maxMentions is the maximum number of mentions stored for a comment.
+const maxMentions = 20
+
+// mentionPattern matches @email mentions which are not a part of another word,
+// so an email address in the text is not taken for a mention.
+// Users have no usernames, so users are mentioned by their emails only.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.@])@([\w.%+-]+@[\w-]+(?:\.[\w-]+)*\.[A-Za-z]{2,})`)
+
+// parseMentions returns the distinct emails mentioned in a comment, lowercased and in order of appearance.
+func parseMentions(body string) []string {
+	handles := make([]string, 0)
+	seen := make(map[string]struct{})
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		handle := strings.ToLower(match[1])
+
+		if _, ok := seen[handle]; ok {
+			continue
+		}
+
+		seen[handle] = struct{}{}
+		handles = append(handles, handle)
+
+		if len(handles) == maxMentions {
+			break
+		}
+	}
+
+	return handles
+}
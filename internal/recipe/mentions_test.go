@@ -0,0 +1,58 @@
+package recipe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		body        string
+		wantHandles []string
+	}{
+		{
+			name:        "no mentions",
+			body:        "Great cookies!",
+			wantHandles: []string{},
+		},
+		{
+			name:        "email mention",
+			body:        "Thanks @Jane.Doe@Mail.com, it worked!",
+			wantHandles: []string{"jane.doe@mail.com"},
+		},
+		{
+			name:        "email mention at the end of a sentence",
+			body:        "Ask @baker.bob@mail.com.",
+			wantHandles: []string{"baker.bob@mail.com"},
+		},
+		{
+			name:        "username is not a mention",
+			body:        "Ask @baker_bob.",
+			wantHandles: []string{},
+		},
+		{
+			name:        "email address without a mention",
+			body:        "Write to chef@mail.com for the recipe.",
+			wantHandles: []string{},
+		},
+		{
+			name:        "repeated mentions",
+			body:        "@anna@mail.com and @bob@mail.com, see what @Anna@Mail.com wrote",
+			wantHandles: []string{"anna@mail.com", "bob@mail.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			handles := parseMentions(tc.body)
+
+			// then
+			assert.Equal(t, tc.wantHandles, handles)
+		})
+	}
+}
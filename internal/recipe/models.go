@@ -1,20 +1,364 @@
 package recipe
 
-import "time"
+import (
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/textdiff"
+	"github.com/google/uuid"
+)
 
 type RecipeResponse struct {
-	Title     string    `json:"title" example:"Chocolate Cookies"`
-	Content   string    `json:"content" example:"Having all your ingredients the same temperature really helps here"`
-	CreatedAt time.Time `json:"created_at" example:"2025-02-05T21:35:31.00635Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"2025-02-07T21:35:31.00635Z"`
+	UserID      uuid.UUID            `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Title       string               `json:"title" example:"Chocolate Cookies"`
+	Content     string               `json:"content" example:"Having all your ingredients the same temperature really helps here"`
+	Servings    int32                `json:"servings" example:"4"`
+	Ingredients []IngredientResponse `json:"ingredients"`
+	Steps       []StepResponse       `json:"steps"`
+	Tags        []string             `json:"tags" example:"dessert,vegetarian"`
+	Images      []ImageResponse      `json:"images"`
+	// AverageRating is null for recipes nobody has reviewed yet.
+	AverageRating *float64 `json:"average_rating" example:"4.25"`
+	RatingCount   int32    `json:"rating_count" example:"8"`
+	FavoriteCount int32    `json:"favorite_count" example:"31"`
+	ForkCount     int32    `json:"fork_count" example:"3"`
+	// ForkedFrom is null for recipes which are not forks of another recipe.
+	ForkedFrom *ForkAttributionResponse `json:"forked_from"`
+	// IsFavorite tells whether the signed-in user has saved the recipe to their favorites.
+	IsFavorite bool   `json:"is_favorite" example:"true"`
+	Status     string `json:"status" example:"published"`
+	Visibility string `json:"visibility" example:"public"`
+	// PublishAt is when a scheduled recipe is going to be published, or when a published recipe was published.
+	PublishAt *time.Time `json:"publish_at" example:"2025-02-06T08:00:00Z"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-02-05T21:35:31.00635Z"`
+	UpdatedAt time.Time  `json:"updated_at" example:"2025-02-07T21:35:31.00635Z"`
+}
+
+type IngredientResponse struct {
+	// Quantity is null for ingredients without a measured amount, e.g. "salt to taste".
+	Quantity *float64 `json:"quantity" example:"200"`
+	// QuantityText is the quantity written the way cooks write it, e.g. "1 1/2".
+	QuantityText string `json:"quantity_text" example:"200"`
+	Unit         string `json:"unit" example:"g"`
+	Name         string `json:"name" example:"dark chocolate"`
+	Note         string `json:"note" example:"finely chopped"`
+}
+
+type GetRecipeRequest struct {
+	// Servings scales the quantities of ingredients from the base servings of a recipe.
+	Servings *int32 `query:"servings" validate:"omitempty,min=1,max=1000"`
 }
 
+// NewRecipeRequest creates a recipe for a single serving when servings are omitted.
 type NewRecipeRequest struct {
-	Title   string `json:"title" validate:"required,min=5" example:"Chocolate Cookies"`
-	Content string `json:"content" validate:"required,min=5" example:"Having all your ingredients the same temperature really helps here"`
+	Title       string              `json:"title" validate:"required,min=5" example:"Chocolate Cookies"`
+	Content     string              `json:"content" validate:"required,min=5" example:"Having all your ingredients the same temperature really helps here"`
+	Servings    int32               `json:"servings" validate:"omitempty,min=1,max=100" example:"4"`
+	Ingredients []IngredientRequest `json:"ingredients" validate:"max=100,dive"`
+	Steps       []StepRequest       `json:"steps" validate:"max=100,dive"`
+	Tags        []string            `json:"tags" validate:"max=20,dive,required,max=50" example:"dessert,vegetarian"`
+	// Visibility is public when it is omitted.
+	Visibility string `json:"visibility" validate:"omitempty,oneof=private unlisted public" example:"public"`
 }
 
+// UpdateRecipeRequest keeps the current value of a recipe for every omitted field.
+// Sending an empty list of ingredients or tags removes them from the recipe.
 type UpdateRecipeRequest struct {
-	Title   string `json:"title" validate:"required,min=5" example:"Chocolate Cookies"`
-	Content string `json:"content" validate:"required,min=5" example:"Having all your ingredients the same temperature really helps here"`
+	Title       string              `json:"title" validate:"required,min=5" example:"Chocolate Cookies"`
+	Content     string              `json:"content" validate:"required,min=5" example:"Having all your ingredients the same temperature really helps here"`
+	Servings    int32               `json:"servings" validate:"required,min=1,max=100" example:"4"`
+	Ingredients []IngredientRequest `json:"ingredients" validate:"max=100,dive"`
+	Tags        []string            `json:"tags" validate:"max=20,dive,required,max=50" example:"dessert,vegetarian"`
+	Visibility  string              `json:"visibility" validate:"required,oneof=private unlisted public" example:"unlisted"`
+}
+
+type IngredientRequest struct {
+	Quantity *float64 `json:"quantity" validate:"omitempty,gt=0" example:"200"`
+	Unit     string   `json:"unit" validate:"max=30" example:"g"`
+	Name     string   `json:"name" validate:"required,max=100" example:"dark chocolate"`
+	Note     string   `json:"note" validate:"max=200" example:"finely chopped"`
+}
+
+type RecipeSummaryResponse struct {
+	RecipeID      uuid.UUID `json:"recipe_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	UserID        uuid.UUID `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Title         string    `json:"title" example:"Chocolate Cookies"`
+	FavoriteCount int32     `json:"favorite_count" example:"31"`
+	CreatedAt     time.Time `json:"created_at" example:"2025-02-05T21:35:31.00635Z"`
+	UpdatedAt     time.Time `json:"updated_at" example:"2025-02-07T21:35:31.00635Z"`
+}
+
+type ListRecipesRequest struct {
+	Cursor        string    `query:"cursor"`
+	Limit         int32     `query:"limit" validate:"omitempty,min=1,max=100"`
+	Sort          string    `query:"sort" validate:"omitempty,oneof=created_at updated_at title favorite_count"`
+	AuthorID      uuid.UUID `query:"author_id"`
+	CreatedAfter  time.Time `query:"created_after"`
+	CreatedBefore time.Time `query:"created_before"`
+	Tags          []string  `query:"tag" validate:"max=10,dive,required,max=50"`
+	TagMatch      string    `query:"tag_match" validate:"omitempty,oneof=any all"`
+}
+
+type RecipeSearchResultResponse struct {
+	RecipeID       uuid.UUID `json:"recipe_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	UserID         uuid.UUID `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Title          string    `json:"title" example:"Chocolate Cookies"`
+	TitleHighlight string    `json:"title_highlight" example:"<mark>Chocolate</mark> Cookies"`
+	Snippet        string    `json:"snippet" example:"melt the <mark>chocolate</mark> over a water bath"`
+	Rank           float64   `json:"rank" example:"0.6079271"`
+	CreatedAt      time.Time `json:"created_at" example:"2025-02-05T21:35:31.00635Z"`
+	UpdatedAt      time.Time `json:"updated_at" example:"2025-02-07T21:35:31.00635Z"`
+}
+
+type SearchRecipesRequest struct {
+	Query  string `query:"q" validate:"required,min=2,max=200"`
+	Cursor string `query:"cursor"`
+	Limit  int32  `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type StepResponse struct {
+	StepID          uuid.UUID `json:"step_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Position        int32     `json:"position" example:"1"`
+	Instruction     string    `json:"instruction" example:"Bake until the edges are golden."`
+	DurationMinutes *int32    `json:"duration_minutes" example:"12"`
+	Temperature     *int32    `json:"temperature" example:"180"`
+	TemperatureUnit *string   `json:"temperature_unit" example:"C"`
+}
+
+type StepRequest struct {
+	Instruction     string `json:"instruction" validate:"required,min=3,max=2000" example:"Bake until the edges are golden."`
+	DurationMinutes *int32 `json:"duration_minutes" validate:"omitempty,gt=0" example:"12"`
+	Temperature     *int32 `json:"temperature" validate:"omitempty,gt=0" example:"180"`
+	TemperatureUnit string `json:"temperature_unit" validate:"required_with=Temperature,excluded_without=Temperature,omitempty,oneof=C F" example:"C"`
+}
+
+// InsertStepRequest adds a step at the given position, moving the following steps down.
+// The step is appended to the end of a recipe when the position is omitted.
+type InsertStepRequest struct {
+	StepRequest
+	Position *int32 `json:"position" validate:"omitempty,min=1" example:"2"`
+}
+
+type ReorderStepsRequest struct {
+	StepIDs []uuid.UUID `json:"step_ids" validate:"required,min=1"`
+}
+
+type TagResponse struct {
+	Name        string `json:"name" example:"vegetarian"`
+	Category    string `json:"category" example:"diet"`
+	RecipeCount int32  `json:"recipe_count" example:"12"`
+}
+
+type ImageResponse struct {
+	ImageID uuid.UUID `json:"image_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	URL     string    `json:"url" example:"http://localhost:8080/uploads/recipes/0194b341-6797-736a-9a98-474d08025925/0194b341-6797-736a-9a98-474d08025926.jpg"`
+}
+
+type CompareRecipesRequest struct {
+	A string `query:"a" validate:"required,uuid"`
+	B string `query:"b" validate:"required,uuid"`
+}
+
+type RecipeComparisonResponse struct {
+	A           ComparedRecipeResponse         `json:"a"`
+	B           ComparedRecipeResponse         `json:"b"`
+	TitleDiff   []textdiff.Segment             `json:"title_diff"`
+	ContentDiff []textdiff.Segment             `json:"content_diff"`
+	Ingredients []IngredientComparisonResponse `json:"ingredients"`
+	// Similarity is 0 for recipes with nothing in common and 1 for the same recipes.
+	Similarity float64 `json:"similarity" example:"0.72"`
+}
+
+type ComparedRecipeResponse struct {
+	RecipeID uuid.UUID `json:"recipe_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	UserID   uuid.UUID `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Title    string    `json:"title" example:"Chocolate Cookies"`
+}
+
+// IngredientComparisonResponse shows the quantities of an ingredient in both recipes.
+// A quantity is null when the recipe does not use the ingredient.
+type IngredientComparisonResponse struct {
+	Name      string  `json:"name" example:"dark chocolate"`
+	QuantityA *string `json:"quantity_a" example:"200 g"`
+	QuantityB *string `json:"quantity_b" example:"150 g"`
+}
+
+// ReviewRequest replaces the previous review of a user, so every user has at most one review of a recipe.
+type ReviewRequest struct {
+	Rating int16  `json:"rating" validate:"required,min=1,max=5" example:"5"`
+	Body   string `json:"body" validate:"max=2000" example:"Crispy edges and a soft middle, just as promised."`
+}
+
+type ReviewResponse struct {
+	UserID    uuid.UUID `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Rating    int16     `json:"rating" example:"5"`
+	Body      string    `json:"body" example:"Crispy edges and a soft middle, just as promised."`
+	CreatedAt time.Time `json:"created_at" example:"2025-02-05T21:35:31.00635Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-02-07T21:35:31.00635Z"`
+}
+
+type ListReviewsRequest struct {
+	Cursor string `query:"cursor"`
+	Limit  int32  `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type FavoriteRecipeResponse struct {
+	RecipeSummaryResponse
+	FavoritedAt time.Time `json:"favorited_at" example:"2025-02-08T21:35:31.00635Z"`
+}
+
+type ListFavoritesRequest struct {
+	Cursor string `query:"cursor"`
+	Limit  int32  `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// CommentRequest replies to a top-level comment when parent_id is set.
+type CommentRequest struct {
+	Body     string     `json:"body" validate:"required,max=2000" example:"Thanks @jane@mail.com, I added a pinch of salt too!"`
+	ParentID *uuid.UUID `json:"parent_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=2000" example:"Thanks @jane@mail.com, I added a pinch of salt too!"`
+}
+
+// CommentResponse hides the author and the text of a deleted comment.
+type CommentResponse struct {
+	CommentID uuid.UUID  `json:"comment_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	UserID    *uuid.UUID `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Body      string     `json:"body" example:"Thanks @jane@mail.com, I added a pinch of salt too!"`
+	IsDeleted bool       `json:"is_deleted" example:"false"`
+	// ReplyCount is the number of replies to a top-level comment. It is always 0 for replies.
+	ReplyCount int32     `json:"reply_count" example:"3"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-02-05T21:35:31.00635Z"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2025-02-07T21:35:31.00635Z"`
+}
+
+type ListCommentsRequest struct {
+	Cursor string `query:"cursor"`
+	Limit  int32  `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type RevisionSummaryResponse struct {
+	RevisionNumber int32     `json:"revision_number" example:"3"`
+	Title          string    `json:"title" example:"Chocolate Cookies"`
+	CreatedAt      time.Time `json:"created_at" example:"2025-02-07T21:35:31.00635Z"`
+}
+
+// RevisionResponse is the state of a recipe saved by its creation or by one of its updates.
+// Steps are null and visibility is empty for revisions saved before revisions kept them.
+type RevisionResponse struct {
+	RevisionNumber int32                  `json:"revision_number" example:"3"`
+	Title          string                 `json:"title" example:"Chocolate Cookies"`
+	Content        string                 `json:"content" example:"Having all your ingredients the same temperature really helps here"`
+	Servings       int32                  `json:"servings" example:"4"`
+	Ingredients    []IngredientResponse   `json:"ingredients"`
+	Steps          []RevisionStepResponse `json:"steps"`
+	Tags           []string               `json:"tags" example:"dessert,vegetarian"`
+	Visibility     string                 `json:"visibility,omitempty" example:"public"`
+	CreatedAt      time.Time              `json:"created_at" example:"2025-02-07T21:35:31.00635Z"`
+}
+
+// RevisionStepResponse is a step saved in a revision.
+// Reverting to a revision creates its steps anew, so revisions do not keep the IDs of steps.
+type RevisionStepResponse struct {
+	Position        int32   `json:"position" example:"1"`
+	Instruction     string  `json:"instruction" example:"Bake until the edges are golden."`
+	DurationMinutes *int32  `json:"duration_minutes" example:"12"`
+	Temperature     *int32  `json:"temperature" example:"180"`
+	TemperatureUnit *string `json:"temperature_unit" example:"C"`
+}
+
+type ListRevisionsRequest struct {
+	Cursor string `query:"cursor"`
+	Limit  int32  `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type RevisionDiffRequest struct {
+	From int32 `query:"from" validate:"required,min=1"`
+	To   int32 `query:"to" validate:"required,min=1"`
+}
+
+// RevisionDiffResponse compares two revisions of a recipe line by line.
+// Ingredients and steps are compared one per line and tags one per line in alphabetical order.
+type RevisionDiffResponse struct {
+	From        int32              `json:"from" example:"1"`
+	To          int32              `json:"to" example:"3"`
+	Title       []textdiff.Segment `json:"title"`
+	Content     []textdiff.Segment `json:"content"`
+	Servings    []textdiff.Segment `json:"servings"`
+	Ingredients []textdiff.Segment `json:"ingredients"`
+	Steps       []textdiff.Segment `json:"steps"`
+	Tags        []textdiff.Segment `json:"tags"`
+	Visibility  []textdiff.Segment `json:"visibility"`
+}
+
+// TrashedRecipeResponse is a deleted recipe, which can be restored until it is purged.
+type TrashedRecipeResponse struct {
+	RecipeSummaryResponse
+	DeletedAt time.Time `json:"deleted_at" example:"2025-02-08T21:35:31.00635Z"`
+	PurgeAt   time.Time `json:"purge_at" example:"2025-03-10T21:35:31.00635Z"`
+}
+
+type ListTrashRequest struct {
+	Cursor string `query:"cursor"`
+	Limit  int32  `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type PublishRecipeRequest struct {
+	// PublishAt schedules the recipe to be published later. The recipe is published right away when it is omitted or in the past.
+	PublishAt *time.Time `json:"publish_at" example:"2025-02-06T08:00:00Z"`
+}
+
+type PublishRecipeResponse struct {
+	Status    string    `json:"status" example:"scheduled"`
+	PublishAt time.Time `json:"publish_at" example:"2025-02-06T08:00:00Z"`
+}
+
+type OwnRecipeResponse struct {
+	RecipeSummaryResponse
+	Status     string     `json:"status" example:"draft"`
+	PublishAt  *time.Time `json:"publish_at" example:"2025-02-06T08:00:00Z"`
+	Visibility string     `json:"visibility" example:"private"`
+}
+
+type ListOwnRecipesRequest struct {
+	Cursor string `query:"cursor"`
+	Limit  int32  `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status string `query:"status" validate:"omitempty,oneof=draft scheduled published"`
+}
+
+type ShareLinkRequest struct {
+	// ExpiresAt is when the link stops working. The link is valid until it is revoked when it is omitted.
+	ExpiresAt *time.Time `json:"expires_at" example:"2025-03-01T00:00:00Z"`
+}
+
+type ShareLinkResponse struct {
+	ShareLinkID uuid.UUID  `json:"share_link_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	ExpiresAt   *time.Time `json:"expires_at" example:"2025-03-01T00:00:00Z"`
+	RevokedAt   *time.Time `json:"revoked_at" example:"2025-02-10T12:00:00Z"`
+	ViewCount   int32      `json:"view_count" example:"12"`
+	// LastAccessedAt is null for links nobody has opened yet.
+	LastAccessedAt *time.Time `json:"last_accessed_at" example:"2025-02-09T18:21:00Z"`
+	CreatedAt      time.Time  `json:"created_at" example:"2025-02-05T21:35:31.00635Z"`
+}
+
+// CreatedShareLinkResponse is returned only once, because the token is not stored and cannot be shown again.
+type CreatedShareLinkResponse struct {
+	ShareLinkID uuid.UUID  `json:"share_link_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Token       string     `json:"token" example:"q3v1X0pTn8bA2cYdE5fGh7iJk9lMn0oPq2rSt4uVw6x"`
+	URL         string     `json:"url" example:"/api/v1/shared/q3v1X0pTn8bA2cYdE5fGh7iJk9lMn0oPq2rSt4uVw6x"`
+	ExpiresAt   *time.Time `json:"expires_at" example:"2025-03-01T00:00:00Z"`
+}
+
+// ForkAttributionResponse points a fork to its original recipe. It is kept after the original recipe is deleted.
+type ForkAttributionResponse struct {
+	RecipeID uuid.UUID `json:"recipe_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	// UserID is null when the author of the original recipe has deleted their account.
+	UserID *uuid.UUID `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Title  string     `json:"title" example:"Chocolate Cookies"`
+}
+
+type ListForksRequest struct {
+	Cursor string `query:"cursor"`
+	Limit  int32  `query:"limit" validate:"omitempty,min=1,max=100"`
 }
@@ -0,0 +1,127 @@
+package recipe
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// PublishRecipe godoc
+//
+//	@Summary		Publish a recipe
+//	@Description	Make a draft visible to everyone. Pass publish_at to schedule the recipe to be published later instead.
+//	@Description	A scheduled recipe can be published again to change its publish time or to publish it right away.
+//	@Tags			recipes
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string								true	"UUID of a recipe."
+//	@Param			PublishRecipeRequest	body		recipe.PublishRecipeRequest			false	"Optional request body with a publish time."
+//
+//	@Success		200						{object}	recipe.PublishRecipeResponse		"Recipe published or scheduled successfully."
+//	@Failure		400						{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401						{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403						{object}	shared.CommonResponse				"User is not the owner of the recipe or has not verified the email."
+//	@Failure		404						{object}	shared.CommonResponse				"Recipe not found."
+//	@Failure		409						{object}	shared.CommonResponse				"Recipe is already published."
+//
+//	@Router			/api/v1/recipes/{id}/publish [POST]
+func (h *handler) PublishRecipe(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestBody = PublishRecipeRequest{}
+
+	// The body is optional, a recipe without one is published right away.
+	if c.Request().ContentLength != 0 {
+		if err := shared.ValidateJSONContentType(c); err != nil {
+			return err
+		}
+
+		if err := c.Bind(&requestBody); err != nil {
+			return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+		}
+
+		if err := c.Validate(&requestBody); err != nil {
+			return err
+		}
+	}
+
+	recipeFromDb, err := h.getOwnedRecipe(c, recipeId)
+	if err != nil {
+		return err
+	}
+
+	if recipeFromDb.Status == statusPublished {
+		return echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "the recipe is already published"})
+	}
+
+	publishAt := time.Now()
+	if requestBody.PublishAt != nil && requestBody.PublishAt.After(publishAt) {
+		publishAt = *requestBody.PublishAt
+	}
+
+	status, err := h.recipeService.PublishRecipe(c.Request().Context(), recipeId, publishAt)
+	if err != nil {
+		return err
+	}
+
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully changed the status of a recipe", zap.String("recipeId", recipeId.String()), zap.String("status", status))
+
+	return c.JSON(http.StatusOK, PublishRecipeResponse{Status: status, PublishAt: publishAt.UTC()})
+}
+
+// ListOwnRecipes godoc
+//
+//	@Summary		List recipes of the signed-in user
+//	@Description	Get a page of recipes of the signed-in user in every status, starting from the most recently updated ones.
+//	@Description	Pass status to list only drafts, scheduled or published recipes.
+//	@Tags			recipes
+//
+//	@Produce		json
+//
+//	@Param			cursor	query		string												false	"Cursor to the next page."
+//	@Param			limit	query		int													false	"Maximum number of recipes on a page."	minimum(1)	maximum(100)	default(20)
+//	@Param			status	query		string												false	"Status of the listed recipes."			Enums(draft, scheduled, published)
+//
+//	@Success		200		{object}	shared.PagedDataResponse[recipe.OwnRecipeResponse]	"Recipes fetched successfully."
+//	@Failure		400		{object}	validator.ValidationErrorResponse					"Invalid data provided."
+//	@Failure		401		{object}	shared.CommonResponse								"User is not signed in."
+//
+//	@Router			/api/v1/me/recipes [GET]
+func (h *handler) ListOwnRecipes(c echo.Context) error {
+	var requestQuery = ListOwnRecipesRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	if requestQuery.Limit == 0 {
+		requestQuery.Limit = defaultListLimit
+	}
+
+	recipes, nextCursor, err := h.recipeService.ListOwnRecipes(c.Request().Context(), session.FromContext(c).UserID, requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[OwnRecipeResponse]{
+		Data: recipes,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
@@ -0,0 +1,187 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Statuses of a recipe. Only published recipes are visible to users other than the owner.
+const (
+	statusDraft     = "draft"
+	statusScheduled = "scheduled"
+	statusPublished = "published"
+)
+
+// ownRecipeCursor stores the update time and ID of the last recipe returned on a page.
+type ownRecipeCursor struct {
+	UpdatedAt time.Time `json:"u"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// PublishRecipe publishes a draft or a scheduled recipe. A recipe with a publish time in the future
+// is scheduled instead and gets published by the scheduler. It returns the new status of the recipe.
+func (s *service) PublishRecipe(ctx context.Context, recipeId uuid.UUID, publishAt time.Time) (string, error) {
+	status := statusPublished
+	if publishAt.After(time.Now()) {
+		status = statusScheduled
+	}
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		updatedRows, err := q.PublishRecipe(qCtx, sqlc.PublishRecipeParams{
+			RecipeID:  recipeId,
+			Status:    status,
+			PublishAt: toPgTimestamp(publishAt),
+		})
+		if err != nil {
+			return err
+		}
+
+		// The recipe is either deleted or has been published in the meantime.
+		if updatedRows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return "", echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "the recipe is already published"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("publishRecipe method got uncaught error", zap.Error(err))
+			return "", err
+		}
+	}
+
+	return status, nil
+}
+
+// RunPublishScheduler publishes the scheduled recipes every interval until the context is done.
+func (s *service) RunPublishScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		publishedCount, err := s.PublishScheduledRecipes(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Error("failed to publish scheduled recipes", zap.Error(err))
+		} else if publishedCount > 0 {
+			s.logger.Info("successfully published scheduled recipes", zap.Int64("count", publishedCount))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishScheduledRecipes publishes the scheduled recipes whose publish time has come
+// and returns the number of published recipes.
+func (s *service) PublishScheduledRecipes(ctx context.Context) (int64, error) {
+	var publishedCount int64
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		var err error
+
+		publishedCount, err = q.PublishScheduledRecipes(qCtx)
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return publishedCount, nil
+}
+
+// ListOwnRecipes returns a page of recipes of a user in any status, starting from the most recently updated ones,
+// and a cursor to the next page. The returned cursor is empty when there are no more recipes.
+func (s *service) ListOwnRecipes(ctx context.Context, userId uuid.UUID, listOwnRecipesRequest ListOwnRecipesRequest) ([]OwnRecipeResponse, string, error) {
+	var cursor ownRecipeCursor
+
+	if listOwnRecipesRequest.Cursor != "" {
+		if err := shared.DecodeCursor(listOwnRecipesRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rowLimit := shared.PageLimit(listOwnRecipesRequest.Limit)
+
+	recipes := make([]OwnRecipeResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListRecipesByUserId(qCtx, sqlc.ListRecipesByUserIdParams{
+			UserID:          userId,
+			Status:          pgtype.Text{String: listOwnRecipesRequest.Status, Valid: listOwnRecipesRequest.Status != ""},
+			CursorID:        toPgUUID(cursor.ID),
+			CursorUpdatedAt: toPgTimestamp(cursor.UpdatedAt),
+			RowLimit:        rowLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			recipes = append(recipes, OwnRecipeResponse{
+				RecipeSummaryResponse: newRecipeSummaryResponse(row.RecipeID, row.UserID, row.Title, row.FavoriteCount, row.CreatedAt, row.UpdatedAt),
+				Status:                row.Status,
+				PublishAt:             fromPgTimestamp(row.PublishAt),
+				Visibility:            row.Visibility,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listOwnRecipes method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return shared.Paginate(recipes, listOwnRecipesRequest.Limit, func(lastRecipe OwnRecipeResponse) any {
+		return ownRecipeCursor{UpdatedAt: lastRecipe.UpdatedAt, ID: lastRecipe.RecipeID}
+	})
+}
@@ -0,0 +1,132 @@
+package recipe
+
+import (
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// SaveRecipeReview godoc
+//
+//	@Summary		Review a recipe
+//	@Description	Rate a recipe with 1 to 5 stars and an optional text. A user has one review of a recipe, so posting again replaces the previous review.
+//	@Tags			reviews
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string								true	"UUID of a recipe."
+//	@Param			ReviewRequest	body		recipe.ReviewRequest				true	"Request body with a rating and an optional text."
+//
+//	@Success		200				{object}	shared.CommonResponse				"Review updated successfully."
+//	@Success		201				{object}	shared.CommonResponse				"Review saved successfully."
+//	@Failure		400				{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401				{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403				{object}	shared.CommonResponse				"User has not verified the email or is the owner of the recipe."
+//	@Failure		404				{object}	shared.CommonResponse				"Recipe not found."
+//
+//	@Router			/api/v1/recipes/{id}/reviews [POST]
+func (h *handler) SaveRecipeReview(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestBody = ReviewRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	recipe, err := h.getReadableRecipe(c, recipeId)
+	if err != nil {
+		return err
+	}
+
+	userId := session.FromContext(c).UserID
+
+	if recipe.UserID == userId {
+		return echo.NewHTTPError(http.StatusForbidden, shared.CommonResponse{Message: "the owner of the recipe cannot review it"})
+	}
+
+	created, err := h.recipeService.SaveRecipeReview(c.Request().Context(), recipeId, userId, requestBody)
+	if err != nil {
+		return err
+	}
+
+	// The cached recipe holds the rating aggregate, which has just changed.
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully saved a review of a recipe", zap.String("recipeId", recipeId.String()), zap.String("userId", userId.String()))
+
+	if !created {
+		return c.JSON(http.StatusOK, shared.CommonResponse{Message: "successfully updated a review"})
+	}
+
+	return c.JSON(http.StatusCreated, shared.CommonResponse{Message: "successfully saved a review"})
+}
+
+// ListRecipeReviews godoc
+//
+//	@Summary		List reviews of a recipe
+//	@Description	Get a page of reviews of a recipe, starting from the newest ones.
+//	@Tags			reviews
+//
+//	@Produce		json
+//
+//	@Param			id		path		string											true	"UUID of a recipe."
+//	@Param			cursor	query		string											false	"Cursor to the next page."
+//	@Param			limit	query		int												false	"Maximum number of reviews on a page."	minimum(1)	maximum(100)	default(20)
+//
+//	@Success		200		{object}	shared.PagedDataResponse[recipe.ReviewResponse]	"Reviews fetched successfully."
+//	@Failure		400		{object}	validator.ValidationErrorResponse				"Invalid data provided."
+//	@Failure		404		{object}	shared.CommonResponse							"Recipe not found."
+//
+//	@Router			/api/v1/recipes/{id}/reviews [GET]
+func (h *handler) ListRecipeReviews(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestQuery = ListReviewsRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	if requestQuery.Limit == 0 {
+		requestQuery.Limit = defaultListLimit
+	}
+
+	if _, err := h.getReadableRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	reviews, nextCursor, err := h.recipeService.ListRecipeReviews(c.Request().Context(), recipeId, requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[ReviewResponse]{
+		Data: reviews,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
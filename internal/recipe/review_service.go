@@ -0,0 +1,163 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// SaveRecipeReview creates a review of a recipe or replaces the previous review of the user.
+// The rating aggregate of the recipe is adjusted in the same transaction, so it always matches the reviews.
+// It reports whether a new review was created.
+func (s *service) SaveRecipeReview(ctx context.Context, recipeId, userId uuid.UUID, reviewRequest ReviewRequest) (bool, error) {
+	var created bool
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		// Lock the recipe, so concurrent reviews of the same user cannot both count as new ones.
+		if _, err := q.LockRecipeForReview(qCtx, recipeId); err != nil {
+			return err
+		}
+
+		var ratingSumDelta, ratingCountDelta int32
+
+		previousRating, err := q.GetRecipeReviewRating(qCtx, sqlc.GetRecipeReviewRatingParams{
+			RecipeID: recipeId,
+			UserID:   userId,
+		})
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			created = true
+			ratingSumDelta = int32(reviewRequest.Rating)
+			ratingCountDelta = 1
+		case err != nil:
+			return err
+		default:
+			ratingSumDelta = int32(reviewRequest.Rating - previousRating)
+		}
+
+		err = q.UpsertRecipeReview(qCtx, sqlc.UpsertRecipeReviewParams{
+			RecipeID: recipeId,
+			UserID:   userId,
+			Rating:   reviewRequest.Rating,
+			Body:     reviewRequest.Body,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.UpdateRecipeRatingAggregate(qCtx, sqlc.UpdateRecipeRatingAggregateParams{
+			RecipeID:         recipeId,
+			RatingSumDelta:   ratingSumDelta,
+			RatingCountDelta: ratingCountDelta,
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return false, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a recipe with this ID"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return false, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("saveRecipeReview method got uncaught error", zap.Error(err))
+			return false, err
+		}
+	}
+
+	return created, nil
+}
+
+// reviewCursor stores the creation time and author of the last review returned on a page.
+type reviewCursor struct {
+	CreatedAt time.Time `json:"c"`
+	UserID    uuid.UUID `json:"id"`
+}
+
+// ListRecipeReviews returns a page of reviews of a recipe, starting from the newest ones,
+// and a cursor to the next page. The returned cursor is empty when there are no more reviews.
+func (s *service) ListRecipeReviews(ctx context.Context, recipeId uuid.UUID, listReviewsRequest ListReviewsRequest) ([]ReviewResponse, string, error) {
+	var cursor reviewCursor
+
+	if listReviewsRequest.Cursor != "" {
+		if err := shared.DecodeCursor(listReviewsRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rowLimit := shared.PageLimit(listReviewsRequest.Limit)
+
+	reviews := make([]ReviewResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListRecipeReviews(qCtx, sqlc.ListRecipeReviewsParams{
+			RecipeID:        recipeId,
+			CursorUserID:    toPgUUID(cursor.UserID),
+			CursorCreatedAt: toPgTimestamp(cursor.CreatedAt),
+			RowLimit:        rowLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			reviews = append(reviews, ReviewResponse{
+				UserID:    row.UserID,
+				Rating:    row.Rating,
+				Body:      row.Body,
+				CreatedAt: row.CreatedAt.Time,
+				UpdatedAt: row.UpdatedAt.Time,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listRecipeReviews method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return shared.Paginate(reviews, listReviewsRequest.Limit, func(lastReview ReviewResponse) any {
+		return reviewCursor{CreatedAt: lastReview.CreatedAt, UserID: lastReview.UserID}
+	})
+}
+
+// averageRating returns the mean rating rounded to two decimal places, or nil when there are no ratings.
+func averageRating(ratingSum, ratingCount int32) *float64 {
+	if ratingCount == 0 {
+		return nil
+	}
+
+	average := math.Round(float64(ratingSum)/float64(ratingCount)*100) / 100
+
+	return &average
+}
@@ -0,0 +1,259 @@
+package recipe
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// ListRecipeRevisions godoc
+//
+//	@Summary		List revisions of a recipe
+//	@Description	Get a page of revisions of a recipe, starting from the newest ones. A revision is saved when a recipe is created and on every update.
+//	@Description	Only the owner can see the history of a recipe.
+//	@Tags			revisions
+//
+//	@Produce		json
+//
+//	@Param			id		path		string														true	"UUID of a recipe."
+//	@Param			cursor	query		string														false	"Cursor to the next page."
+//	@Param			limit	query		int															false	"Maximum number of revisions on a page."	minimum(1)	maximum(100)	default(20)
+//
+//	@Success		200		{object}	shared.PagedDataResponse[recipe.RevisionSummaryResponse]	"Revisions fetched successfully."
+//	@Failure		400		{object}	validator.ValidationErrorResponse							"Invalid data provided."
+//	@Failure		401		{object}	shared.CommonResponse										"User is not signed in."
+//	@Failure		403		{object}	shared.CommonResponse										"User is not the owner of the recipe."
+//	@Failure		404		{object}	shared.CommonResponse										"Recipe not found."
+//
+//	@Router			/api/v1/recipes/{id}/revisions [GET]
+func (h *handler) ListRecipeRevisions(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestQuery = ListRevisionsRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	if requestQuery.Limit == 0 {
+		requestQuery.Limit = defaultListLimit
+	}
+
+	if _, err := h.getOwnedRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	revisions, nextCursor, err := h.recipeService.ListRecipeRevisions(c.Request().Context(), recipeId, requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[RevisionSummaryResponse]{
+		Data: revisions,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
+
+// GetRecipeRevision godoc
+//
+//	@Summary		Get a revision of a recipe
+//	@Description	Get the title, content, servings, ingredients and tags of a recipe as they were saved by a revision.
+//	@Tags			revisions
+//
+//	@Produce		json
+//
+//	@Param			id				path		string					true	"UUID of a recipe."
+//	@Param			revisionNumber	path		int						true	"Number of a revision, starting from 1."
+//
+//	@Success		200				{object}	recipe.RevisionResponse	"Revision fetched successfully."
+//	@Failure		400				{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401				{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		403				{object}	shared.CommonResponse	"User is not the owner of the recipe."
+//	@Failure		404				{object}	shared.CommonResponse	"Recipe or revision not found."
+//
+//	@Router			/api/v1/recipes/{id}/revisions/{revisionNumber} [GET]
+func (h *handler) GetRecipeRevision(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	revisionNumber, err := parseRevisionNumber(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.getOwnedRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	revision, err := h.recipeService.GetRecipeRevision(c.Request().Context(), recipeId, revisionNumber)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, revision)
+}
+
+// DiffRecipeRevisions godoc
+//
+//	@Summary		Compare two revisions of a recipe
+//	@Description	Diff the fields of two revisions of a recipe line by line. Ingredients and tags are compared one per line.
+//	@Tags			revisions
+//
+//	@Produce		json
+//
+//	@Param			id		path		string								true	"UUID of a recipe."
+//	@Param			from	query		int									true	"Number of the older revision."
+//	@Param			to		query		int									true	"Number of the newer revision."
+//
+//	@Success		200		{object}	recipe.RevisionDiffResponse			"Revisions compared successfully."
+//	@Failure		400		{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401		{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403		{object}	shared.CommonResponse				"User is not the owner of the recipe."
+//	@Failure		404		{object}	shared.CommonResponse				"Recipe or revision not found."
+//
+//	@Router			/api/v1/recipes/{id}/revisions/diff [GET]
+func (h *handler) DiffRecipeRevisions(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestQuery = RevisionDiffRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	if _, err := h.getOwnedRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	diff, err := h.recipeService.DiffRecipeRevisions(c.Request().Context(), recipeId, requestQuery.From, requestQuery.To)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
+
+// RevertRecipeRevision godoc
+//
+//	@Summary		Revert a recipe to a revision
+//	@Description	Restore the title, content, servings, ingredients, steps and tags of a recipe from a revision.
+//	@Description	The visibility is kept, so reverting cannot make a private recipe visible again. Steps are kept too for revisions saved before revisions kept steps.
+//	@Description	Reverting is an update of its own, so it saves a new revision and keeps the history intact.
+//	@Tags			revisions
+//
+//	@Produce		json
+//
+//	@Param			id				path	string	true	"UUID of a recipe."
+//	@Param			revisionNumber	path	int		true	"Number of a revision, starting from 1."
+//
+//	@Success		204				"Recipe reverted successfully."
+//	@Failure		400				{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401				{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		403				{object}	shared.CommonResponse	"User is not the owner of the recipe."
+//	@Failure		404				{object}	shared.CommonResponse	"Recipe or revision not found."
+//	@Failure		409				{object}	shared.CommonResponse	"Recipe was modified at the same time."
+//
+//	@Router			/api/v1/recipes/{id}/revisions/{revisionNumber}/revert [POST]
+func (h *handler) RevertRecipeRevision(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	revisionNumber, err := parseRevisionNumber(c)
+	if err != nil {
+		return err
+	}
+
+	recipeFromDb, err := h.getOwnedRecipe(c, recipeId)
+	if err != nil {
+		return err
+	}
+
+	revision, err := h.recipeService.GetRecipeRevision(c.Request().Context(), recipeId, revisionNumber)
+	if err != nil {
+		return err
+	}
+
+	updateRecipeRequest := UpdateRecipeRequest{
+		Title:       revision.Title,
+		Content:     revision.Content,
+		Servings:    revision.Servings,
+		Ingredients: make([]IngredientRequest, 0, len(revision.Ingredients)),
+		Tags:        revision.Tags,
+		Visibility:  recipeFromDb.Visibility,
+	}
+
+	for _, ingredient := range revision.Ingredients {
+		updateRecipeRequest.Ingredients = append(updateRecipeRequest.Ingredients, IngredientRequest{
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+			Name:     ingredient.Name,
+			Note:     ingredient.Note,
+		})
+	}
+
+	// Steps stay nil for revisions which did not keep them, so the current steps are kept.
+	var steps []StepRequest
+
+	if revision.Steps != nil {
+		steps = make([]StepRequest, 0, len(revision.Steps))
+
+		for _, step := range revision.Steps {
+			stepRequest := StepRequest{
+				Instruction:     step.Instruction,
+				DurationMinutes: step.DurationMinutes,
+				Temperature:     step.Temperature,
+			}
+
+			if step.TemperatureUnit != nil {
+				stepRequest.TemperatureUnit = *step.TemperatureUnit
+			}
+
+			steps = append(steps, stepRequest)
+		}
+	}
+
+	err = h.recipeService.RevertRecipeRevision(c.Request().Context(), recipeId, recipeFromDb.UpdatedAt, updateRecipeRequest, steps)
+	if err != nil {
+		return err
+	}
+
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully reverted a recipe", zap.String("recipeId", recipeId.String()), zap.Int32("revisionNumber", revisionNumber))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseRevisionNumber reads the number of a revision from the revisionNumber path param.
+func parseRevisionNumber(c echo.Context) (int32, error) {
+	revisionNumber, err := strconv.ParseInt(c.Param("revisionNumber"), 10, 32)
+	if err != nil || revisionNumber < 1 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received revision number is not a positive integer"})
+	}
+
+	return int32(revisionNumber), nil
+}
@@ -0,0 +1,351 @@
+package recipe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/textdiff"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// revisionCursor stores the number of the last revision returned on a page.
+type revisionCursor struct {
+	RevisionNumber int32 `json:"n"`
+}
+
+// GetRecipeRevision returns a saved state of a recipe.
+func (s *service) GetRecipeRevision(ctx context.Context, recipeId uuid.UUID, revisionNumber int32) (RevisionResponse, error) {
+	var revisionResponse RevisionResponse
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		revision, err := q.GetRecipeRevision(qCtx, sqlc.GetRecipeRevisionParams{
+			RecipeID:       recipeId,
+			RevisionNumber: revisionNumber,
+		})
+		if err != nil {
+			return err
+		}
+
+		revisionResponse, err = newRevisionResponse(revision)
+
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return RevisionResponse{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a revision with this number in the recipe"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return RevisionResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("getRecipeRevision method got uncaught error", zap.Error(err))
+			return RevisionResponse{}, err
+		}
+	}
+
+	return revisionResponse, nil
+}
+
+// ListRecipeRevisions returns a page of revisions of a recipe, starting from the newest ones,
+// and a cursor to the next page. The returned cursor is empty when there are no more revisions.
+func (s *service) ListRecipeRevisions(ctx context.Context, recipeId uuid.UUID, listRevisionsRequest ListRevisionsRequest) ([]RevisionSummaryResponse, string, error) {
+	var cursor revisionCursor
+
+	if listRevisionsRequest.Cursor != "" {
+		if err := shared.DecodeCursor(listRevisionsRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rowLimit := shared.PageLimit(listRevisionsRequest.Limit)
+
+	revisions := make([]RevisionSummaryResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListRecipeRevisions(qCtx, sqlc.ListRecipeRevisionsParams{
+			RecipeID:             recipeId,
+			CursorRevisionNumber: pgtype.Int4{Int32: cursor.RevisionNumber, Valid: cursor.RevisionNumber > 0},
+			RowLimit:             rowLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			revisions = append(revisions, RevisionSummaryResponse{
+				RevisionNumber: row.RevisionNumber,
+				Title:          row.Title,
+				CreatedAt:      row.CreatedAt.Time,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listRecipeRevisions method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return shared.Paginate(revisions, listRevisionsRequest.Limit, func(lastRevision RevisionSummaryResponse) any {
+		return revisionCursor{RevisionNumber: lastRevision.RevisionNumber}
+	})
+}
+
+// DiffRecipeRevisions compares two revisions of a recipe line by line.
+func (s *service) DiffRecipeRevisions(ctx context.Context, recipeId uuid.UUID, fromRevisionNumber, toRevisionNumber int32) (RevisionDiffResponse, error) {
+	var fromRevision, toRevision RevisionResponse
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		revision, err := q.GetRecipeRevision(qCtx, sqlc.GetRecipeRevisionParams{RecipeID: recipeId, RevisionNumber: fromRevisionNumber})
+		if err != nil {
+			return err
+		}
+
+		if fromRevision, err = newRevisionResponse(revision); err != nil {
+			return err
+		}
+
+		revision, err = q.GetRecipeRevision(qCtx, sqlc.GetRecipeRevisionParams{RecipeID: recipeId, RevisionNumber: toRevisionNumber})
+		if err != nil {
+			return err
+		}
+
+		toRevision, err = newRevisionResponse(revision)
+
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return RevisionDiffResponse{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a revision with this number in the recipe"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return RevisionDiffResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("diffRecipeRevisions method got uncaught error", zap.Error(err))
+			return RevisionDiffResponse{}, err
+		}
+	}
+
+	return diffRevisions(fromRevision, toRevision), nil
+}
+
+// RevertRecipeRevision updates a recipe to the state of a revision and replaces its steps with the steps of the revision.
+// The steps are kept when they are nil, for revisions saved before revisions kept steps.
+// Reverting is an update of its own, so it saves a new revision.
+func (s *service) RevertRecipeRevision(ctx context.Context, recipeId uuid.UUID, updatedAt time.Time, updateRecipeRequest UpdateRecipeRequest, steps []StepRequest) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		if err := updateRecipe(qCtx, q, recipeId, updatedAt, updateRecipeRequest); err != nil {
+			return err
+		}
+
+		if steps != nil {
+			if err := q.DeleteRecipeStepsByRecipeId(qCtx, recipeId); err != nil {
+				return err
+			}
+
+			if err := createSteps(qCtx, q, recipeId, steps); err != nil {
+				return err
+			}
+		}
+
+		return q.CreateRecipeRevision(qCtx, recipeId)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errRecipeModified):
+			return echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "conflict occurred when trying to update a recipe"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("revertRecipeRevision method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// diffRevisions compares every field of two revisions line by line.
+func diffRevisions(from, to RevisionResponse) RevisionDiffResponse {
+	return RevisionDiffResponse{
+		From:        from.RevisionNumber,
+		To:          to.RevisionNumber,
+		Title:       textdiff.Lines(from.Title, to.Title),
+		Content:     textdiff.Lines(from.Content, to.Content),
+		Servings:    textdiff.Lines(strconv.Itoa(int(from.Servings)), strconv.Itoa(int(to.Servings))),
+		Ingredients: textdiff.Lines(formatIngredientLines(from.Ingredients), formatIngredientLines(to.Ingredients)),
+		Steps:       textdiff.Lines(formatStepLines(from.Steps), formatStepLines(to.Steps)),
+		Tags:        textdiff.Lines(strings.Join(from.Tags, "\n"), strings.Join(to.Tags, "\n")),
+		Visibility:  textdiff.Lines(from.Visibility, to.Visibility),
+	}
+}
+
+// formatIngredientLines writes every ingredient on its own line, like "200 g dark chocolate, finely chopped".
+func formatIngredientLines(ingredients []IngredientResponse) string {
+	lines := make([]string, 0, len(ingredients))
+
+	for _, ingredient := range ingredients {
+		words := make([]string, 0, 3)
+
+		if ingredient.QuantityText != "" {
+			words = append(words, ingredient.QuantityText)
+		}
+
+		if ingredient.Unit != "" {
+			words = append(words, ingredient.Unit)
+		}
+
+		line := strings.Join(append(words, ingredient.Name), " ")
+
+		if ingredient.Note != "" {
+			line += ", " + ingredient.Note
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatStepLines writes every step on its own line, like "2. Bake until the edges are golden. (12 min, 180 C)".
+func formatStepLines(steps []RevisionStepResponse) string {
+	lines := make([]string, 0, len(steps))
+
+	for _, step := range steps {
+		line := fmt.Sprintf("%d. %s", step.Position, step.Instruction)
+
+		details := make([]string, 0, 2)
+
+		if step.DurationMinutes != nil {
+			details = append(details, fmt.Sprintf("%d min", *step.DurationMinutes))
+		}
+
+		if step.Temperature != nil && step.TemperatureUnit != nil {
+			details = append(details, fmt.Sprintf("%d %s", *step.Temperature, *step.TemperatureUnit))
+		}
+
+		if len(details) > 0 {
+			line += " (" + strings.Join(details, ", ") + ")"
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// newRevisionResponse decodes the ingredients and steps a revision stores as JSON.
+func newRevisionResponse(revision sqlc.RecipeRevision) (RevisionResponse, error) {
+	var ingredients []IngredientRequest
+
+	if err := json.Unmarshal(revision.Ingredients, &ingredients); err != nil {
+		return RevisionResponse{}, errors.Join(errors.New("failed to decode ingredients of a revision"), err)
+	}
+
+	// Revisions saved before revisions kept steps have no steps at all, which is not the same as an empty list.
+	var steps []RevisionStepResponse
+
+	if revision.Steps != nil {
+		var stepsFromRevision []StepRequest
+
+		if err := json.Unmarshal(revision.Steps, &stepsFromRevision); err != nil {
+			return RevisionResponse{}, errors.Join(errors.New("failed to decode steps of a revision"), err)
+		}
+
+		steps = make([]RevisionStepResponse, 0, len(stepsFromRevision))
+
+		for i, step := range stepsFromRevision {
+			revisionStep := RevisionStepResponse{
+				Position:        int32(i + 1),
+				Instruction:     step.Instruction,
+				DurationMinutes: step.DurationMinutes,
+				Temperature:     step.Temperature,
+			}
+
+			if step.TemperatureUnit != "" {
+				revisionStep.TemperatureUnit = &step.TemperatureUnit
+			}
+
+			steps = append(steps, revisionStep)
+		}
+	}
+
+	ingredientsFromRevision := make([]sqlc.RecipeIngredient, 0, len(ingredients))
+
+	for i, ingredient := range ingredients {
+		ingredientsFromRevision = append(ingredientsFromRevision, sqlc.RecipeIngredient{
+			RecipeID: revision.RecipeID,
+			Position: int32(i + 1),
+			Quantity: toPgFloat8(ingredient.Quantity),
+			Unit:     ingredient.Unit,
+			Name:     ingredient.Name,
+			Note:     ingredient.Note,
+		})
+	}
+
+	tags := revision.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return RevisionResponse{
+		RevisionNumber: revision.RevisionNumber,
+		Title:          revision.Title,
+		Content:        revision.Content,
+		Servings:       revision.Servings,
+		Ingredients:    newIngredientResponses(ingredientsFromRevision),
+		Steps:          steps,
+		Tags:           tags,
+		Visibility:     revision.Visibility.String,
+		CreatedAt:      revision.CreatedAt.Time,
+	}, nil
+}
@@ -1,13 +1,59 @@
 package recipe
 
 import (
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
 	"github.com/labstack/echo/v4"
 )
 
 // RegisterRoutes sets endpoints for Recipe resource.
+// Routes that modify recipes are available only to signed-in users,
+// and publishing recipes, reviewing and commenting only to users with verified emails.
 func (h *handler) RegisterRoutes(e *echo.Echo) {
-	e.POST("api/v1/recipes", h.CreateRecipe)
+	e.GET("api/v1/recipes", h.ListRecipes)
+	e.GET("api/v1/recipes/search", h.SearchRecipes)
+	e.GET("api/v1/recipes/compare", h.CompareRecipes)
+	e.POST("api/v1/recipes", h.CreateRecipe, session.RequireAuth)
 	e.GET("api/v1/recipes/:id", h.GetRecipeById)
-	e.PUT("api/v1/recipes/:id", h.UpdateRecipeById)
-	e.DELETE("api/v1/recipes/:id", h.DeleteRecipeById)
+	e.PUT("api/v1/recipes/:id", h.UpdateRecipeById, session.RequireAuth)
+	e.DELETE("api/v1/recipes/:id", h.DeleteRecipeById, session.RequireAuth)
+	e.POST("api/v1/recipes/:id/restore", h.RestoreRecipe, session.RequireAuth)
+	e.POST("api/v1/recipes/:id/publish", h.PublishRecipe, session.RequireVerifiedEmail)
+
+	e.POST("api/v1/recipes/:id/images", h.UploadRecipeImage, session.RequireAuth)
+
+	e.GET("api/v1/recipes/:id/reviews", h.ListRecipeReviews)
+	e.POST("api/v1/recipes/:id/reviews", h.SaveRecipeReview, session.RequireVerifiedEmail)
+
+	e.GET("api/v1/recipes/:id/revisions", h.ListRecipeRevisions, session.RequireAuth)
+	e.GET("api/v1/recipes/:id/revisions/diff", h.DiffRecipeRevisions, session.RequireAuth)
+	e.GET("api/v1/recipes/:id/revisions/:revisionNumber", h.GetRecipeRevision, session.RequireAuth)
+	e.POST("api/v1/recipes/:id/revisions/:revisionNumber/revert", h.RevertRecipeRevision, session.RequireAuth)
+
+	e.GET("api/v1/recipes/:id/share-links", h.ListShareLinks, session.RequireAuth)
+	e.POST("api/v1/recipes/:id/share-links", h.CreateShareLink, session.RequireAuth)
+	e.DELETE("api/v1/recipes/:id/share-links/:shareLinkId", h.RevokeShareLink, session.RequireAuth)
+	e.GET("api/v1/shared/:token", h.GetSharedRecipe)
+
+	e.POST("api/v1/recipes/:id/fork", h.ForkRecipe, session.RequireAuth)
+	e.GET("api/v1/recipes/:id/forks", h.ListRecipeForks)
+
+	e.GET("api/v1/recipes/:id/comments", h.ListComments)
+	e.POST("api/v1/recipes/:id/comments", h.CreateComment, session.RequireVerifiedEmail)
+	e.GET("api/v1/recipes/:id/comments/:commentId/replies", h.ListCommentReplies)
+	e.PUT("api/v1/recipes/:id/comments/:commentId", h.UpdateComment, session.RequireVerifiedEmail)
+	e.DELETE("api/v1/recipes/:id/comments/:commentId", h.DeleteComment, session.RequireAuth)
+
+	e.GET("api/v1/tags", h.ListTags)
+
+	e.GET("api/v1/me/favorites", h.ListFavoriteRecipes, session.RequireAuth)
+	e.PUT("api/v1/me/favorites/:recipeId", h.AddFavoriteRecipe, session.RequireAuth)
+	e.DELETE("api/v1/me/favorites/:recipeId", h.RemoveFavoriteRecipe, session.RequireAuth)
+
+	e.GET("api/v1/me/recipes", h.ListOwnRecipes, session.RequireAuth)
+	e.GET("api/v1/me/trash", h.ListDeletedRecipes, session.RequireAuth)
+
+	e.GET("api/v1/recipes/:id/steps", h.GetRecipeSteps)
+	e.POST("api/v1/recipes/:id/steps", h.CreateRecipeStep, session.RequireAuth)
+	e.PUT("api/v1/recipes/:id/steps/order", h.ReorderRecipeSteps, session.RequireAuth)
+	e.DELETE("api/v1/recipes/:id/steps/:stepId", h.DeleteRecipeStep, session.RequireAuth)
 }
@@ -0,0 +1,65 @@
+package recipe
+
+import (
+	"math"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/units"
+)
+
+// scaleRecipe returns the recipe with the quantities of ingredients scaled from its base servings to the given servings.
+// Quantities in recognized units are normalized, so e.g. 48 tsp becomes 1 cup.
+// A recipe without base servings, e.g. one cached before servings were added, is returned unscaled.
+func scaleRecipe(recipe RecipeResponse, servings int32) RecipeResponse {
+	if recipe.Servings <= 0 {
+		return recipe
+	}
+
+	ratio := float64(servings) / float64(recipe.Servings)
+
+	scaledIngredients := make([]IngredientResponse, 0, len(recipe.Ingredients))
+
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient.Quantity == nil {
+			scaledIngredients = append(scaledIngredients, ingredient)
+			continue
+		}
+
+		quantity := *ingredient.Quantity * ratio
+
+		if unit, ok := units.Parse(ingredient.Unit); ok {
+			normalizedQuantity := units.Quantity{Amount: quantity, Unit: unit}.Normalize()
+
+			quantity = normalizedQuantity.Amount
+			ingredient.Unit = normalizedQuantity.Unit.Name(quantity)
+		}
+
+		quantity = roundQuantity(quantity)
+
+		ingredient.Quantity = &quantity
+		ingredient.QuantityText = formatQuantity(quantity, ingredient.Unit)
+
+		scaledIngredients = append(scaledIngredients, ingredient)
+	}
+
+	recipe.Servings = servings
+	recipe.Ingredients = scaledIngredients
+
+	return recipe
+}
+
+// formatQuantity writes the quantity in the style of its unit's measurement system.
+// Quantities without a recognized unit, like "2 eggs", are written with fractions.
+func formatQuantity(quantity float64, unitName string) string {
+	system := units.USCustomary
+
+	if unit, ok := units.Parse(unitName); ok {
+		system = unit.System
+	}
+
+	return units.FormatAmount(quantity, system)
+}
+
+// roundQuantity drops the noise of floating point arithmetic from a converted quantity.
+func roundQuantity(quantity float64) float64 {
+	return math.Round(quantity*1000) / 1000
+}
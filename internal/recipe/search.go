@@ -0,0 +1,42 @@
+package recipe
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// highlightMarkers are put by ts_headline around the matched words of a recipe.
+// They are random for every search, so the text of a recipe cannot contain them,
+// and the text can be HTML-escaped before the markers are replaced with <mark> tags.
+type highlightMarkers struct {
+	start string
+	stop  string
+}
+
+func newHighlightMarkers() (highlightMarkers, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return highlightMarkers{}, errors.Join(errors.New("failed to generate highlight markers"), err)
+	}
+
+	token := strings.ReplaceAll(id.String(), "-", "")
+
+	return highlightMarkers{
+		start: "hlstart" + token,
+		stop:  "hlstop" + token,
+	}, nil
+}
+
+// headlineOptions returns the options of ts_headline with the markers and the given options added.
+func (m highlightMarkers) headlineOptions(options string) string {
+	return fmt.Sprintf("StartSel=%s, StopSel=%s, %s", m.start, m.stop, options)
+}
+
+// toHTML escapes a headline and wraps its matched words in <mark> tags.
+func (m highlightMarkers) toHTML(headline string) string {
+	return strings.NewReplacer(m.start, "<mark>", m.stop, "</mark>").Replace(html.EscapeString(headline))
+}
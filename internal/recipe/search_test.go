@@ -0,0 +1,47 @@
+package recipe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightMarkersToHTML(t *testing.T) {
+	markers, err := newHighlightMarkers()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		headline string
+		wantHTML string
+	}{
+		{
+			name:     "no matched words",
+			headline: "Chocolate Cookies",
+			wantHTML: "Chocolate Cookies",
+		},
+		{
+			name:     "matched word",
+			headline: markers.start + "Chocolate" + markers.stop + " Cookies",
+			wantHTML: "<mark>Chocolate</mark> Cookies",
+		},
+		{
+			name:     "HTML in the text of a recipe",
+			headline: `<img src=x onerror="alert(1)"> ` + markers.start + "Cookies" + markers.stop + " & <mark>milk</mark>",
+			wantHTML: "&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>Cookies</mark> &amp; &lt;mark&gt;milk&lt;/mark&gt;",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			html := markers.toHTML(tc.headline)
+
+			// then
+			assert.Equal(t, tc.wantHTML, html)
+		})
+	}
+}
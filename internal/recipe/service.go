@@ -3,7 +3,9 @@ package recipe
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
@@ -19,15 +21,29 @@ import (
 const queryExecutionTimeout = 3 * time.Second
 const acquireConnectionTimeout = 3 * time.Second
 
+// errRecipeModified is returned when a recipe has been modified by someone else since it was read.
+var errRecipeModified = errors.New("recipe has been modified since it was read")
+
 type service struct {
-	logger *zap.Logger
-	dbpool *pgxpool.Pool
+	logger    *zap.Logger
+	dbpool    *pgxpool.Pool
+	blobStore blobStore
+	// trashRetention is how long deleted recipes are kept in the trash before they are purged.
+	trashRetention time.Duration
+}
+
+type blobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
 }
 
-func NewService(logger *zap.Logger, dbpool *pgxpool.Pool) *service {
+func NewService(logger *zap.Logger, dbpool *pgxpool.Pool, blobStore blobStore, trashRetention time.Duration) *service {
 	return &service{
-		logger: logger,
-		dbpool: dbpool,
+		logger:         logger,
+		dbpool:         dbpool,
+		blobStore:      blobStore,
+		trashRetention: trashRetention,
 	}
 }
 
@@ -45,11 +61,49 @@ func (s *service) GetRecipeById(ctx context.Context, recipeId uuid.UUID) (Recipe
 			return err
 		}
 
+		ingredientsFromDb, err := q.GetRecipeIngredientsByRecipeId(dbCtx, recipeId)
+		if err != nil {
+			return err
+		}
+
+		stepsFromDb, err := q.GetRecipeStepsByRecipeId(dbCtx, recipeId)
+		if err != nil {
+			return err
+		}
+
+		tagsFromDb, err := q.GetTagNamesByRecipeId(dbCtx, recipeId)
+		if err != nil {
+			return err
+		}
+
+		if tagsFromDb == nil {
+			tagsFromDb = []string{}
+		}
+
+		imagesFromDb, err := q.GetRecipeImagesByRecipeId(dbCtx, recipeId)
+		if err != nil {
+			return err
+		}
+
 		recipeResponse = RecipeResponse{
-			Title:     recipeFromDb.Title,
-			Content:   recipeFromDb.Content,
-			CreatedAt: recipeFromDb.CreatedAt.Time,
-			UpdatedAt: recipeFromDb.UpdatedAt.Time,
+			UserID:        recipeFromDb.UserID,
+			Title:         recipeFromDb.Title,
+			Content:       recipeFromDb.Content,
+			Servings:      recipeFromDb.Servings,
+			Ingredients:   newIngredientResponses(ingredientsFromDb),
+			Steps:         newStepResponses(stepsFromDb),
+			Tags:          tagsFromDb,
+			Images:        s.newImageResponses(imagesFromDb),
+			AverageRating: averageRating(recipeFromDb.RatingSum, recipeFromDb.RatingCount),
+			RatingCount:   recipeFromDb.RatingCount,
+			FavoriteCount: recipeFromDb.FavoriteCount,
+			ForkCount:     recipeFromDb.ForkCount,
+			ForkedFrom:    newForkAttributionResponse(recipeFromDb),
+			Status:        recipeFromDb.Status,
+			PublishAt:     fromPgTimestamp(recipeFromDb.PublishAt),
+			Visibility:    recipeFromDb.Visibility,
+			CreatedAt:     recipeFromDb.CreatedAt.Time,
+			UpdatedAt:     recipeFromDb.UpdatedAt.Time,
 		}
 
 		return err
@@ -69,6 +123,8 @@ func (s *service) GetRecipeById(ctx context.Context, recipeId uuid.UUID) (Recipe
 	return recipeResponse, nil
 }
 
+// DeleteRecipeById moves a recipe to the trash of its owner. The recipe is hidden from every read,
+// but it keeps its ingredients, images, reviews and places in cookbooks until it is purged.
 func (s *service) DeleteRecipeById(ctx context.Context, recipeID uuid.UUID) error {
 	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
 	defer cancelConnCtx()
@@ -79,7 +135,16 @@ func (s *service) DeleteRecipeById(ctx context.Context, recipeID uuid.UUID) erro
 		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
 		defer cancelQCtx()
 
-		return q.DeleteRecipeById(qCtx, recipeID)
+		deletedRows, err := q.SoftDeleteRecipeById(qCtx, recipeID)
+		if err != nil {
+			return err
+		}
+
+		if deletedRows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
 	})
 	if err != nil {
 		switch {
@@ -96,7 +161,7 @@ func (s *service) DeleteRecipeById(ctx context.Context, recipeID uuid.UUID) erro
 	return nil
 }
 
-func (s *service) CreateNewRecipe(ctx context.Context, newRecipeRequest NewRecipeRequest) (uuid.UUID, error) {
+func (s *service) CreateNewRecipe(ctx context.Context, userId uuid.UUID, newRecipeRequest NewRecipeRequest) (uuid.UUID, error) {
 	var id uuid.UUID
 
 	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
@@ -107,21 +172,40 @@ func (s *service) CreateNewRecipe(ctx context.Context, newRecipeRequest NewRecip
 		return id, errors.Join(errors.New("failed to generate UUID"), err)
 	}
 
-	err = s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+	err = pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
 		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
 		defer cancelQCtx()
 
-		q := sqlc.New(c)
+		q := sqlc.New(tx)
 
 		id, err = q.CreateRecipe(
 			qCtx,
 			sqlc.CreateRecipeParams{
-				RecipeID: id,
-				Title:    newRecipeRequest.Title,
-				Content:  newRecipeRequest.Content,
+				RecipeID:   id,
+				Title:      newRecipeRequest.Title,
+				Content:    newRecipeRequest.Content,
+				UserID:     userId,
+				Servings:   newRecipeRequest.Servings,
+				Visibility: newRecipeRequest.Visibility,
 			},
 		)
-		return err
+		if err != nil {
+			return err
+		}
+
+		if err := createIngredients(qCtx, q, id, newRecipeRequest.Ingredients); err != nil {
+			return err
+		}
+
+		if err := createSteps(qCtx, q, id, newRecipeRequest.Steps); err != nil {
+			return err
+		}
+
+		if err := setRecipeTags(qCtx, q, id, normalizeTagNames(newRecipeRequest.Tags)); err != nil {
+			return err
+		}
+
+		return q.CreateRecipeRevision(qCtx, id)
 	})
 	if err != nil {
 		switch {
@@ -140,25 +224,48 @@ func (s *service) UpdateRecipeById(ctx context.Context, id uuid.UUID, updatedAt
 	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
 	defer cancelConnCtx()
 
-	tx, err := s.dbpool.Begin(connCtx)
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		if err := updateRecipe(qCtx, q, id, updatedAt, updateRecipeRequest); err != nil {
+			return err
+		}
+
+		// Keep the updated state as a new revision, so the update can be reverted later.
+		return q.CreateRecipeRevision(qCtx, id)
+	})
 	if err != nil {
-		return err
+		switch {
+		case errors.Is(err, errRecipeModified):
+			return echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "conflict occurred when trying to update a recipe"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("updateRecipeById method got uncaught error", zap.Error(err))
+			return err
+		}
 	}
 
-	q := sqlc.New(tx)
-
-	qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
-	defer cancelQCtx()
+	return nil
+}
 
-	err = q.UpdateRecipeById(qCtx, sqlc.UpdateRecipeByIdParams{
+// updateRecipe replaces the fields, ingredients and tags of a recipe,
+// if the recipe has not been modified since updatedAt. It returns errRecipeModified otherwise.
+func updateRecipe(ctx context.Context, q *sqlc.Queries, id uuid.UUID, updatedAt time.Time, updateRecipeRequest UpdateRecipeRequest) error {
+	updatedRows, err := q.UpdateRecipeById(ctx, sqlc.UpdateRecipeByIdParams{
 		RecipeID: id,
 		UpdatedAt: pgtype.Timestamp{
 			Time:             updatedAt,
 			InfinityModifier: pgtype.Finite,
 			Valid:            true,
 		},
-		Title:   updateRecipeRequest.Title,
-		Content: updateRecipeRequest.Content,
+		Title:      updateRecipeRequest.Title,
+		Content:    updateRecipeRequest.Content,
+		Servings:   updateRecipeRequest.Servings,
+		Visibility: updateRecipeRequest.Visibility,
 		NewUpdatedAt: pgtype.Timestamp{
 			Time:             time.Now(),
 			InfinityModifier: pgtype.Finite,
@@ -166,16 +273,364 @@ func (s *service) UpdateRecipeById(ctx context.Context, id uuid.UUID, updatedAt
 		},
 	})
 	if err != nil {
-		_ = tx.Rollback(ctx)
+		return err
+	}
+
+	if updatedRows == 0 {
+		return errRecipeModified
+	}
+
+	if err := q.DeleteRecipeIngredientsByRecipeId(ctx, id); err != nil {
+		return err
+	}
+
+	if err := createIngredients(ctx, q, id, updateRecipeRequest.Ingredients); err != nil {
+		return err
+	}
+
+	if err := q.DeleteRecipeTagsByRecipeId(ctx, id); err != nil {
+		return err
+	}
+
+	return setRecipeTags(ctx, q, id, normalizeTagNames(updateRecipeRequest.Tags))
+}
+
+// createIngredients saves the ingredients of a recipe in the order they were provided.
+func createIngredients(ctx context.Context, q *sqlc.Queries, recipeId uuid.UUID, ingredients []IngredientRequest) error {
+	for i, ingredient := range ingredients {
+		err := q.CreateRecipeIngredient(ctx, sqlc.CreateRecipeIngredientParams{
+			RecipeID: recipeId,
+			Position: int32(i + 1),
+			Quantity: toPgFloat8(ingredient.Quantity),
+			Unit:     ingredient.Unit,
+			Name:     ingredient.Name,
+			Note:     ingredient.Note,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newIngredientResponses(ingredientsFromDb []sqlc.RecipeIngredient) []IngredientResponse {
+	ingredients := make([]IngredientResponse, 0, len(ingredientsFromDb))
+
+	for _, ingredient := range ingredientsFromDb {
+		ingredientResponse := IngredientResponse{
+			Unit: ingredient.Unit,
+			Name: ingredient.Name,
+			Note: ingredient.Note,
+		}
+
+		if ingredient.Quantity.Valid {
+			ingredientResponse.Quantity = &ingredient.Quantity.Float64
+			ingredientResponse.QuantityText = formatQuantity(ingredient.Quantity.Float64, ingredient.Unit)
+		}
+
+		ingredients = append(ingredients, ingredientResponse)
+	}
+
+	return ingredients
+}
+
+const (
+	defaultListLimit = 20
+
+	sortByCreatedAt = "created_at"
+	sortByUpdatedAt = "updated_at"
+	sortByTitle     = "title"
+	sortByFavorites = "favorite_count"
+)
 
+// listCursor stores the sort key and ID of the last recipe returned on a page.
+type listCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// ListRecipes returns a page of recipes and a cursor to the next page.
+// The returned cursor is empty when there are no more recipes to fetch.
+func (s *service) ListRecipes(ctx context.Context, listRecipesRequest ListRecipesRequest) ([]RecipeSummaryResponse, string, error) {
+	cursor := listCursor{Sort: listRecipesRequest.Sort}
+
+	if listRecipesRequest.Cursor != "" {
+		if err := shared.DecodeCursor(listRecipesRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+
+		if cursor.Sort != listRecipesRequest.Sort {
+			return nil, "", echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received cursor does not match the sort order"})
+		}
+	}
+
+	var cursorTime time.Time
+	var cursorFavoriteCount pgtype.Int4
+
+	if cursor.ID != uuid.Nil {
+		switch cursor.Sort {
+		case sortByTitle:
+			// The cursor value is the title itself.
+		case sortByFavorites:
+			parsedCount, err := strconv.ParseInt(cursor.Value, 10, 32)
+			if err != nil {
+				return nil, "", echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received cursor is not valid"})
+			}
+
+			cursorFavoriteCount = pgtype.Int4{Int32: int32(parsedCount), Valid: true}
+		default:
+			parsedTime, err := time.Parse(time.RFC3339Nano, cursor.Value)
+			if err != nil {
+				return nil, "", echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received cursor is not valid"})
+			}
+
+			cursorTime = parsedTime
+		}
+	}
+
+	authorId := toPgUUID(listRecipesRequest.AuthorID)
+	createdAfter := toPgTimestamp(listRecipesRequest.CreatedAfter)
+	createdBefore := toPgTimestamp(listRecipesRequest.CreatedBefore)
+	cursorId := toPgUUID(cursor.ID)
+	tags := normalizeTagNames(listRecipesRequest.Tags)
+	matchAllTags := listRecipesRequest.TagMatch == tagMatchAll
+
+	rowLimit := shared.PageLimit(listRecipesRequest.Limit)
+
+	recipes := make([]RecipeSummaryResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		switch listRecipesRequest.Sort {
+		case sortByUpdatedAt:
+			rows, err := q.ListRecipesByUpdatedAt(qCtx, sqlc.ListRecipesByUpdatedAtParams{
+				AuthorID:        authorId,
+				CreatedAfter:    createdAfter,
+				CreatedBefore:   createdBefore,
+				Tags:            tags,
+				MatchAllTags:    matchAllTags,
+				CursorID:        cursorId,
+				CursorUpdatedAt: toPgTimestamp(cursorTime),
+				RowLimit:        rowLimit,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, row := range rows {
+				recipes = append(recipes, newRecipeSummaryResponse(row.RecipeID, row.UserID, row.Title, row.FavoriteCount, row.CreatedAt, row.UpdatedAt))
+			}
+		case sortByTitle:
+			rows, err := q.ListRecipesByTitle(qCtx, sqlc.ListRecipesByTitleParams{
+				AuthorID:      authorId,
+				CreatedAfter:  createdAfter,
+				CreatedBefore: createdBefore,
+				Tags:          tags,
+				MatchAllTags:  matchAllTags,
+				CursorID:      cursorId,
+				CursorTitle:   pgtype.Text{String: cursor.Value, Valid: cursor.ID != uuid.Nil},
+				RowLimit:      rowLimit,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, row := range rows {
+				recipes = append(recipes, newRecipeSummaryResponse(row.RecipeID, row.UserID, row.Title, row.FavoriteCount, row.CreatedAt, row.UpdatedAt))
+			}
+		case sortByFavorites:
+			rows, err := q.ListRecipesByFavoriteCount(qCtx, sqlc.ListRecipesByFavoriteCountParams{
+				AuthorID:            authorId,
+				CreatedAfter:        createdAfter,
+				CreatedBefore:       createdBefore,
+				Tags:                tags,
+				MatchAllTags:        matchAllTags,
+				CursorID:            cursorId,
+				CursorFavoriteCount: cursorFavoriteCount,
+				RowLimit:            rowLimit,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, row := range rows {
+				recipes = append(recipes, newRecipeSummaryResponse(row.RecipeID, row.UserID, row.Title, row.FavoriteCount, row.CreatedAt, row.UpdatedAt))
+			}
+		default:
+			rows, err := q.ListRecipesByCreatedAt(qCtx, sqlc.ListRecipesByCreatedAtParams{
+				AuthorID:        authorId,
+				CreatedAfter:    createdAfter,
+				CreatedBefore:   createdBefore,
+				Tags:            tags,
+				MatchAllTags:    matchAllTags,
+				CursorID:        cursorId,
+				CursorCreatedAt: toPgTimestamp(cursorTime),
+				RowLimit:        rowLimit,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, row := range rows {
+				recipes = append(recipes, newRecipeSummaryResponse(row.RecipeID, row.UserID, row.Title, row.FavoriteCount, row.CreatedAt, row.UpdatedAt))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
 		switch {
-		case errors.Is(err, pgx.ErrNoRows):
-			return echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "conflict occurred when trying to update a recipe"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
 		default:
-			s.logger.Error("updateRecipeById method got uncaught error", zap.Error(err))
+			s.logger.Error("listRecipes method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return shared.Paginate(recipes, listRecipesRequest.Limit, func(lastRecipe RecipeSummaryResponse) any {
+		nextCursor := listCursor{Sort: listRecipesRequest.Sort, ID: lastRecipe.RecipeID}
+
+		switch listRecipesRequest.Sort {
+		case sortByUpdatedAt:
+			nextCursor.Value = lastRecipe.UpdatedAt.Format(time.RFC3339Nano)
+		case sortByTitle:
+			nextCursor.Value = lastRecipe.Title
+		case sortByFavorites:
+			nextCursor.Value = strconv.Itoa(int(lastRecipe.FavoriteCount))
+		default:
+			nextCursor.Value = lastRecipe.CreatedAt.Format(time.RFC3339Nano)
+		}
+
+		return nextCursor
+	})
+}
+
+func newRecipeSummaryResponse(recipeId, userId uuid.UUID, title string, favoriteCount int32, createdAt, updatedAt pgtype.Timestamp) RecipeSummaryResponse {
+	return RecipeSummaryResponse{
+		RecipeID:      recipeId,
+		UserID:        userId,
+		Title:         title,
+		FavoriteCount: favoriteCount,
+		CreatedAt:     createdAt.Time,
+		UpdatedAt:     updatedAt.Time,
+	}
+}
+
+// toPgUUID converts the UUID to a nullable database value, treating uuid.Nil as NULL.
+func toPgUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: id != uuid.Nil}
+}
+
+// toPgTimestamp converts the time to a nullable database value, treating the zero time as NULL.
+func toPgTimestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{
+		Time:             t.UTC(),
+		InfinityModifier: pgtype.Finite,
+		Valid:            !t.IsZero(),
+	}
+}
+
+// fromPgTimestamp converts a nullable database value to a time, treating NULL as nil.
+func fromPgTimestamp(t pgtype.Timestamp) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+// searchCursor stores the rank and ID of the last recipe returned on a page of search results.
+type searchCursor struct {
+	Rank float64   `json:"r"`
+	ID   uuid.UUID `json:"id"`
+}
+
+// SearchRecipes returns a page of recipes matching the search query, ordered by relevance,
+// and a cursor to the next page. The returned cursor is empty when there are no more results.
+func (s *service) SearchRecipes(ctx context.Context, searchRecipesRequest SearchRecipesRequest) ([]RecipeSearchResultResponse, string, error) {
+	var cursor searchCursor
+
+	if searchRecipesRequest.Cursor != "" {
+		if err := shared.DecodeCursor(searchRecipesRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	markers, err := newHighlightMarkers()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rowLimit := shared.PageLimit(searchRecipesRequest.Limit)
+
+	results := make([]RecipeSearchResultResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.SearchRecipes(qCtx, sqlc.SearchRecipesParams{
+			Query:                  searchRecipesRequest.Query,
+			CursorID:               toPgUUID(cursor.ID),
+			CursorRank:             pgtype.Float8{Float64: cursor.Rank, Valid: cursor.ID != uuid.Nil},
+			RowLimit:               rowLimit,
+			TitleHeadlineOptions:   markers.headlineOptions("HighlightAll=true"),
+			ContentHeadlineOptions: markers.headlineOptions("MaxFragments=2, MaxWords=25, MinWords=10"),
+		})
+		if err != nil {
 			return err
 		}
+
+		for _, row := range rows {
+			results = append(results, RecipeSearchResultResponse{
+				RecipeID:       row.RecipeID,
+				UserID:         row.UserID,
+				Title:          row.Title,
+				TitleHighlight: markers.toHTML(row.TitleHeadline),
+				Snippet:        markers.toHTML(row.ContentHeadline),
+				Rank:           row.Rank,
+				CreatedAt:      row.CreatedAt.Time,
+				UpdatedAt:      row.UpdatedAt.Time,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("searchRecipes method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return shared.Paginate(results, searchRecipesRequest.Limit, func(lastResult RecipeSearchResultResponse) any {
+		return searchCursor{Rank: lastResult.Rank, ID: lastResult.RecipeID}
+	})
+}
+
+// toPgFloat8 converts the number to a nullable database value, treating nil as NULL.
+func toPgFloat8(f *float64) pgtype.Float8 {
+	if f == nil {
+		return pgtype.Float8{}
 	}
 
-	return tx.Commit(ctx)
+	return pgtype.Float8{Float64: *f, Valid: true}
 }
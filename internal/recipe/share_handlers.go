@@ -0,0 +1,184 @@
+package recipe
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// CreateShareLink godoc
+//
+//	@Summary		Create a share link
+//	@Description	Create a link which lets anyone read the recipe without an account, even a draft or a private recipe.
+//	@Description	The token is returned only once, save it to share the link. Pass expires_at to create a link which stops working after that time.
+//	@Tags			share links
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string													true	"UUID of a recipe."
+//	@Param			ShareLinkRequest	body		recipe.ShareLinkRequest									false	"Optional request body with an expiration time."
+//
+//	@Success		201					{object}	shared.DataResponse[recipe.CreatedShareLinkResponse]	"Share link created successfully."
+//	@Failure		400					{object}	shared.CommonResponse									"Invalid data provided."
+//	@Failure		401					{object}	shared.CommonResponse									"User is not signed in."
+//	@Failure		403					{object}	shared.CommonResponse									"User is not the owner of the recipe."
+//	@Failure		404					{object}	shared.CommonResponse									"Recipe not found."
+//
+//	@Router			/api/v1/recipes/{id}/share-links [POST]
+func (h *handler) CreateShareLink(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestBody = ShareLinkRequest{}
+
+	// The body is optional, a link without one never expires.
+	if c.Request().ContentLength != 0 {
+		if err := shared.ValidateJSONContentType(c); err != nil {
+			return err
+		}
+
+		if err := c.Bind(&requestBody); err != nil {
+			return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+		}
+	}
+
+	var expiresAt time.Time
+
+	if requestBody.ExpiresAt != nil {
+		if !requestBody.ExpiresAt.After(time.Now()) {
+			return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the expiration time of a share link must be in the future"})
+		}
+
+		expiresAt = requestBody.ExpiresAt.UTC()
+	}
+
+	if _, err := h.getOwnedRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	shareLink, err := h.recipeService.CreateShareLink(c.Request().Context(), recipeId, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully created a share link for a recipe", zap.String("recipeId", recipeId.String()), zap.String("shareLinkId", shareLink.ShareLinkID.String()))
+
+	c.Response().Header().Add("Location", shareLink.URL)
+	return c.JSON(http.StatusCreated, shared.DataResponse[CreatedShareLinkResponse]{Data: shareLink})
+}
+
+// ListShareLinks godoc
+//
+//	@Summary		List share links of a recipe
+//	@Description	Get every share link of a recipe, starting from the newest ones, with the number of views and the time of the last view.
+//	@Description	Revoked and expired links are listed too. The tokens are not stored, so they are not returned.
+//	@Tags			share links
+//
+//	@Produce		json
+//	@Param			id	path		string											true	"UUID of a recipe."
+//
+//	@Success		200	{object}	shared.DataResponse[[]recipe.ShareLinkResponse]	"Share links fetched successfully."
+//	@Failure		400	{object}	shared.CommonResponse							"Invalid data provided."
+//	@Failure		401	{object}	shared.CommonResponse							"User is not signed in."
+//	@Failure		403	{object}	shared.CommonResponse							"User is not the owner of the recipe."
+//	@Failure		404	{object}	shared.CommonResponse							"Recipe not found."
+//
+//	@Router			/api/v1/recipes/{id}/share-links [GET]
+func (h *handler) ListShareLinks(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.getOwnedRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	shareLinks, err := h.recipeService.ListShareLinks(c.Request().Context(), recipeId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[[]ShareLinkResponse]{Data: shareLinks})
+}
+
+// RevokeShareLink godoc
+//
+//	@Summary		Revoke a share link
+//	@Description	Stop a share link of a recipe from working. A revoked link stays on the list of links with its views.
+//	@Tags			share links
+//
+//	@Produce		json
+//	@Param			id			path	string	true	"UUID of a recipe."
+//	@Param			shareLinkId	path	string	true	"UUID of a share link."
+//
+//	@Success		204			"Share link revoked successfully."
+//	@Failure		400			{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401			{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		403			{object}	shared.CommonResponse	"User is not the owner of the recipe."
+//	@Failure		404			{object}	shared.CommonResponse	"Recipe or share link not found."
+//
+//	@Router			/api/v1/recipes/{id}/share-links/{shareLinkId} [DELETE]
+func (h *handler) RevokeShareLink(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	shareLinkId, err := uuid.Parse(c.Param("shareLinkId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received share link ID is not a valid UUID"})
+	}
+
+	if _, err := h.getOwnedRecipe(c, recipeId); err != nil {
+		return err
+	}
+
+	if err := h.recipeService.RevokeShareLink(c.Request().Context(), recipeId, shareLinkId); err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully revoked a share link of a recipe", zap.String("recipeId", recipeId.String()), zap.String("shareLinkId", shareLinkId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetSharedRecipe godoc
+//
+//	@Summary		Get a shared recipe
+//	@Description	Get the recipe a share link points to. Share links work without an account and give access to drafts and private recipes too.
+//	@Description	Every request is counted as a view of the link.
+//	@Tags			share links
+//
+//	@Produce		json
+//	@Param			token	path		string										true	"Token of a share link."
+//
+//	@Success		200		{object}	shared.DataResponse[recipe.RecipeResponse]	"Recipe fetched successfully."
+//	@Failure		404		{object}	shared.CommonResponse						"Share link is not valid, has expired or has been revoked."
+//
+//	@Router			/api/v1/shared/{token} [GET]
+func (h *handler) GetSharedRecipe(c echo.Context) error {
+	recipeId, err := h.recipeService.UseShareLink(c.Request().Context(), c.Param("token"))
+	if err != nil {
+		return err
+	}
+
+	// A share link grants access on its own, so the visibility of the recipe is not checked.
+	recipe, err := h.getRecipe(c.Request().Context(), recipeId)
+	if err != nil {
+		return err
+	}
+
+	if system, ok := measurementSystems[session.FromContext(c).MeasurementSystem]; ok {
+		recipe = convertRecipe(recipe, system)
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[RecipeResponse]{Data: recipe})
+}
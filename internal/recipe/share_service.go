@@ -0,0 +1,192 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// sharedRecipePath is the path of the endpoint which serves recipes by the tokens of their share links.
+const sharedRecipePath = "/api/v1/shared/"
+
+// CreateShareLink creates a link which grants read access to a recipe. Only the hash of its token is saved,
+// so the returned token cannot be read again. A zero expiration time creates a link valid until it is revoked.
+func (s *service) CreateShareLink(ctx context.Context, recipeId uuid.UUID, expiresAt time.Time) (CreatedShareLinkResponse, error) {
+	shareLinkId, err := uuid.NewV7()
+	if err != nil {
+		return CreatedShareLinkResponse{}, errors.Join(errors.New("failed to generate UUID"), err)
+	}
+
+	token, tokenHash, err := shared.GenerateSecretToken()
+	if err != nil {
+		return CreatedShareLinkResponse{}, errors.Join(errors.New("failed to generate a token for a share link"), err)
+	}
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		return q.CreateRecipeShareLink(qCtx, sqlc.CreateRecipeShareLinkParams{
+			ShareLinkID: shareLinkId,
+			RecipeID:    recipeId,
+			TokenHash:   tokenHash,
+			ExpiresAt:   toPgTimestamp(expiresAt),
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return CreatedShareLinkResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("createShareLink method got uncaught error", zap.Error(err))
+			return CreatedShareLinkResponse{}, err
+		}
+	}
+
+	createdShareLink := CreatedShareLinkResponse{
+		ShareLinkID: shareLinkId,
+		Token:       token,
+		URL:         sharedRecipePath + token,
+	}
+
+	if !expiresAt.IsZero() {
+		createdShareLink.ExpiresAt = &expiresAt
+	}
+
+	return createdShareLink, nil
+}
+
+// ListShareLinks returns every share link of a recipe, starting from the newest ones, with their view counts.
+func (s *service) ListShareLinks(ctx context.Context, recipeId uuid.UUID) ([]ShareLinkResponse, error) {
+	var shareLinks []ShareLinkResponse
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListRecipeShareLinks(qCtx, recipeId)
+		if err != nil {
+			return err
+		}
+
+		shareLinks = make([]ShareLinkResponse, 0, len(rows))
+
+		for _, row := range rows {
+			shareLinks = append(shareLinks, ShareLinkResponse{
+				ShareLinkID:    row.ShareLinkID,
+				ExpiresAt:      fromPgTimestamp(row.ExpiresAt),
+				RevokedAt:      fromPgTimestamp(row.RevokedAt),
+				ViewCount:      row.ViewCount,
+				LastAccessedAt: fromPgTimestamp(row.LastAccessedAt),
+				CreatedAt:      row.CreatedAt.Time,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listShareLinks method got uncaught error", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	return shareLinks, nil
+}
+
+// RevokeShareLink stops a share link of a recipe from working. Revoking a link twice keeps its first revocation time.
+func (s *service) RevokeShareLink(ctx context.Context, recipeId, shareLinkId uuid.UUID) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		revokedRows, err := q.RevokeRecipeShareLink(qCtx, sqlc.RevokeRecipeShareLinkParams{
+			ShareLinkID: shareLinkId,
+			RecipeID:    recipeId,
+		})
+		if err != nil {
+			return err
+		}
+
+		if revokedRows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a share link with this ID"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("revokeShareLink method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UseShareLink records a view of a share link and returns the ID of the shared recipe.
+// Revoked and expired links, and links to deleted recipes, are reported as not found.
+func (s *service) UseShareLink(ctx context.Context, token string) (uuid.UUID, error) {
+	var recipeId uuid.UUID
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		var err error
+
+		recipeId, err = q.UseRecipeShareLink(qCtx, shared.HashSecretToken(token))
+
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "the share link is not valid or has expired"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("useShareLink method got uncaught error", zap.Error(err))
+			return uuid.UUID{}, err
+		}
+	}
+
+	return recipeId, nil
+}
@@ -0,0 +1,198 @@
+package recipe
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// GetRecipeSteps godoc
+//
+//	@Summary		Get steps of a recipe
+//	@Description	Get the ordered preparation steps of a recipe.
+//	@Tags			steps
+//
+//	@Produce		json
+//	@Param			id	path		string										true	"UUID of a recipe."
+//
+//	@Success		200	{object}	shared.DataResponse[[]recipe.StepResponse]	"Steps fetched successfully."
+//	@Failure		400	{object}	shared.CommonResponse						"Invalid data provided."
+//	@Failure		404	{object}	shared.CommonResponse						"Recipe not found."
+//
+//	@Router			/api/v1/recipes/{id}/steps [GET]
+func (h *handler) GetRecipeSteps(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	recipe, err := h.getReadableRecipe(c, recipeId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[[]StepResponse]{Data: recipe.Steps})
+}
+
+// CreateRecipeStep godoc
+//
+//	@Summary		Insert a step
+//	@Description	Insert a step into a recipe at the given position and move the following steps down. The step is appended when the position is omitted.
+//	@Tags			steps
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string								true	"UUID of a recipe."
+//	@Param			InsertStepRequest	body		recipe.InsertStepRequest			true	"Request body with the step and its position."
+//
+//	@Success		201					{object}	shared.CommonResponse				"Step saved successfully."
+//	@Failure		400					{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401					{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403					{object}	shared.CommonResponse				"User is not the owner of the recipe."
+//	@Failure		404					{object}	shared.CommonResponse				"Recipe not found."
+//	@Failure		409					{object}	shared.CommonResponse				"Database conflict occurred when trying to saving a recipe."
+//
+//	@Router			/api/v1/recipes/{id}/steps [POST]
+func (h *handler) CreateRecipeStep(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestBody = InsertStepRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	recipeFromDb, err := h.getOwnedRecipe(c, recipeId)
+	if err != nil {
+		return err
+	}
+
+	stepId, err := h.recipeService.CreateRecipeStep(c.Request().Context(), recipeId, recipeFromDb.UpdatedAt, requestBody)
+	if err != nil {
+		return err
+	}
+
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully saved a step of a recipe", zap.String("recipeId", recipeId.String()), zap.String("stepId", stepId.String()))
+
+	c.Response().Header().Add("Location", fmt.Sprintf("http://localhost:8080/api/v1/recipes/%v/steps", recipeId.String()))
+	return c.JSON(http.StatusCreated, shared.CommonResponse{Message: "successfully saved a step"})
+}
+
+// ReorderRecipeSteps godoc
+//
+//	@Summary		Reorder steps
+//	@Description	Set the order of steps in a recipe. The list must contain the ID of every step of the recipe exactly once.
+//	@Tags			steps
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path	string						true	"UUID of a recipe."
+//	@Param			ReorderStepsRequest	body	recipe.ReorderStepsRequest	true	"Request body with step IDs in the new order."
+//
+//	@Success		204					"Steps reordered successfully."
+//	@Failure		400					{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401					{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		403					{object}	shared.CommonResponse				"User is not the owner of the recipe."
+//	@Failure		404					{object}	shared.CommonResponse				"Recipe not found."
+//	@Failure		409					{object}	shared.CommonResponse				"Database conflict occurred when trying to saving a recipe."
+//
+//	@Router			/api/v1/recipes/{id}/steps/order [PUT]
+func (h *handler) ReorderRecipeSteps(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	var requestBody = ReorderStepsRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	recipeFromDb, err := h.getOwnedRecipe(c, recipeId)
+	if err != nil {
+		return err
+	}
+
+	err = h.recipeService.ReorderRecipeSteps(c.Request().Context(), recipeId, recipeFromDb.UpdatedAt, requestBody.StepIDs)
+	if err != nil {
+		return err
+	}
+
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully reordered steps of a recipe", zap.String("recipeId", recipeId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteRecipeStep godoc
+//
+//	@Summary		Delete a step
+//	@Description	Delete a step from a recipe and move the following steps up.
+//	@Tags			steps
+//
+//	@Produce		json
+//	@Param			id		path	string	true	"UUID of a recipe."
+//	@Param			stepId	path	string	true	"UUID of a step."
+//
+//	@Success		204		"Step deleted successfully."
+//	@Failure		400		{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401		{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		403		{object}	shared.CommonResponse	"User is not the owner of the recipe."
+//	@Failure		404		{object}	shared.CommonResponse	"Recipe or step not found."
+//	@Failure		409		{object}	shared.CommonResponse	"Database conflict occurred when trying to saving a recipe."
+//
+//	@Router			/api/v1/recipes/{id}/steps/{stepId} [DELETE]
+func (h *handler) DeleteRecipeStep(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	stepId, err := uuid.Parse(c.Param("stepId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "the received step ID is not a valid UUID"})
+	}
+
+	recipeFromDb, err := h.getOwnedRecipe(c, recipeId)
+	if err != nil {
+		return err
+	}
+
+	err = h.recipeService.DeleteRecipeStep(c.Request().Context(), recipeId, recipeFromDb.UpdatedAt, stepId)
+	if err != nil {
+		return err
+	}
+
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully deleted a step of a recipe", zap.String("recipeId", recipeId.String()), zap.String("stepId", stepId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
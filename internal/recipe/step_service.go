@@ -0,0 +1,297 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+var (
+	errStepPositionOutOfRange = errors.New("step position is out of range")
+	errInvalidStepOrder       = errors.New("step IDs do not match the steps of the recipe")
+)
+
+// CreateRecipeStep inserts a step into a recipe and moves the following steps down.
+// The updatedAt time is used to check if the recipe has not been modified in the meantime.
+func (s *service) CreateRecipeStep(ctx context.Context, recipeId uuid.UUID, updatedAt time.Time, insertStepRequest InsertStepRequest) (uuid.UUID, error) {
+	stepId, err := uuid.NewV7()
+	if err != nil {
+		return uuid.UUID{}, errors.Join(errors.New("failed to generate UUID"), err)
+	}
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		if err := touchRecipe(qCtx, q, recipeId, updatedAt); err != nil {
+			return err
+		}
+
+		stepsCount, err := q.CountRecipeSteps(qCtx, recipeId)
+		if err != nil {
+			return err
+		}
+
+		position := int32(stepsCount) + 1
+
+		if insertStepRequest.Position != nil {
+			if *insertStepRequest.Position > position {
+				return errStepPositionOutOfRange
+			}
+
+			position = *insertStepRequest.Position
+		}
+
+		err = q.ShiftRecipeStepsDown(qCtx, sqlc.ShiftRecipeStepsDownParams{
+			RecipeID: recipeId,
+			Position: position,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := q.CreateRecipeStep(qCtx, newCreateRecipeStepParams(stepId, recipeId, position, insertStepRequest.StepRequest)); err != nil {
+			return err
+		}
+
+		// Changes of steps are updates of the recipe, so they are kept as revisions too.
+		return q.CreateRecipeRevision(qCtx, recipeId)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errRecipeModified):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "conflict occurred when trying to update a recipe"})
+		case errors.Is(err, errStepPositionOutOfRange):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the position is greater than the number of steps plus one"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("createRecipeStep method got uncaught error", zap.Error(err))
+			return uuid.UUID{}, err
+		}
+	}
+
+	return stepId, nil
+}
+
+// ReorderRecipeSteps sets the order of steps in a recipe to the order of the given step IDs.
+// The step IDs must contain every step of the recipe exactly once.
+func (s *service) ReorderRecipeSteps(ctx context.Context, recipeId uuid.UUID, updatedAt time.Time, stepIds []uuid.UUID) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		if err := touchRecipe(qCtx, q, recipeId, updatedAt); err != nil {
+			return err
+		}
+
+		stepsFromDb, err := q.GetRecipeStepsByRecipeId(qCtx, recipeId)
+		if err != nil {
+			return err
+		}
+
+		if len(stepsFromDb) != len(stepIds) {
+			return errInvalidStepOrder
+		}
+
+		remainingSteps := make(map[uuid.UUID]struct{}, len(stepsFromDb))
+		for _, step := range stepsFromDb {
+			remainingSteps[step.StepID] = struct{}{}
+		}
+
+		for i, stepId := range stepIds {
+			if _, ok := remainingSteps[stepId]; !ok {
+				return errInvalidStepOrder
+			}
+
+			delete(remainingSteps, stepId)
+
+			err := q.UpdateRecipeStepPosition(qCtx, sqlc.UpdateRecipeStepPositionParams{
+				StepID:   stepId,
+				RecipeID: recipeId,
+				Position: int32(i + 1),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return q.CreateRecipeRevision(qCtx, recipeId)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errRecipeModified):
+			return echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "conflict occurred when trying to update a recipe"})
+		case errors.Is(err, errInvalidStepOrder):
+			return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the step IDs must contain every step of the recipe exactly once"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("reorderRecipeSteps method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteRecipeStep removes a step from a recipe and moves the following steps up.
+func (s *service) DeleteRecipeStep(ctx context.Context, recipeId uuid.UUID, updatedAt time.Time, stepId uuid.UUID) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		if err := touchRecipe(qCtx, q, recipeId, updatedAt); err != nil {
+			return err
+		}
+
+		position, err := q.DeleteRecipeStep(qCtx, sqlc.DeleteRecipeStepParams{
+			StepID:   stepId,
+			RecipeID: recipeId,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.ShiftRecipeStepsUp(qCtx, sqlc.ShiftRecipeStepsUpParams{
+			RecipeID: recipeId,
+			Position: position,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.CreateRecipeRevision(qCtx, recipeId)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errRecipeModified):
+			return echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "conflict occurred when trying to update a recipe"})
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a step with this ID in the recipe"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("deleteRecipeStep method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// touchRecipe bumps the updated_at time of a recipe, if the recipe has not been modified since updatedAt.
+// It returns errRecipeModified otherwise.
+func touchRecipe(ctx context.Context, q *sqlc.Queries, recipeId uuid.UUID, updatedAt time.Time) error {
+	updatedRows, err := q.TouchRecipe(ctx, sqlc.TouchRecipeParams{
+		RecipeID: recipeId,
+		UpdatedAt: pgtype.Timestamp{
+			Time:             updatedAt,
+			InfinityModifier: pgtype.Finite,
+			Valid:            true,
+		},
+		NewUpdatedAt: pgtype.Timestamp{
+			Time:             time.Now(),
+			InfinityModifier: pgtype.Finite,
+			Valid:            true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if updatedRows == 0 {
+		return errRecipeModified
+	}
+
+	return nil
+}
+
+// createSteps saves the steps of a recipe in the order they were provided.
+func createSteps(ctx context.Context, q *sqlc.Queries, recipeId uuid.UUID, steps []StepRequest) error {
+	for i, step := range steps {
+		stepId, err := uuid.NewV7()
+		if err != nil {
+			return errors.Join(errors.New("failed to generate UUID"), err)
+		}
+
+		if err := q.CreateRecipeStep(ctx, newCreateRecipeStepParams(stepId, recipeId, int32(i+1), step)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newCreateRecipeStepParams(stepId, recipeId uuid.UUID, position int32, step StepRequest) sqlc.CreateRecipeStepParams {
+	return sqlc.CreateRecipeStepParams{
+		StepID:          stepId,
+		RecipeID:        recipeId,
+		Position:        position,
+		Instruction:     step.Instruction,
+		DurationMinutes: toPgInt4(step.DurationMinutes),
+		Temperature:     toPgInt4(step.Temperature),
+		TemperatureUnit: pgtype.Text{String: step.TemperatureUnit, Valid: step.TemperatureUnit != ""},
+	}
+}
+
+func newStepResponses(stepsFromDb []sqlc.RecipeStep) []StepResponse {
+	steps := make([]StepResponse, 0, len(stepsFromDb))
+
+	for _, step := range stepsFromDb {
+		stepResponse := StepResponse{
+			StepID:      step.StepID,
+			Position:    step.Position,
+			Instruction: step.Instruction,
+		}
+
+		if step.DurationMinutes.Valid {
+			stepResponse.DurationMinutes = &step.DurationMinutes.Int32
+		}
+
+		if step.Temperature.Valid {
+			stepResponse.Temperature = &step.Temperature.Int32
+		}
+
+		if step.TemperatureUnit.Valid {
+			stepResponse.TemperatureUnit = &step.TemperatureUnit.String
+		}
+
+		steps = append(steps, stepResponse)
+	}
+
+	return steps
+}
+
+// toPgInt4 converts the number to a nullable database value, treating nil as NULL.
+func toPgInt4(i *int32) pgtype.Int4 {
+	if i == nil {
+		return pgtype.Int4{}
+	}
+
+	return pgtype.Int4{Int32: *i, Valid: true}
+}
@@ -0,0 +1,29 @@
+package recipe
+
+import (
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+)
+
+// ListTags godoc
+//
+//	@Summary		List tags
+//	@Description	Get all tags, grouped by category, with the number of recipes tagged with them.
+//	@Description	Only recipes which are listed publicly are counted, so deleted, unpublished, unlisted and private recipes are left out.
+//	@Tags			tags
+//
+//	@Produce		json
+//
+//	@Success		200	{object}	shared.DataResponse[[]recipe.TagResponse]	"Tags fetched successfully."
+//
+//	@Router			/api/v1/tags [GET]
+func (h *handler) ListTags(c echo.Context) error {
+	tags, err := h.recipeService.ListTags(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[[]TagResponse]{Data: tags})
+}
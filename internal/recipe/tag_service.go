@@ -0,0 +1,98 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	tagMatchAny = "any"
+	tagMatchAll = "all"
+)
+
+// ListTags returns all tags with the number of recipes tagged with them, grouped by category.
+func (s *service) ListTags(ctx context.Context) ([]TagResponse, error) {
+	var tags []TagResponse
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListTags(qCtx)
+		if err != nil {
+			return err
+		}
+
+		tags = make([]TagResponse, 0, len(rows))
+
+		for _, row := range rows {
+			tags = append(tags, TagResponse{
+				Name:        row.Name,
+				Category:    row.Category,
+				RecipeCount: row.RecipeCount,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listTags method got uncaught error", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	return tags, nil
+}
+
+// setRecipeTags tags a recipe, creating the tags which do not exist yet.
+// The queries normalize the tag names again, into names which always pass the check on tags.name,
+// because Go and Postgres do not agree on every whitespace and letter case, and the check must not reject a tag.
+func setRecipeTags(ctx context.Context, q *sqlc.Queries, recipeId uuid.UUID, tagNames []string) error {
+	if len(tagNames) == 0 {
+		return nil
+	}
+
+	if err := q.CreateTags(ctx, tagNames); err != nil {
+		return err
+	}
+
+	return q.CreateRecipeTags(ctx, sqlc.CreateRecipeTagsParams{
+		RecipeID: recipeId,
+		Names:    tagNames,
+	})
+}
+
+// normalizeTagNames lowercases tag names and collapses their whitespace, so "Gluten  Free" matches "gluten free".
+// Duplicates are removed, keeping the order of the first occurrences.
+// It is close to how the database normalizes tags, which is enough to filter recipes by tags.
+func normalizeTagNames(tagNames []string) []string {
+	normalizedNames := make([]string, 0, len(tagNames))
+
+	for _, name := range tagNames {
+		name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+
+		if name != "" && !slices.Contains(normalizedNames, name) {
+			normalizedNames = append(normalizedNames, name)
+		}
+	}
+
+	return normalizedNames
+}
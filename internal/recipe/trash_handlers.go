@@ -0,0 +1,89 @@
+package recipe
+
+import (
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// ListDeletedRecipes godoc
+//
+//	@Summary		List deleted recipes
+//	@Description	Get a page of recipes in the trash of the signed-in user, starting from the most recently deleted ones.
+//	@Description	Every recipe can be restored until its purge time.
+//	@Tags			trash
+//
+//	@Produce		json
+//
+//	@Param			cursor	query		string													false	"Cursor to the next page."
+//	@Param			limit	query		int														false	"Maximum number of recipes on a page."	minimum(1)	maximum(100)	default(20)
+//
+//	@Success		200		{object}	shared.PagedDataResponse[recipe.TrashedRecipeResponse]	"Deleted recipes fetched successfully."
+//	@Failure		400		{object}	validator.ValidationErrorResponse						"Invalid data provided."
+//	@Failure		401		{object}	shared.CommonResponse									"User is not signed in."
+//
+//	@Router			/api/v1/me/trash [GET]
+func (h *handler) ListDeletedRecipes(c echo.Context) error {
+	var requestQuery = ListTrashRequest{}
+
+	if err := c.Bind(&requestQuery); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "received invalid query parameters"})
+	}
+
+	if err := c.Validate(&requestQuery); err != nil {
+		return err
+	}
+
+	if requestQuery.Limit == 0 {
+		requestQuery.Limit = defaultListLimit
+	}
+
+	recipes, nextCursor, err := h.recipeService.ListDeletedRecipes(c.Request().Context(), session.FromContext(c).UserID, requestQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.PagedDataResponse[TrashedRecipeResponse]{
+		Data: recipes,
+		Paging: shared.Paging{
+			NextCursor: nextCursor,
+			Limit:      requestQuery.Limit,
+		},
+	})
+}
+
+// RestoreRecipe godoc
+//
+//	@Summary		Restore a deleted recipe
+//	@Description	Take a recipe out of the trash of the signed-in user, with its ingredients, images, reviews and places in cookbooks.
+//	@Tags			trash
+//
+//	@Produce		json
+//	@Param			id	path	string	true	"UUID of a recipe."
+//
+//	@Success		204	"Recipe restored successfully."
+//	@Failure		400	{object}	shared.CommonResponse	"Invalid data provided."
+//	@Failure		401	{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		404	{object}	shared.CommonResponse	"Recipe not found in the trash."
+//
+//	@Router			/api/v1/recipes/{id}/restore [POST]
+func (h *handler) RestoreRecipe(c echo.Context) error {
+	recipeId, err := parseRecipeId(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.recipeService.RestoreRecipe(c.Request().Context(), recipeId, session.FromContext(c).UserID); err != nil {
+		return err
+	}
+
+	// A read which raced with the deletion could have cached the recipe as it was before.
+	h.deleteCachedRecipe(recipeId)
+
+	h.logger.Info("successfully restored a recipe from the trash", zap.String("recipeId", recipeId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
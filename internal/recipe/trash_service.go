@@ -0,0 +1,229 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// purgeBatchSize is the number of recipes purged in one transaction, so a purge never holds many locks for long.
+const purgeBatchSize = 100
+
+// trashCursor stores the deletion time and ID of the last recipe returned on a page.
+type trashCursor struct {
+	DeletedAt time.Time `json:"d"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// ListDeletedRecipes returns a page of recipes in the trash of a user, starting from the most recently deleted ones,
+// and a cursor to the next page. The returned cursor is empty when there are no more recipes.
+func (s *service) ListDeletedRecipes(ctx context.Context, userId uuid.UUID, listTrashRequest ListTrashRequest) ([]TrashedRecipeResponse, string, error) {
+	var cursor trashCursor
+
+	if listTrashRequest.Cursor != "" {
+		if err := shared.DecodeCursor(listTrashRequest.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rowLimit := shared.PageLimit(listTrashRequest.Limit)
+
+	recipes := make([]TrashedRecipeResponse, 0, rowLimit)
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListDeletedRecipes(qCtx, sqlc.ListDeletedRecipesParams{
+			UserID:          userId,
+			CursorID:        toPgUUID(cursor.ID),
+			CursorDeletedAt: toPgTimestamp(cursor.DeletedAt),
+			RowLimit:        rowLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			recipes = append(recipes, TrashedRecipeResponse{
+				RecipeSummaryResponse: newRecipeSummaryResponse(row.RecipeID, row.UserID, row.Title, row.FavoriteCount, row.CreatedAt, row.UpdatedAt),
+				DeletedAt:             row.DeletedAt.Time,
+				PurgeAt:               row.DeletedAt.Time.Add(s.trashRetention),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, "", echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listDeletedRecipes method got uncaught error", zap.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return shared.Paginate(recipes, listTrashRequest.Limit, func(lastRecipe TrashedRecipeResponse) any {
+		return trashCursor{DeletedAt: lastRecipe.DeletedAt, ID: lastRecipe.RecipeID}
+	})
+}
+
+// RestoreRecipe takes a recipe of a user out of the trash.
+func (s *service) RestoreRecipe(ctx context.Context, recipeId, userId uuid.UUID) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		restoredRows, err := q.RestoreRecipeById(qCtx, sqlc.RestoreRecipeByIdParams{
+			RecipeID: recipeId,
+			UserID:   userId,
+		})
+		if err != nil {
+			return err
+		}
+
+		if restoredRows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a recipe with this ID in the trash"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("restoreRecipe method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunTrashPurge purges the trash every interval until the context is done.
+func (s *service) RunTrashPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purgedCount, err := s.PurgeTrash(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Error("failed to purge the trash", zap.Error(err))
+		} else if purgedCount > 0 {
+			s.logger.Info("successfully purged recipes from the trash", zap.Int("count", purgedCount))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeTrash removes the recipes which have been in the trash for longer than the retention period,
+// together with their images in the blob store. It returns the number of purged recipes.
+func (s *service) PurgeTrash(ctx context.Context) (int, error) {
+	deletedBefore := time.Now().Add(-s.trashRetention)
+	purgedCount := 0
+
+	for {
+		recipeIds, imageKeys, err := s.purgeTrashBatch(ctx, deletedBefore)
+		if err != nil {
+			return purgedCount, err
+		}
+
+		purgedCount += len(recipeIds)
+
+		// Images are deleted after the commit, so a failed transaction never leaves recipes without their images.
+		for _, key := range imageKeys {
+			blobCtx, cancelBlobCtx := context.WithTimeout(ctx, blobUploadTimeout)
+
+			if err := s.blobStore.Delete(blobCtx, key); err != nil {
+				s.logger.Error("failed to delete an image of a purged recipe", zap.String("key", key), zap.Error(err))
+			}
+
+			cancelBlobCtx()
+		}
+
+		if len(recipeIds) < purgeBatchSize {
+			return purgedCount, nil
+		}
+	}
+}
+
+// purgeTrashBatch deletes a batch of recipes deleted before the given time and returns their IDs and the keys of their images.
+func (s *service) purgeTrashBatch(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, []string, error) {
+	var recipeIds []uuid.UUID
+	var imageKeys []string
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		var err error
+
+		recipeIds, err = q.ListPurgeableRecipes(qCtx, sqlc.ListPurgeableRecipesParams{
+			DeletedBefore: toPgTimestamp(deletedBefore),
+			RowLimit:      purgeBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, recipeId := range recipeIds {
+			images, err := q.GetRecipeImagesByRecipeId(qCtx, recipeId)
+			if err != nil {
+				return err
+			}
+
+			for _, image := range images {
+				imageKeys = append(imageKeys, image.ObjectKey)
+			}
+
+			// Close the gaps the recipe leaves in the order of cookbooks.
+			if err := q.DeleteRecipeFromCookbooks(qCtx, recipeId); err != nil {
+				return err
+			}
+
+			if err := q.DeleteRecipeById(qCtx, recipeId); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return recipeIds, imageKeys, nil
+}
@@ -1,30 +1,137 @@
 package session
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base32"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
+// DefaultExpirationTime is the number of seconds a session is kept in memcached.
+const DefaultExpirationTime = 86400 * 14
+
+// PendingExpirationTime is the number of seconds a sign-in waits for the second factor.
+const PendingExpirationTime = 300
+
+// ChallengeExpirationTime is the number of seconds a passkey ceremony waits for the authenticator.
+const ChallengeExpirationTime = 300
+
+var (
+	// ErrPendingNotFound is returned for pending sign-ins which do not exist or have expired.
+	ErrPendingNotFound = errors.New("pending sign-in not found")
+	// ErrChallengeNotFound is returned for challenges which do not exist, have expired or have been used.
+	ErrChallengeNotFound = errors.New("challenge not found")
+	// ErrUserNotFound is returned by a VersionSource for users which do not exist anymore.
+	ErrUserNotFound = errors.New("user not found")
+)
+
 const (
 	storageSessionKeyLength = 20
 	sessionStorageKey       = "session_id"
+	sessionContextKey       = "session"
+	// userSessionsKeyPrefix prefixes the keys of the lists of session IDs of every user.
+	userSessionsKeyPrefix = "user_sessions_"
+	// sessionVersionKeyPrefix prefixes the keys of the cached session versions of every user.
+	sessionVersionKeyPrefix = "session_version_"
+	// maxUserSessionsUpdates limits the retries of updating sessions and their lists changed concurrently.
+	maxUserSessionsUpdates = 5
+	// pendingKeyPrefix prefixes the keys of sign-ins which wait for the second factor,
+	// and pendingAttemptsKeyPrefix the keys of their numbers of attempts.
+	pendingKeyPrefix         = "pending_"
+	pendingAttemptsKeyPrefix = "pending_attempts_"
+	// challengeKeyPrefix prefixes the keys of the challenges of passkey ceremonies.
+	challengeKeyPrefix = "webauthn_challenge_"
 )
 
 // Session represents stored values in memcache.
 type Session struct {
-	Email string `json:"email"`
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	// MeasurementSystem is the preferred system of measurement of the user, empty if the user has not chosen one.
+	MeasurementSystem string `json:"measurement_system"`
+	// UnverifiedEmail is set until the user opens the link from the verification email.
+	// Sessions created before emails were verified do not have it, and they belong to users with verified emails.
+	UnverifiedEmail bool `json:"unverified_email,omitempty"`
+	// SessionVersion is the session version of the user when the session was created.
+	// Sessions created before sessions had versions do not have it, and they have the first version.
+	SessionVersion int32 `json:"session_version,omitempty"`
+}
+
+// VersionSource returns the current session version of a user. The version is raised when the password changes,
+// and sessions with an older version are rejected.
+type VersionSource interface {
+	SessionVersion(ctx context.Context, userId uuid.UUID) (int32, error)
+}
+
+// IsAuthenticated reports whether the session belongs to a signed-in user.
+func (s *Session) IsAuthenticated() bool {
+	return s.UserID != uuid.Nil
+}
+
+// FromContext returns the session attached to the request by the Middleware.
+// If the request has not passed through the Middleware, an empty session is returned.
+func FromContext(c echo.Context) *Session {
+	if session, ok := c.Get(sessionContextKey).(*Session); ok {
+		return session
+	}
+
+	return &Session{}
+}
+
+// ToContext attaches the session to the request.
+func ToContext(c echo.Context, session *Session) {
+	c.Set(sessionContextKey, session)
+}
+
+// WithSessionForTest attaches the given session to every request, in place of Middleware in tests of handlers.
+func WithSessionForTest(session *Session) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ToContext(c, session)
+			return next(c)
+		}
+	}
+}
+
+// RequireAuth marks a route as available only to signed-in users
+// and rejects requests with an empty session.
+func RequireAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !FromContext(c).IsAuthenticated() {
+			return echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "you must be signed in to perform this action"})
+		}
+
+		return next(c)
+	}
+}
+
+// RequireVerifiedEmail marks a route as available only to signed-in users who have verified their email,
+// like publishing recipes or commenting. It rejects requests with an empty session too, so it replaces RequireAuth.
+func RequireVerifiedEmail(next echo.HandlerFunc) echo.HandlerFunc {
+	return RequireAuth(func(c echo.Context) error {
+		if FromContext(c).UnverifiedEmail {
+			return echo.NewHTTPError(http.StatusForbidden, shared.CommonResponse{Message: "you must verify your email to perform this action"})
+		}
+
+		return next(c)
+	})
 }
 
 // Middlewares adds the stored session from the memcache to the request context.
-func Middleware(memcachedStore *MemcachedStore, sessionCookieName string, skipper middleware.Skipper) echo.MiddlewareFunc {
+// Sessions older than the session version of their user from versionSource are deleted.
+func Middleware(memcachedStore *MemcachedStore, versionSource VersionSource, sessionCookieName string, skipper middleware.Skipper) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			if skipper(c) {
@@ -38,13 +145,13 @@ func Middleware(memcachedStore *MemcachedStore, sessionCookieName string, skippe
 				switch {
 				case errors.Is(err, http.ErrNoCookie):
 					// Pass the request with empty session
-					c.Set("session", &session)
+					ToContext(c, &session)
 					return next(c)
 				case errors.Is(err, memcache.ErrCacheMiss):
 					// The session cookie does not exist in the cache, so just delete the cookie from client.
 					// Also pass the request with empty session.
 					deleteCookieFromClient(c, sessionCookieName)
-					c.Set("session", &session)
+					ToContext(c, &session)
 					return next(c)
 				default:
 					return err
@@ -55,7 +162,24 @@ func Middleware(memcachedStore *MemcachedStore, sessionCookieName string, skippe
 				panic(errors.Join(errors.New("failed to decode the value from session"), err))
 			}
 
-			c.Set("session", &session)
+			currentVersion, err := memcachedStore.sessionVersion(c.Request().Context(), session.UserID, versionSource)
+			if err != nil && !errors.Is(err, ErrUserNotFound) {
+				return err
+			}
+
+			// The password has changed since the session was created, or the user does not exist anymore,
+			// so the session is deleted even when it was missing from the list of sessions of the user.
+			if err != nil || session.SessionVersion < currentVersion {
+				if cookie, err := c.Cookie(sessionCookieName); err == nil {
+					memcachedStore.Delete(cookie.Value)
+				}
+
+				deleteCookieFromClient(c, sessionCookieName)
+				ToContext(c, &Session{})
+				return next(c)
+			}
+
+			ToContext(c, &session)
 			return next(c)
 		}
 	}
@@ -119,9 +243,9 @@ func (ms *MemcachedStore) Get(c echo.Context, sessionCookieName string) ([]byte,
 	return item.Value, nil
 }
 
-// CreateNew creates and saves an entirely new session to memcached.
+// CreateNew creates and saves an entirely new session of the user to memcached.
 // The returned string type is a ID of the newly created session.
-func (ms *MemcachedStore) CreateNew(value []byte, expiration int32) (string, error) {
+func (ms *MemcachedStore) CreateNew(userId uuid.UUID, value []byte, expiration int32) (string, error) {
 	generatedSessionID := generateSessionID()
 
 	item := ms.getItemFromThePool()
@@ -136,11 +260,61 @@ func (ms *MemcachedStore) CreateNew(value []byte, expiration int32) (string, err
 		return "", err
 	}
 
+	// A session which is not on the list of the user could not be deleted with DeleteAllOfUser.
+	if err := ms.addUserSession(userId, generatedSessionID, expiration); err != nil {
+		ms.Delete(generatedSessionID)
+		return "", err
+	}
+
 	return generatedSessionID, nil
 }
 
-// Update updates already existing session in memcached.
-func (ms *MemcachedStore) Update(key string, value []byte, expiration int32) error {
+// addUserSession appends the session ID to the list of sessions of the user
+// and drops the IDs of sessions which have expired or have been deleted.
+func (ms *MemcachedStore) addUserSession(userId uuid.UUID, sessionID string, expiration int32) error {
+	key := userSessionsKeyPrefix + userId.String()
+
+	for range maxUserSessionsUpdates {
+		item, err := ms.memcachedClient.Get(key)
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			err = ms.memcachedClient.Add(&memcache.Item{Key: key, Value: []byte(sessionID), Expiration: expiration})
+			if errors.Is(err, memcache.ErrNotStored) {
+				// Another sign-in has just created the list.
+				continue
+			}
+
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		storedSessions, err := ms.memcachedClient.GetMulti(strings.Fields(string(item.Value)))
+		if err != nil {
+			return err
+		}
+
+		sessionIDs := make([]string, 0, len(storedSessions)+1)
+		for storedSessionID := range storedSessions {
+			sessionIDs = append(sessionIDs, storedSessionID)
+		}
+
+		item.Value = []byte(strings.Join(append(sessionIDs, sessionID), " "))
+		item.Expiration = expiration
+
+		err = ms.memcachedClient.CompareAndSwap(item)
+		if errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrNotStored) {
+			continue
+		}
+
+		return err
+	}
+
+	return errors.New("failed to update the sessions of the user because of concurrent changes")
+}
+
+// Update updates already existing session of the user in memcached.
+func (ms *MemcachedStore) Update(userId uuid.UUID, key string, value []byte, expiration int32) error {
 	item := ms.getItemFromThePool()
 	defer ms.returnItemToThePool(item)
 
@@ -153,6 +327,17 @@ func (ms *MemcachedStore) Update(key string, value []byte, expiration int32) err
 		return err
 	}
 
+	return ms.renewUserSessions(userId, expiration)
+}
+
+// renewUserSessions extends the expiration of the list of sessions of the user once a session is renewed,
+// so the list does not expire before the sessions on it.
+func (ms *MemcachedStore) renewUserSessions(userId uuid.UUID, expiration int32) error {
+	err := ms.memcachedClient.Touch(userSessionsKeyPrefix+userId.String(), expiration)
+	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return err
+	}
+
 	return nil
 }
 
@@ -161,6 +346,230 @@ func (ms *MemcachedStore) Delete(key string) {
 	_ = ms.memcachedClient.Delete(key)
 }
 
+// UpdateAllOfUser applies the update to every session of the user stored in memcached,
+// so a change of the account applies to the user on all devices without signing in again.
+func (ms *MemcachedStore) UpdateAllOfUser(userId uuid.UUID, update func(*Session)) error {
+	item, err := ms.memcachedClient.Get(userSessionsKeyPrefix + userId.String())
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, sessionID := range strings.Fields(string(item.Value)) {
+		if err := ms.updateSession(sessionID, update); err != nil {
+			return err
+		}
+	}
+
+	// updateSession renews the sessions, so their list is renewed as well.
+	return ms.renewUserSessions(userId, DefaultExpirationTime)
+}
+
+// updateSession applies the update to a stored session, retrying when the session is changed concurrently.
+// Sessions which have expired or have been deleted are skipped.
+func (ms *MemcachedStore) updateSession(sessionID string, update func(*Session)) error {
+	for range maxUserSessionsUpdates {
+		item, err := ms.memcachedClient.Get(sessionID)
+		if err != nil {
+			if errors.Is(err, memcache.ErrCacheMiss) {
+				return nil
+			}
+
+			return err
+		}
+
+		var session Session
+
+		if err := json.Unmarshal(item.Value, &session); err != nil {
+			return errors.Join(errors.New("failed to decode the value from session"), err)
+		}
+
+		update(&session)
+
+		item.Value, err = json.Marshal(session)
+		if err != nil {
+			return err
+		}
+
+		// memcached does not return the expiration of an item, so it is renewed like with Update.
+		item.Expiration = DefaultExpirationTime
+
+		err = ms.memcachedClient.CompareAndSwap(item)
+		switch {
+		case errors.Is(err, memcache.ErrNotStored):
+			return nil
+		case errors.Is(err, memcache.ErrCASConflict):
+			continue
+		default:
+			return err
+		}
+	}
+
+	return errors.New("failed to update the session because of concurrent changes")
+}
+
+// DeleteAllOfUser deletes every session of the user from memcached, signing the user out on all devices.
+func (ms *MemcachedStore) DeleteAllOfUser(userId uuid.UUID) error {
+	key := userSessionsKeyPrefix + userId.String()
+
+	item, err := ms.memcachedClient.Get(key)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, sessionID := range strings.Fields(string(item.Value)) {
+		if err := ms.memcachedClient.Delete(sessionID); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+			return err
+		}
+	}
+
+	if err := ms.memcachedClient.Delete(key); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return err
+	}
+
+	return nil
+}
+
+// SetSessionVersion caches the new session version of the user after the password has changed,
+// so the Middleware rejects older sessions at once.
+func (ms *MemcachedStore) SetSessionVersion(userId uuid.UUID, version int32) error {
+	return ms.memcachedClient.Set(&memcache.Item{
+		Key:        sessionVersionKeyPrefix + userId.String(),
+		Value:      []byte(strconv.FormatInt(int64(version), 10)),
+		Expiration: DefaultExpirationTime,
+	})
+}
+
+// sessionVersion returns the current session version of the user, cached in memcached or else from versionSource.
+func (ms *MemcachedStore) sessionVersion(ctx context.Context, userId uuid.UUID, versionSource VersionSource) (int32, error) {
+	key := sessionVersionKeyPrefix + userId.String()
+
+	item, err := ms.memcachedClient.Get(key)
+	if err == nil {
+		version, err := strconv.ParseInt(string(item.Value), 10, 32)
+		if err != nil {
+			return 0, errors.Join(errors.New("failed to parse the session version"), err)
+		}
+
+		return int32(version), nil
+	}
+
+	if !errors.Is(err, memcache.ErrCacheMiss) {
+		return 0, err
+	}
+
+	version, err := versionSource.SessionVersion(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	// Add does not replace a newer version set by SetSessionVersion since the version was read.
+	err = ms.memcachedClient.Add(&memcache.Item{Key: key, Value: []byte(strconv.FormatInt(int64(version), 10)), Expiration: DefaultExpirationTime})
+	if err != nil && !errors.Is(err, memcache.ErrNotStored) {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// CreatePending saves the value of a session which is created once the user passes the second factor,
+// and returns the ID of the pending sign-in.
+func (ms *MemcachedStore) CreatePending(value []byte) (string, error) {
+	pendingID := generateSessionID()
+
+	err := ms.memcachedClient.Add(&memcache.Item{Key: pendingAttemptsKeyPrefix + pendingID, Value: []byte("0"), Expiration: PendingExpirationTime})
+	if err != nil {
+		return "", err
+	}
+
+	err = ms.memcachedClient.Add(&memcache.Item{Key: pendingKeyPrefix + pendingID, Value: value, Expiration: PendingExpirationTime})
+	if err != nil {
+		return "", err
+	}
+
+	return pendingID, nil
+}
+
+// GetPending fetches the value of a pending sign-in. It returns ErrPendingNotFound
+// for IDs which do not exist, have expired or are not valid keys.
+func (ms *MemcachedStore) GetPending(pendingID string) ([]byte, error) {
+	item, err := ms.memcachedClient.Get(pendingKeyPrefix + pendingID)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) || errors.Is(err, memcache.ErrMalformedKey) {
+			return nil, ErrPendingNotFound
+		}
+
+		return nil, err
+	}
+
+	return item.Value, nil
+}
+
+// CountPendingAttempt counts an attempt to pass the second factor of a pending sign-in before its code is verified
+// and returns the number of attempts so far.
+func (ms *MemcachedStore) CountPendingAttempt(pendingID string) (uint64, error) {
+	attempts, err := ms.memcachedClient.Increment(pendingAttemptsKeyPrefix+pendingID, 1)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) || errors.Is(err, memcache.ErrMalformedKey) {
+			return 0, ErrPendingNotFound
+		}
+
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+// DeletePending deletes a pending sign-in from memcached.
+func (ms *MemcachedStore) DeletePending(pendingID string) {
+	_ = ms.memcachedClient.Delete(pendingKeyPrefix + pendingID)
+	_ = ms.memcachedClient.Delete(pendingAttemptsKeyPrefix + pendingID)
+}
+
+// SaveChallenge saves the challenge of a passkey ceremony with the user it was created for,
+// or with uuid.Nil for a sign-in, when the user is not known yet.
+func (ms *MemcachedStore) SaveChallenge(challenge []byte, userId uuid.UUID) error {
+	return ms.memcachedClient.Add(&memcache.Item{Key: challengeKey(challenge), Value: userId[:], Expiration: ChallengeExpirationTime})
+}
+
+// TakeChallenge deletes the challenge of a passkey ceremony, so it cannot be used twice,
+// and returns the user it was created for. It returns ErrChallengeNotFound for unknown challenges.
+func (ms *MemcachedStore) TakeChallenge(challenge []byte) (uuid.UUID, error) {
+	key := challengeKey(challenge)
+
+	item, err := ms.memcachedClient.Get(key)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) || errors.Is(err, memcache.ErrMalformedKey) {
+			return uuid.UUID{}, ErrChallengeNotFound
+		}
+
+		return uuid.UUID{}, err
+	}
+
+	// Only the request which deletes the challenge may use it.
+	if err := ms.memcachedClient.Delete(key); err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return uuid.UUID{}, ErrChallengeNotFound
+		}
+
+		return uuid.UUID{}, err
+	}
+
+	return uuid.FromBytes(item.Value)
+}
+
+// challengeKey returns the memcached key of a challenge. Challenges are random bytes, so they are encoded to be valid keys.
+func challengeKey(challenge []byte) string {
+	return challengeKeyPrefix + base64.RawURLEncoding.EncodeToString(challenge)
+}
+
 // DeleteCookieFromClient deletes a session cookie from a client's browser.
 func deleteCookieFromClient(c echo.Context, sessionCookieName string) {
 	cookie := http.Cookie{
@@ -0,0 +1,90 @@
+package session_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "email is not verified",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7()), UnverifiedEmail: true},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "email is verified",
+			session:        &session.Session{UserID: uuid.Must(uuid.NewV7())},
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			e.POST("/", func(c echo.Context) error {
+				return c.NoContent(http.StatusNoContent)
+			}, session.RequireVerifiedEmail)
+
+			rec := httptest.NewRecorder()
+
+			// when
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestSessionVersion(t *testing.T) {
+	t.Run("session created before sessions had versions has the first version", func(t *testing.T) {
+		// given
+		storedSession := `{"user_id": "0194b341-6797-736a-9a98-474d08025925", "email": "user@mail.com", "measurement_system": ""}`
+
+		// when
+		var s session.Session
+		err := json.Unmarshal([]byte(storedSession), &s)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, s.SessionVersion)
+	})
+
+	t.Run("session version is stored", func(t *testing.T) {
+		// given
+		s := session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com", SessionVersion: 3}
+
+		// when
+		storedSession, err := json.Marshal(s)
+		require.NoError(t, err)
+
+		var decoded session.Session
+		err = json.Unmarshal(storedSession, &decoded)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, s, decoded)
+	})
+}
@@ -1,7 +1,16 @@
 package shared
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"mime"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -17,3 +26,96 @@ func ValidateJSONContentType(c echo.Context) error {
 
 	return nil
 }
+
+// ValidateMultipartFormContentType accepts only requests with a multipart form body, like file uploads.
+// Unlike JSON, the content type always has parameters, because it carries the boundary of the parts.
+func ValidateMultipartFormContentType(c echo.Context) error {
+	mediaType, params, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+
+	if err != nil || mediaType != echo.MIMEMultipartForm || params["boundary"] == "" {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, CommonResponse{
+			Message: "Only 'multipart/form-data' content type is allowed",
+		})
+	}
+
+	return nil
+}
+
+// TooManyRequests returns 429 with the message and tells the client how long to wait in the Retry-After header.
+func TooManyRequests(c echo.Context, wait time.Duration, message string) error {
+	c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	return echo.NewHTTPError(http.StatusTooManyRequests, CommonResponse{Message: message})
+}
+
+// EncodeCursor encodes the position of the last returned row into an opaque cursor for keyset pagination.
+func EncodeCursor(position any) (string, error) {
+	encodedPosition, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(encodedPosition), nil
+}
+
+// DecodeCursor decodes a cursor created by EncodeCursor into position.
+// It returns a 400 HTTP error when the cursor has been malformed.
+func DecodeCursor(cursor string, position any) error {
+	decodedCursor, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, CommonResponse{Message: "the received cursor is not valid"})
+	}
+
+	if err := json.Unmarshal(decodedCursor, position); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, CommonResponse{Message: "the received cursor is not valid"})
+	}
+
+	return nil
+}
+
+// PageLimit returns how many rows to fetch for a page of the given size. It is one more row than requested,
+// so Paginate can find out if there is a next page.
+func PageLimit(size int32) int32 {
+	return size + 1
+}
+
+// Paginate trims rows which were fetched with one extra row to find out if there is a next page,
+// and encodes the position of the last returned row into a cursor to the next page.
+// The cursor is empty when there are no more rows.
+func Paginate[T any](rows []T, limit int32, position func(last T) any) ([]T, string, error) {
+	if len(rows) <= int(limit) {
+		return rows, "", nil
+	}
+
+	rows = rows[:limit]
+
+	encodedCursor, err := EncodeCursor(position(rows[len(rows)-1]))
+	if err != nil {
+		return nil, "", errors.Join(errors.New("failed to encode a cursor"), err)
+	}
+
+	return rows, encodedCursor, nil
+}
+
+// secretTokenLength is the number of random bytes in a token created by GenerateSecretToken.
+const secretTokenLength = 32
+
+// GenerateSecretToken creates a random URL-safe token, like the ones sent in links, and its hash.
+// Only the hash should be stored, so a leaked database does not leak usable tokens.
+func GenerateSecretToken() (string, []byte, error) {
+	buf := make([]byte, secretTokenLength)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	return token, HashSecretToken(token), nil
+}
+
+// HashSecretToken returns the hash of a token created by GenerateSecretToken, to look up its stored copy.
+func HashSecretToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+
+	return hash[:]
+}
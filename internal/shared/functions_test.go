@@ -0,0 +1,51 @@
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	type position struct {
+		ID int `json:"id"`
+	}
+
+	testCases := []struct {
+		name       string
+		rows       []int
+		wantRows   []int
+		wantCursor bool
+	}{
+		{name: "no rows", rows: []int{}, wantRows: []int{}},
+		{name: "fewer rows than the limit", rows: []int{1, 2}, wantRows: []int{1, 2}},
+		{name: "as many rows as the limit", rows: []int{1, 2, 3}, wantRows: []int{1, 2, 3}},
+		{name: "one extra row", rows: []int{1, 2, 3, 4}, wantRows: []int{1, 2, 3}, wantCursor: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			rows, cursor, err := shared.Paginate(tc.rows, 3, func(last int) any {
+				return position{ID: last}
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRows, rows)
+
+			if !tc.wantCursor {
+				assert.Empty(t, cursor)
+				return
+			}
+
+			var decoded position
+			require.NoError(t, shared.DecodeCursor(cursor, &decoded))
+			assert.Equal(t, 3, decoded.ID)
+		})
+	}
+}
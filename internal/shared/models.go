@@ -7,3 +7,15 @@ type CommonResponse struct {
 type DataResponse[T any] struct {
 	Data T `json:"data"`
 }
+
+// Paging describes how to fetch the next page of a paginated resource.
+type Paging struct {
+	// NextCursor is empty when there are no more pages to fetch.
+	NextCursor string `json:"next_cursor" example:"eyJzIjoiY3JlYXRlZF9hdCJ9"`
+	Limit      int32  `json:"limit" example:"20"`
+}
+
+type PagedDataResponse[T any] struct {
+	Data   []T    `json:"data"`
+	Paging Paging `json:"paging"`
+}
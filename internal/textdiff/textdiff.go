@@ -0,0 +1,163 @@
+// Package textdiff compares texts word by word or line by line.
+package textdiff
+
+import "strings"
+
+// Operation tells how a segment of text changed between the old and the new text.
+type Operation string
+
+const (
+	Equal  Operation = "equal"
+	Insert Operation = "insert"
+	Delete Operation = "delete"
+)
+
+// Segment is a run of words or lines with the same operation.
+type Segment struct {
+	Operation Operation `json:"op" example:"equal"`
+	Text      string    `json:"text" example:"Melt the chocolate"`
+}
+
+// maxTableSize bounds the memory used to align the changed middle parts of texts.
+// Texts which differ in more words or lines are reported as fully replaced.
+const maxTableSize = 1 << 20
+
+// Words compares the words of two texts and returns the segments which turn the old text into the new one.
+// Whitespace is not compared, words in segments are joined with single spaces.
+func Words(oldText, newText string) []Segment {
+	return diff(strings.Fields(oldText), strings.Fields(newText), " ")
+}
+
+// Lines compares the lines of two texts and returns the segments which turn the old text into the new one.
+// Trailing whitespace of lines is not compared, lines in segments are joined with newlines.
+func Lines(oldText, newText string) []Segment {
+	return diff(splitLines(oldText), splitLines(newText), "\n")
+}
+
+// splitLines returns the lines of a text without line endings. An empty text has no lines.
+func splitLines(text string) []string {
+	text = strings.TrimRight(text, "\r\n")
+	if text == "" {
+		return nil
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	return lines
+}
+
+// diff aligns two sequences of tokens and returns the segments, joining tokens with the separator.
+func diff(oldTokens, newTokens []string, separator string) []Segment {
+	// Common prefix and suffix are cheap to find and usually make the rest small.
+	prefix := 0
+	for prefix < len(oldTokens) && prefix < len(newTokens) && oldTokens[prefix] == newTokens[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(oldTokens)-prefix && suffix < len(newTokens)-prefix &&
+		oldTokens[len(oldTokens)-1-suffix] == newTokens[len(newTokens)-1-suffix] {
+		suffix++
+	}
+
+	b := builder{separator: separator}
+
+	b.add(Equal, oldTokens[:prefix]...)
+	b.alignMiddle(oldTokens[prefix:len(oldTokens)-suffix], newTokens[prefix:len(newTokens)-suffix])
+	b.add(Equal, oldTokens[len(oldTokens)-suffix:]...)
+
+	return b.segments
+}
+
+// Similarity returns the share of words the texts have in common, from 0 for different texts to 1 for equal ones.
+func Similarity(segments []Segment) float64 {
+	var equalWords, allWords int
+
+	for _, segment := range segments {
+		words := len(strings.Fields(segment.Text))
+
+		if segment.Operation == Equal {
+			// Equal words are a part of both texts.
+			equalWords += 2 * words
+			allWords += 2 * words
+		} else {
+			allWords += words
+		}
+	}
+
+	if allWords == 0 {
+		return 1
+	}
+
+	return float64(equalWords) / float64(allWords)
+}
+
+type builder struct {
+	segments  []Segment
+	separator string
+}
+
+// add appends tokens to the last segment if it has the same operation.
+func (b *builder) add(operation Operation, tokens ...string) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	text := strings.Join(tokens, b.separator)
+
+	if last := len(b.segments) - 1; last >= 0 && b.segments[last].Operation == operation {
+		b.segments[last].Text += b.separator + text
+		return
+	}
+
+	b.segments = append(b.segments, Segment{Operation: operation, Text: text})
+}
+
+// alignMiddle finds the longest common subsequence of tokens and emits the tokens around it as changes.
+func (b *builder) alignMiddle(oldWords, newWords []string) {
+	if len(oldWords)*len(newWords) > maxTableSize {
+		b.add(Delete, oldWords...)
+		b.add(Insert, newWords...)
+
+		return
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of oldWords[i:] and newWords[j:].
+	lcs := make([][]int32, len(oldWords)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(newWords)+1)
+	}
+
+	for i := len(oldWords) - 1; i >= 0; i-- {
+		for j := len(newWords) - 1; j >= 0; j-- {
+			if oldWords[i] == newWords[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+
+	for i < len(oldWords) && j < len(newWords) {
+		switch {
+		case oldWords[i] == newWords[j]:
+			b.add(Equal, oldWords[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			b.add(Delete, oldWords[i])
+			i++
+		default:
+			b.add(Insert, newWords[j])
+			j++
+		}
+	}
+
+	b.add(Delete, oldWords[i:]...)
+	b.add(Insert, newWords[j:]...)
+}
@@ -0,0 +1,146 @@
+package textdiff_test
+
+import (
+	"testing"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/textdiff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	testCases := []struct {
+		name    string
+		oldText string
+		newText string
+		want    []textdiff.Segment
+	}{
+		{
+			name:    "equal texts",
+			oldText: "Melt the chocolate.",
+			newText: "Melt  the chocolate.",
+			want:    []textdiff.Segment{{Operation: textdiff.Equal, Text: "Melt the chocolate."}},
+		},
+		{
+			name:    "replaced word",
+			oldText: "Melt the dark chocolate slowly.",
+			newText: "Melt the milk chocolate slowly.",
+			want: []textdiff.Segment{
+				{Operation: textdiff.Equal, Text: "Melt the"},
+				{Operation: textdiff.Delete, Text: "dark"},
+				{Operation: textdiff.Insert, Text: "milk"},
+				{Operation: textdiff.Equal, Text: "chocolate slowly."},
+			},
+		},
+		{
+			name:    "inserted and deleted words",
+			oldText: "Mix flour and sugar, then bake.",
+			newText: "Mix flour and brown sugar, then bake for 20 minutes.",
+			want: []textdiff.Segment{
+				{Operation: textdiff.Equal, Text: "Mix flour and"},
+				{Operation: textdiff.Insert, Text: "brown"},
+				{Operation: textdiff.Equal, Text: "sugar, then"},
+				{Operation: textdiff.Delete, Text: "bake."},
+				{Operation: textdiff.Insert, Text: "bake for 20 minutes."},
+			},
+		},
+		{
+			name:    "empty old text",
+			oldText: "",
+			newText: "Bake.",
+			want:    []textdiff.Segment{{Operation: textdiff.Insert, Text: "Bake."}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := textdiff.Words(tc.oldText, tc.newText)
+
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	testCases := []struct {
+		name    string
+		oldText string
+		newText string
+		want    float64
+	}{
+		{name: "equal texts", oldText: "Melt the chocolate.", newText: "Melt the chocolate.", want: 1},
+		{name: "different texts", oldText: "Melt the chocolate.", newText: "Boil water.", want: 0},
+		{name: "half of the words in common", oldText: "Melt the dark chocolate", newText: "Melt the white sugar", want: 0.5},
+		{name: "empty texts", oldText: "", newText: "", want: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := textdiff.Similarity(textdiff.Words(tc.oldText, tc.newText))
+
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		name    string
+		oldText string
+		newText string
+		want    []textdiff.Segment
+	}{
+		{
+			name:    "equal texts",
+			oldText: "Melt the chocolate.\nAdd eggs.\n",
+			newText: "Melt the chocolate.  \nAdd eggs.",
+			want:    []textdiff.Segment{{Operation: textdiff.Equal, Text: "Melt the chocolate.\nAdd eggs."}},
+		},
+		{
+			name:    "changed line",
+			oldText: "Melt the chocolate.\nAdd 2 eggs.\nBake.",
+			newText: "Melt the chocolate.\nAdd 3 eggs.\nBake.",
+			want: []textdiff.Segment{
+				{Operation: textdiff.Equal, Text: "Melt the chocolate."},
+				{Operation: textdiff.Delete, Text: "Add 2 eggs."},
+				{Operation: textdiff.Insert, Text: "Add 3 eggs."},
+				{Operation: textdiff.Equal, Text: "Bake."},
+			},
+		},
+		{
+			name:    "inserted lines",
+			oldText: "Melt the chocolate.\nBake.",
+			newText: "Melt the chocolate.\nAdd sugar.\nAdd eggs.\nBake.",
+			want: []textdiff.Segment{
+				{Operation: textdiff.Equal, Text: "Melt the chocolate."},
+				{Operation: textdiff.Insert, Text: "Add sugar.\nAdd eggs."},
+				{Operation: textdiff.Equal, Text: "Bake."},
+			},
+		},
+		{
+			name:    "empty new text",
+			oldText: "Bake.",
+			newText: "",
+			want:    []textdiff.Segment{{Operation: textdiff.Delete, Text: "Bake."}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := textdiff.Lines(tc.oldText, tc.newText)
+
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
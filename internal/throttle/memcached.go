@@ -0,0 +1,129 @@
+package throttle
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// lockKeySuffix is appended to a key to store its lock next to its failures.
+const lockKeySuffix = "_lock"
+
+// maxIncrementRetries limits the retries of creating a counter which another request has created meanwhile.
+const maxIncrementRetries = 3
+
+// memcachedStore keeps failures and locks in memcached, so they are shared by every instance of the app.
+type memcachedStore struct {
+	memcachedClient *memcache.Client
+}
+
+// NewMemcached returns a store which keeps failures and locks in memcached.
+func NewMemcached(memcachedClient *memcache.Client) *memcachedStore {
+	return &memcachedStore{memcachedClient: memcachedClient}
+}
+
+func (s *memcachedStore) Increment(key string, window time.Duration) (uint64, error) {
+	for range maxIncrementRetries {
+		failures, err := s.memcachedClient.Increment(key, 1)
+		if err == nil {
+			return failures, nil
+		}
+
+		if !errors.Is(err, memcache.ErrCacheMiss) {
+			return 0, err
+		}
+
+		// Add keeps the expiration of the counter from the first failure, Increment does not change it.
+		err = s.memcachedClient.Add(&memcache.Item{Key: key, Value: []byte("1"), Expiration: expirationSeconds(window)})
+		if err == nil {
+			return 1, nil
+		}
+
+		if !errors.Is(err, memcache.ErrNotStored) {
+			return 0, err
+		}
+	}
+
+	return 0, errors.New("failed to count a failure changed concurrently")
+}
+
+func (s *memcachedStore) Decrement(key string) error {
+	// Memcached does not decrement below zero, and a missing counter has expired with the failures it counted.
+	if _, err := s.memcachedClient.Decrement(key, 1); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *memcachedStore) Count(key string) (uint64, error) {
+	item, err := s.memcachedClient.Get(key)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	// Memcached pads a decremented counter with spaces instead of shortening it.
+	failures, err := strconv.ParseUint(strings.TrimSpace(string(item.Value)), 10, 64)
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to parse the number of failures"), err)
+	}
+
+	return failures, nil
+}
+
+func (s *memcachedStore) Lock(key string, until time.Time) error {
+	duration := time.Until(until)
+	if duration <= 0 {
+		return nil
+	}
+
+	return s.memcachedClient.Set(&memcache.Item{
+		Key:        key + lockKeySuffix,
+		Value:      []byte(strconv.FormatInt(until.UnixMilli(), 10)),
+		Expiration: expirationSeconds(duration),
+	})
+}
+
+func (s *memcachedStore) LockedUntil(key string) (time.Time, error) {
+	item, err := s.memcachedClient.Get(key + lockKeySuffix)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return time.Time{}, nil
+		}
+
+		return time.Time{}, err
+	}
+
+	untilMilli, err := strconv.ParseInt(string(item.Value), 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Join(errors.New("failed to parse the end of a lock"), err)
+	}
+
+	return time.UnixMilli(untilMilli), nil
+}
+
+func (s *memcachedStore) Reset(key string) error {
+	for _, k := range []string{key, key + lockKeySuffix} {
+		if err := s.memcachedClient.Delete(k); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expirationSeconds rounds a duration up to whole seconds, the precision of memcached expirations.
+// Memcached reads expirations longer than 30 days as unix times, so longer durations are capped.
+func expirationSeconds(d time.Duration) int32 {
+	const maxRelativeExpiration = 30 * 24 * 60 * 60
+
+	return int32(min(math.Ceil(d.Seconds()), maxRelativeExpiration))
+}
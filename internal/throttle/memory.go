@@ -0,0 +1,131 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+// memoryStore keeps failures and locks in the memory of the process. It is meant for tests
+// and for running a single instance of the app, because instances do not share it.
+type memoryStore struct {
+	mu        sync.Mutex
+	failures  map[string]memoryCounter
+	locks     map[string]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// memorySweepInterval is how often expired failures and locks are deleted.
+const memorySweepInterval = time.Minute
+
+type memoryCounter struct {
+	count     uint64
+	expiresAt time.Time
+}
+
+// NewMemory returns a store which keeps failures and locks in memory.
+func NewMemory() *memoryStore {
+	return &memoryStore{
+		failures: make(map[string]memoryCounter),
+		locks:    make(map[string]time.Time),
+		now:      time.Now,
+	}
+}
+
+func (s *memoryStore) Increment(key string, window time.Duration) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.deleteExpired(now)
+
+	counter, ok := s.failures[key]
+	if !ok || !counter.expiresAt.After(now) {
+		counter = memoryCounter{expiresAt: now.Add(window)}
+	}
+
+	counter.count++
+	s.failures[key] = counter
+
+	return counter.count, nil
+}
+
+func (s *memoryStore) Decrement(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.failures[key]
+	if !ok || counter.count == 0 {
+		return nil
+	}
+
+	counter.count--
+	s.failures[key] = counter
+
+	return nil
+}
+
+func (s *memoryStore) Count(key string) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.failures[key]
+	if !ok || !counter.expiresAt.After(s.now()) {
+		return 0, nil
+	}
+
+	return counter.count, nil
+}
+
+func (s *memoryStore) Lock(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locks[key] = until
+
+	return nil
+}
+
+func (s *memoryStore) LockedUntil(key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until, ok := s.locks[key]
+	if !ok || !until.After(s.now()) {
+		return time.Time{}, nil
+	}
+
+	return until, nil
+}
+
+func (s *memoryStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, key)
+	delete(s.locks, key)
+
+	return nil
+}
+
+// deleteExpired forgets failures past their window and locks which have ended, so the maps do not grow forever.
+// The maps are swept at most once per memorySweepInterval.
+func (s *memoryStore) deleteExpired(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+
+	s.lastSweep = now
+
+	for key, counter := range s.failures {
+		if !counter.expiresAt.After(now) {
+			delete(s.failures, key)
+		}
+	}
+
+	for key, until := range s.locks {
+		if !until.After(now) {
+			delete(s.locks, key)
+		}
+	}
+}
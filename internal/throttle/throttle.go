@@ -0,0 +1,171 @@
+// Package throttle slows down guessing of passwords. It counts failed attempts of keys, like accounts
+// or IP addresses, makes them wait longer after every failure and locks them out for a while after too many.
+package throttle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Store keeps the failures and locks of keys. Failures expire after a window, so old ones are forgotten.
+type Store interface {
+	// Increment counts a failure of the key and returns the number of failures within the window,
+	// which starts with the first failure.
+	Increment(key string, window time.Duration) (uint64, error)
+	// Decrement takes back a failure counted by Increment.
+	Decrement(key string) error
+	// Count returns the number of failures of the key within the window.
+	Count(key string) (uint64, error)
+	// Lock blocks the key until the given time.
+	Lock(key string, until time.Time) error
+	// LockedUntil returns the end of the lock of the key, or the zero time when the key is not locked.
+	LockedUntil(key string) (time.Time, error)
+	// Reset forgets the failures and the lock of the key.
+	Reset(key string) error
+}
+
+// Policy describes how long a key waits after its failures.
+type Policy struct {
+	// FreeAttempts is the number of failures which do not delay the next attempt.
+	FreeAttempts uint64
+	// BaseDelay is the delay after the first failure past the free ones, which doubles with every next failure.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay until the key is locked out.
+	MaxDelay time.Duration
+	// LockoutAttempts is the number of failures which lock the key out for LockoutDuration, zero never locks it out.
+	LockoutAttempts uint64
+	LockoutDuration time.Duration
+	// Window is how long failures are remembered, counting from the first one.
+	Window time.Duration
+}
+
+// Delay returns how long a key waits after the given number of failures.
+func (p Policy) Delay(failures uint64) time.Duration {
+	if p.LockoutAttempts > 0 && failures >= p.LockoutAttempts {
+		return p.LockoutDuration
+	}
+
+	if failures <= p.FreeAttempts {
+		return 0
+	}
+
+	// Shifting by 63 or more bits overflows, and so does a large base delay shifted by fewer.
+	exponent := failures - p.FreeAttempts - 1
+	if exponent >= 63 || p.BaseDelay > p.MaxDelay>>exponent {
+		return p.MaxDelay
+	}
+
+	return p.BaseDelay << exponent
+}
+
+// Limiter throttles the attempts of keys of one kind, like accounts, according to a policy.
+type Limiter struct {
+	store  Store
+	prefix string
+	policy Policy
+	now    func() time.Time
+}
+
+// New returns a limiter whose keys are stored with the name as a prefix, so limiters of different kinds can share a store.
+func New(store Store, name string, policy Policy) *Limiter {
+	return &Limiter{
+		store:  store,
+		prefix: "throttle_" + name + "_",
+		policy: policy,
+		now:    time.Now,
+	}
+}
+
+// Wait returns how long the key has to wait before its next attempt, zero when it may try now.
+func (l *Limiter) Wait(key string) (time.Duration, error) {
+	lockedUntil, err := l.store.LockedUntil(l.storeKey(key))
+	if err != nil {
+		return 0, err
+	}
+
+	return max(lockedUntil.Sub(l.now()), 0), nil
+}
+
+// Reserve counts an attempt of the key as failed before it is verified and returns how long the key has to wait
+// instead, zero when the attempt may go on. Counting it first means concurrent attempts cannot all pass the check
+// before any of them fails, so no more attempts than LockoutAttempts are let through.
+// An attempt which goes on has to be followed by Fail, Release or Reset.
+func (l *Limiter) Reserve(key string) (time.Duration, error) {
+	storeKey := l.storeKey(key)
+
+	attempts, err := l.store.Increment(storeKey, l.policy.Window)
+	if err != nil {
+		return 0, err
+	}
+
+	// The lock is checked after the attempt is counted, so an attempt counted just before a lockout
+	// reset the failures still sees the lock.
+	wait, err := l.Wait(key)
+	if err != nil {
+		return 0, err
+	}
+
+	// The attempts reserved before this one have not locked the key yet while they are being verified.
+	if wait == 0 && l.policy.LockoutAttempts > 0 && attempts > l.policy.LockoutAttempts {
+		wait = l.policy.LockoutDuration
+	}
+
+	if wait == 0 {
+		return 0, nil
+	}
+
+	// A rejected attempt is not verified, so it does not count.
+	if err := l.store.Decrement(storeKey); err != nil {
+		return 0, err
+	}
+
+	return wait, nil
+}
+
+// Fail locks the key for the delay after its failures, once a reserved attempt has failed.
+// A lockout forgets the failures, so the key starts over once the lockout ends.
+// It returns how long the key has to wait before the next attempt.
+func (l *Limiter) Fail(key string) (time.Duration, error) {
+	storeKey := l.storeKey(key)
+
+	failures, err := l.store.Count(storeKey)
+	if err != nil {
+		return 0, err
+	}
+
+	delay := l.policy.Delay(failures)
+	if delay == 0 {
+		return 0, nil
+	}
+
+	if l.policy.LockoutAttempts > 0 && failures >= l.policy.LockoutAttempts {
+		if err := l.store.Reset(storeKey); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := l.store.Lock(storeKey, l.now().Add(delay)); err != nil {
+		return 0, err
+	}
+
+	return delay, nil
+}
+
+// Release takes back a reserved attempt which has not failed, e.g. because it could not be verified.
+// Other failures of the key are kept.
+func (l *Limiter) Release(key string) error {
+	return l.store.Decrement(l.storeKey(key))
+}
+
+// Reset forgets the failures of the key, e.g. once it has succeeded.
+func (l *Limiter) Reset(key string) error {
+	return l.store.Reset(l.storeKey(key))
+}
+
+// storeKey hashes the key, so keys of any length and characters, like emails, are valid memcached keys
+// and are not stored in plain text.
+func (l *Limiter) storeKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return l.prefix + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,262 @@
+package throttle
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = Policy{
+	FreeAttempts:    3,
+	BaseDelay:       time.Second,
+	MaxDelay:        time.Minute,
+	LockoutAttempts: 10,
+	LockoutDuration: 15 * time.Minute,
+	Window:          time.Hour,
+}
+
+func TestPolicyDelay(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   Policy
+		failures uint64
+		want     time.Duration
+	}{
+		{name: "first failure", policy: testPolicy, failures: 1, want: 0},
+		{name: "last free failure", policy: testPolicy, failures: 3, want: 0},
+		{name: "first delayed failure", policy: testPolicy, failures: 4, want: time.Second},
+		{name: "delay doubles", policy: testPolicy, failures: 6, want: 4 * time.Second},
+		{name: "delay grows up to the cap", policy: testPolicy, failures: 9, want: 32 * time.Second},
+		{name: "lockout", policy: testPolicy, failures: 10, want: 15 * time.Minute},
+		{name: "failures after lockout", policy: testPolicy, failures: 11, want: 15 * time.Minute},
+		{
+			name:     "huge number of failures without lockout",
+			policy:   Policy{BaseDelay: time.Second, MaxDelay: time.Minute},
+			failures: 1 << 40,
+			want:     time.Minute,
+		},
+		{
+			name:     "delay just over the cap",
+			policy:   Policy{BaseDelay: time.Second, MaxDelay: time.Minute},
+			failures: 7,
+			want:     time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			delay := tc.policy.Delay(tc.failures)
+
+			// then
+			assert.Equal(t, tc.want, delay)
+		})
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	// given
+	now := time.Date(2025, 6, 25, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	store := NewMemory()
+	store.now = clock
+
+	limiter := New(store, "account", testPolicy)
+	limiter.now = clock
+
+	// when
+	for range testPolicy.FreeAttempts {
+		delay, err := reserveAndFail(limiter, "user@mail.com")
+		require.NoError(t, err)
+		assert.Zero(t, delay)
+	}
+
+	// then
+	wait, err := limiter.Wait("user@mail.com")
+	require.NoError(t, err)
+	assert.Zero(t, wait, "free failures should not delay the next attempt")
+
+	// when
+	delay, err := reserveAndFail(limiter, "user@mail.com")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, delay)
+
+	wait, err = limiter.Wait("user@mail.com")
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, wait)
+
+	wait, err = limiter.Wait("another@mail.com")
+	require.NoError(t, err)
+	assert.Zero(t, wait, "failures of one key should not delay another")
+
+	// when
+	now = now.Add(time.Second)
+
+	// then
+	wait, err = limiter.Wait("user@mail.com")
+	require.NoError(t, err)
+	assert.Zero(t, wait, "the delay should end")
+
+	// when
+	require.NoError(t, limiter.Reset("user@mail.com"))
+
+	// then
+	delay, err = reserveAndFail(limiter, "user@mail.com")
+	require.NoError(t, err)
+	assert.Zero(t, delay, "reset should forget the failures")
+}
+
+func TestLimiterLockout(t *testing.T) {
+	// given
+	now := time.Date(2025, 6, 25, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	store := NewMemory()
+	store.now = clock
+
+	limiter := New(store, "ip", testPolicy)
+	limiter.now = clock
+
+	// when
+	var delay time.Duration
+	for range testPolicy.LockoutAttempts {
+		var err error
+		delay, err = reserveAndFail(limiter, "192.0.2.1")
+		require.NoError(t, err)
+
+		// A locked key cannot attempt again, so the clock waits the delay out.
+		if delay < testPolicy.LockoutDuration {
+			now = now.Add(delay)
+		}
+	}
+
+	// then
+	assert.Equal(t, testPolicy.LockoutDuration, delay)
+
+	now = now.Add(10 * time.Minute)
+
+	wait, err := limiter.Wait("192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, wait)
+
+	// when
+	now = now.Add(testPolicy.Window)
+
+	// then
+	wait, err = limiter.Wait("192.0.2.1")
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+
+	delay, err = reserveAndFail(limiter, "192.0.2.1")
+	require.NoError(t, err)
+	assert.Zero(t, delay, "failures should be forgotten after the window")
+}
+
+func TestLimiterLockoutEnds(t *testing.T) {
+	// given
+	now := time.Date(2025, 6, 25, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	store := NewMemory()
+	store.now = clock
+
+	limiter := New(store, "account", testPolicy)
+	limiter.now = clock
+
+	for range testPolicy.LockoutAttempts {
+		delay, err := reserveAndFail(limiter, "user@mail.com")
+		require.NoError(t, err)
+
+		if delay < testPolicy.LockoutDuration {
+			now = now.Add(delay)
+		}
+	}
+
+	now = now.Add(10 * time.Minute)
+
+	wait, err := limiter.Reserve("user@mail.com")
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, wait, "a locked out attempt should wait for the rest of the lockout")
+
+	// when
+	now = now.Add(5 * time.Minute)
+
+	// then
+	wait, err = limiter.Reserve("user@mail.com")
+	require.NoError(t, err)
+	assert.Zero(t, wait, "sign-in should be allowed again after the lockout")
+
+	delay, err := limiter.Fail("user@mail.com")
+	require.NoError(t, err)
+	assert.Zero(t, delay, "failures before the lockout should be forgotten")
+}
+
+func TestLimiterReserve(t *testing.T) {
+	// given
+	now := time.Date(2025, 6, 25, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	store := NewMemory()
+	store.now = clock
+
+	limiter := New(store, "account", testPolicy)
+	limiter.now = clock
+
+	var (
+		wg       sync.WaitGroup
+		reserved atomic.Uint64
+	)
+
+	// when
+	for range 3 * testPolicy.LockoutAttempts {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			wait, err := limiter.Reserve("user@mail.com")
+			if assert.NoError(t, err) && wait == 0 {
+				reserved.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// then
+	assert.Equal(t, testPolicy.LockoutAttempts, reserved.Load(), "concurrent attempts should not pass the lockout")
+
+	// when
+	require.NoError(t, limiter.Release("user@mail.com"))
+
+	// then
+	wait, err := limiter.Reserve("user@mail.com")
+	require.NoError(t, err)
+	assert.Zero(t, wait, "a released attempt should not count")
+
+	delay, err := limiter.Fail("user@mail.com")
+	require.NoError(t, err)
+	assert.Equal(t, testPolicy.LockoutDuration, delay)
+
+	wait, err = limiter.Reserve("user@mail.com")
+	require.NoError(t, err)
+	assert.Equal(t, testPolicy.LockoutDuration, wait)
+}
+
+// reserveAndFail makes a failed attempt of the key.
+func reserveAndFail(limiter *Limiter, key string) (time.Duration, error) {
+	if _, err := limiter.Reserve(key); err != nil {
+		return 0, err
+	}
+
+	return limiter.Fail(key)
+}
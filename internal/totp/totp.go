@@ -0,0 +1,111 @@
+// Package totp implements time-based one-time passwords (RFC 6238), which authenticator apps generate
+// as the second factor of signing in.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits is the length of a code.
+	Digits = 6
+	// Period is how long a code is valid.
+	Period = 30 * time.Second
+	// skew is the number of periods before and after the current one whose codes are accepted as well,
+	// so a code still works when the clock of the phone is off or the user typed it at the end of a period.
+	skew = 1
+	// secretLength is the number of random bytes of a secret, as recommended for HMAC-SHA1 by RFC 4226.
+	secretLength = 20
+)
+
+var (
+	// ErrInvalidSecret is returned for secrets which are not valid base32.
+	ErrInvalidSecret = errors.New("totp: invalid secret")
+	// ErrInvalidCode is returned for codes which do not match any of the accepted periods.
+	ErrInvalidCode = errors.New("totp: invalid code")
+)
+
+// secretEncoding is the base32 encoding used by authenticator apps.
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret encoded as base32.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return secretEncoding.EncodeToString(secret), nil
+}
+
+// URI returns the otpauth URI of the secret, which authenticator apps read from a QR code.
+func URI(issuer, accountName, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period.Seconds())))
+
+	return "otpauth://totp/" + url.PathEscape(issuer) + ":" + url.PathEscape(accountName) + "?" + query.Encode()
+}
+
+// Counter returns the number of the period the time belongs to.
+func Counter(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// Code returns the code of the secret for the period with the counter.
+func Code(secret string, counter int64) (string, error) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", ErrInvalidSecret
+	}
+
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation from RFC 4226, section 5.3.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%1_000_000), nil
+}
+
+// Validate checks the code against the periods around the time and returns the counter of the matching period.
+// The counter lets the caller reject codes of the same or an earlier period, which have been used already.
+func Validate(secret, code string, t time.Time) (int64, error) {
+	if len(code) != Digits {
+		return 0, ErrInvalidCode
+	}
+
+	current := Counter(t)
+
+	for counter := current - skew; counter <= current+skew; counter++ {
+		expected, err := Code(secret, counter)
+		if err != nil {
+			return 0, err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter, nil
+		}
+	}
+
+	return 0, ErrInvalidCode
+}
@@ -0,0 +1,128 @@
+package totp_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The secret of the test vectors of RFC 6238, "12345678901234567890" encoded as base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// The test vectors of RFC 6238 for SHA1, truncated to the last six digits.
+func TestCode(t *testing.T) {
+	testCases := []struct {
+		unixTime int64
+		want     string
+	}{
+		{unixTime: 59, want: "287082"},
+		{unixTime: 1111111109, want: "081804"},
+		{unixTime: 1111111111, want: "050471"},
+		{unixTime: 1234567890, want: "005924"},
+		{unixTime: 2000000000, want: "279037"},
+		{unixTime: 20000000000, want: "353130"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			code, err := totp.Code(rfcSecret, totp.Counter(time.Unix(tc.unixTime, 0)))
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, code)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	counter := totp.Counter(now)
+
+	testCases := []struct {
+		name        string
+		code        string
+		wantCounter int64
+		wantErr     error
+	}{
+		{
+			name:        "code of the current period",
+			code:        "050471",
+			wantCounter: counter,
+		},
+		{
+			name:        "code of the previous period",
+			code:        mustCode(t, counter-1),
+			wantCounter: counter - 1,
+		},
+		{
+			name:        "code of the next period",
+			code:        mustCode(t, counter+1),
+			wantCounter: counter + 1,
+		},
+		{
+			name:    "code from two periods ago",
+			code:    mustCode(t, counter-2),
+			wantErr: totp.ErrInvalidCode,
+		},
+		{
+			name:    "code is too short",
+			code:    "50471",
+			wantErr: totp.ErrInvalidCode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			gotCounter, err := totp.Validate(rfcSecret, tc.code, now)
+
+			// then
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantCounter, gotCounter)
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	// when
+	secret, err := totp.GenerateSecret()
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = totp.Code(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	// when
+	uri := totp.URI("Recipe App", "user@mail.com", rfcSecret)
+
+	// then
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/Recipe App:user@mail.com", parsed.Path)
+	assert.Equal(t, rfcSecret, parsed.Query().Get("secret"))
+	assert.Equal(t, "Recipe App", parsed.Query().Get("issuer"))
+}
+
+func mustCode(t *testing.T, counter int64) string {
+	t.Helper()
+
+	code, err := totp.Code(rfcSecret, counter)
+	require.NoError(t, err)
+
+	return code
+}
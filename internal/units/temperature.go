@@ -0,0 +1,103 @@
+package units
+
+import (
+	"math"
+	"strconv"
+)
+
+// TemperatureScale is a scale of temperature used in recipes.
+type TemperatureScale int
+
+const (
+	Celsius TemperatureScale = iota + 1
+	Fahrenheit
+)
+
+// Symbol returns the symbol of the scale, "C" or "F".
+func (s TemperatureScale) Symbol() string {
+	if s == Fahrenheit {
+		return "F"
+	}
+
+	return "C"
+}
+
+// ScaleOf returns the temperature scale used by the system of measurement.
+func ScaleOf(system System) TemperatureScale {
+	if system == Metric {
+		return Celsius
+	}
+
+	return Fahrenheit
+}
+
+// ParseTemperatureScale looks up a temperature scale by its symbol or name.
+func ParseTemperatureScale(name string) (TemperatureScale, bool) {
+	switch name {
+	case "C", "c", "Celsius", "celsius", "centigrade":
+		return Celsius, true
+	case "F", "f", "Fahrenheit", "fahrenheit":
+		return Fahrenheit, true
+	default:
+		return 0, false
+	}
+}
+
+// Temperature is a temperature measured in a scale.
+type Temperature struct {
+	Degrees float64
+	Scale   TemperatureScale
+}
+
+// ovenTemperature is the lowest temperature in Celsius which is rounded the way oven settings are,
+// to tens of degrees Celsius and to 25 degrees Fahrenheit.
+const ovenTemperature = 110
+
+// ConvertTo converts the temperature to the scale.
+// Oven temperatures are rounded to the settings written in recipes, so 180°C becomes 350°F.
+func (t Temperature) ConvertTo(scale TemperatureScale) Temperature {
+	if t.Scale == scale {
+		return t
+	}
+
+	celsius := t.Degrees
+	if t.Scale == Fahrenheit {
+		celsius = (t.Degrees - 32) * 5 / 9
+	}
+
+	degrees, step := celsius, 10.0
+	if scale == Fahrenheit {
+		degrees, step = celsius*9/5+32, 25
+	}
+
+	if celsius < ovenTemperature {
+		step = 1
+	}
+
+	return Temperature{Degrees: math.Round(degrees/step) * step, Scale: scale}
+}
+
+// String formats the temperature the way it is written in recipes, e.g. "180°C".
+func (t Temperature) String() string {
+	return strconv.FormatFloat(math.Round(t.Degrees), 'f', 0, 64) + "°" + t.Scale.Symbol()
+}
+
+// gasMarks are the oven temperatures of gas marks in Celsius.
+var gasMarks = map[int]float64{
+	1: 140,
+	2: 150,
+	3: 170,
+	4: 180,
+	5: 190,
+	6: 200,
+	7: 220,
+	8: 230,
+	9: 240,
+}
+
+// GasMark returns the oven temperature of the gas mark.
+func GasMark(mark int) (Temperature, bool) {
+	celsius, ok := gasMarks[mark]
+
+	return Temperature{Degrees: celsius, Scale: Celsius}, ok
+}
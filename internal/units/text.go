@@ -0,0 +1,109 @@
+package units
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ambiguousAliases are the names of units which are too short to be told apart from other words in text,
+// e.g. "c" in "180 C".
+var ambiguousAliases = []string{"c", "t"}
+
+var (
+	quantityPattern    = `(\d+\s+\d+/\d+|\d+/\d+|\d+(?:\.\d+)?)\s*(` + textUnitPattern() + `)\b`
+	temperaturePattern = `(\d+(?:\.\d+)?)\s*(?:°\s*|degrees?\s+)(celsius|fahrenheit|centigrade|C|F)\b`
+	gasMarkPattern     = `gas\s+mark\s+(\d)\b`
+
+	measurementRegexp = regexp.MustCompile(`(?i)\b(?:` + temperaturePattern + `|` + gasMarkPattern + `|` + quantityPattern + `)`)
+)
+
+// textUnitPattern builds an alternation of the unit names recognized in text, the longest names first.
+func textUnitPattern() string {
+	names := make([]string, 0, len(aliases))
+
+	for name := range aliases {
+		if !slices.Contains(ambiguousAliases, name) {
+			names = append(names, regexp.QuoteMeta(name))
+		}
+	}
+
+	slices.SortFunc(names, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	return strings.ReplaceAll(strings.Join(names, "|"), " ", `\s+`)
+}
+
+// ConvertText converts the quantities, temperatures and gas marks written in the text to the system,
+// e.g. "Bake 2 cups of flour at 350°F" becomes "Bake 473 ml of flour at 180°C".
+// Quantities already written in the system are left as they are.
+func ConvertText(text string, system System) string {
+	return measurementRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		groups := measurementRegexp.FindStringSubmatch(match)
+
+		switch {
+		case groups[1] != "":
+			degrees, err := strconv.ParseFloat(groups[1], 64)
+			scale, ok := ParseTemperatureScale(groups[2])
+
+			if err != nil || !ok || scale == ScaleOf(system) {
+				return match
+			}
+
+			return Temperature{Degrees: degrees, Scale: scale}.ConvertTo(ScaleOf(system)).String()
+		case groups[3] != "":
+			mark, _ := strconv.Atoi(groups[3])
+
+			temperature, ok := GasMark(mark)
+			if !ok {
+				return match
+			}
+
+			return temperature.ConvertTo(ScaleOf(system)).String()
+		default:
+			amount, ok := parseAmount(groups[4])
+			unit, known := Parse(groups[5])
+
+			if !ok || !known || unit.System == system {
+				return match
+			}
+
+			return Quantity{Amount: amount, Unit: unit}.ConvertTo(system).String()
+		}
+	})
+}
+
+// parseAmount reads an amount written as a number, a fraction or a whole number with a fraction.
+func parseAmount(text string) (float64, bool) {
+	fields := strings.Fields(text)
+
+	var amount float64
+
+	for _, field := range fields {
+		numerator, denominator, isFraction := strings.Cut(field, "/")
+
+		n, err := strconv.ParseFloat(numerator, 64)
+		if err != nil {
+			return 0, false
+		}
+
+		if isFraction {
+			d, err := strconv.ParseFloat(denominator, 64)
+			if err != nil || d == 0 {
+				return 0, false
+			}
+
+			n /= d
+		}
+
+		amount += n
+	}
+
+	return amount, true
+}
@@ -0,0 +1,252 @@
+// Package units recognizes cooking units of measurement, converts quantities between them
+// and formats amounts the way they are written in recipes.
+package units
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Dimension is the physical quantity measured by a unit.
+type Dimension int
+
+const (
+	Volume Dimension = iota + 1
+	Mass
+)
+
+// System is a system of measurement.
+type System int
+
+const (
+	Metric System = iota + 1
+	USCustomary
+)
+
+// Unit is a unit of measurement recognized in recipes.
+type Unit struct {
+	// Symbol is the canonical abbreviation of the unit, e.g. "tbsp".
+	Symbol string
+	// Plural is used instead of the symbol for amounts greater than one, if it is set.
+	Plural    string
+	Dimension Dimension
+	System    System
+	// factor is the size of the unit in base units, milliliters for volume and grams for mass.
+	factor float64
+	// normalized marks units a quantity can be normalized to.
+	// Units which are rarely used in recipes are only recognized.
+	normalized bool
+	// minAmount is the smallest amount a quantity is normalized to this unit with, one if it is not set.
+	// Cooks measure 3/4 cup rather than 12 tbsp.
+	minAmount float64
+}
+
+func (u Unit) smallestAmount() float64 {
+	if u.minAmount == 0 {
+		return 1
+	}
+
+	return u.minAmount
+}
+
+var (
+	Milliliter = Unit{Symbol: "ml", Dimension: Volume, System: Metric, factor: 1, normalized: true}
+	Deciliter  = Unit{Symbol: "dl", Dimension: Volume, System: Metric, factor: 100}
+	Liter      = Unit{Symbol: "l", Dimension: Volume, System: Metric, factor: 1000, normalized: true}
+
+	Teaspoon   = Unit{Symbol: "tsp", Dimension: Volume, System: USCustomary, factor: 4.92892159375, normalized: true}
+	Tablespoon = Unit{Symbol: "tbsp", Dimension: Volume, System: USCustomary, factor: 14.78676478125, normalized: true}
+	FluidOunce = Unit{Symbol: "fl oz", Dimension: Volume, System: USCustomary, factor: 29.5735295625}
+	Cup        = Unit{Symbol: "cup", Plural: "cups", Dimension: Volume, System: USCustomary, factor: 236.5882365, normalized: true, minAmount: 0.25}
+	Pint       = Unit{Symbol: "pt", Dimension: Volume, System: USCustomary, factor: 473.176473}
+	Quart      = Unit{Symbol: "qt", Dimension: Volume, System: USCustomary, factor: 946.352946, normalized: true}
+	Gallon     = Unit{Symbol: "gal", Dimension: Volume, System: USCustomary, factor: 3785.411784, normalized: true}
+
+	Milligram = Unit{Symbol: "mg", Dimension: Mass, System: Metric, factor: 0.001}
+	Gram      = Unit{Symbol: "g", Dimension: Mass, System: Metric, factor: 1, normalized: true}
+	Kilogram  = Unit{Symbol: "kg", Dimension: Mass, System: Metric, factor: 1000, normalized: true}
+
+	Ounce = Unit{Symbol: "oz", Dimension: Mass, System: USCustomary, factor: 28.349523125, normalized: true}
+	Pound = Unit{Symbol: "lb", Dimension: Mass, System: USCustomary, factor: 453.59237, normalized: true}
+)
+
+// allUnits is the conversion table, ordered from the smallest to the largest unit of each dimension and system.
+var allUnits = []Unit{
+	Milliliter, Deciliter, Liter,
+	Teaspoon, Tablespoon, FluidOunce, Cup, Pint, Quart, Gallon,
+	Milligram, Gram, Kilogram,
+	Ounce, Pound,
+}
+
+var aliases = map[string]Unit{
+	"ml": Milliliter, "milliliter": Milliliter, "milliliters": Milliliter, "millilitre": Milliliter, "millilitres": Milliliter,
+	"dl": Deciliter, "deciliter": Deciliter, "deciliters": Deciliter, "decilitre": Deciliter, "decilitres": Deciliter,
+	"l": Liter, "liter": Liter, "liters": Liter, "litre": Liter, "litres": Liter,
+	"tsp": Teaspoon, "tsps": Teaspoon, "t": Teaspoon, "teaspoon": Teaspoon, "teaspoons": Teaspoon,
+	"tbsp": Tablespoon, "tbsps": Tablespoon, "tbs": Tablespoon, "tablespoon": Tablespoon, "tablespoons": Tablespoon,
+	"fl oz": FluidOunce, "floz": FluidOunce, "fluid ounce": FluidOunce, "fluid ounces": FluidOunce,
+	"cup": Cup, "cups": Cup, "c": Cup,
+	"pt": Pint, "pint": Pint, "pints": Pint,
+	"qt": Quart, "quart": Quart, "quarts": Quart,
+	"gal": Gallon, "gallon": Gallon, "gallons": Gallon,
+	"mg": Milligram, "milligram": Milligram, "milligrams": Milligram,
+	"g": Gram, "gr": Gram, "gram": Gram, "grams": Gram, "gramme": Gram, "grammes": Gram,
+	"kg": Kilogram, "kilogram": Kilogram, "kilograms": Kilogram,
+	"oz": Ounce, "ounce": Ounce, "ounces": Ounce,
+	"lb": Pound, "lbs": Pound, "pound": Pound, "pounds": Pound,
+}
+
+// Parse looks up a unit by its name, abbreviation or plural form.
+// Names are matched case-insensitively, except for "T" (tablespoon) and "t" (teaspoon).
+func Parse(name string) (Unit, bool) {
+	name = strings.Join(strings.Fields(strings.TrimSuffix(strings.TrimSpace(name), ".")), " ")
+
+	if name == "T" {
+		return Tablespoon, true
+	}
+
+	unit, ok := aliases[strings.ToLower(name)]
+
+	return unit, ok
+}
+
+// Name returns the symbol of the unit or its plural form, depending on the amount.
+func (u Unit) Name(amount float64) string {
+	if u.Plural != "" && amount > 1 {
+		return u.Plural
+	}
+
+	return u.Symbol
+}
+
+// Quantity is an amount measured in a unit.
+type Quantity struct {
+	Amount float64
+	Unit   Unit
+}
+
+// Scale multiplies the amount of the quantity by the ratio.
+func (q Quantity) Scale(ratio float64) Quantity {
+	return Quantity{Amount: q.Amount * ratio, Unit: q.Unit}
+}
+
+// Normalize converts the quantity to the largest unit of the same dimension and system
+// in which the amount is at least one, e.g. 48 tsp becomes 1 cup and 1500 g becomes 1.5 kg.
+// Cups are used from a quarter of a cup.
+func (q Quantity) Normalize() Quantity {
+	return q.convert(q.Unit.System)
+}
+
+// ConvertTo converts the quantity to the given system and normalizes it.
+func (q Quantity) ConvertTo(system System) Quantity {
+	return q.convert(system)
+}
+
+func (q Quantity) convert(system System) Quantity {
+	baseAmount := q.Amount * q.Unit.factor
+
+	var best Unit
+
+	for _, unit := range allUnits {
+		if unit.Dimension != q.Unit.Dimension || unit.System != system || !unit.normalized {
+			continue
+		}
+
+		// Always fall back to the smallest unit, so tiny amounts are not left unconverted.
+		if best.Symbol == "" || baseAmount/unit.factor >= unit.smallestAmount()*(1-roundingTolerance) {
+			best = unit
+		}
+	}
+
+	if best.Symbol == "" {
+		return q
+	}
+
+	return Quantity{Amount: baseAmount / best.factor, Unit: best}
+}
+
+// String formats the quantity the way it is written in recipes, e.g. "1 1/2 cups" or "1.5 kg".
+func (q Quantity) String() string {
+	return FormatAmount(q.Amount, q.Unit.System) + " " + q.Unit.Name(q.Amount)
+}
+
+// roundingTolerance is the relative error accepted when rounding amounts.
+const roundingTolerance = 0.01
+
+// fractions cooks use in US customary recipes, by their value.
+var fractions = []struct {
+	value float64
+	text  string
+}{
+	{1.0 / 8, "1/8"},
+	{1.0 / 4, "1/4"},
+	{1.0 / 3, "1/3"},
+	{3.0 / 8, "3/8"},
+	{1.0 / 2, "1/2"},
+	{5.0 / 8, "5/8"},
+	{2.0 / 3, "2/3"},
+	{3.0 / 4, "3/4"},
+	{7.0 / 8, "7/8"},
+}
+
+// FormatAmount formats the amount as a decimal number for the metric system,
+// and as a whole number with a fraction otherwise, e.g. "1 1/3".
+func FormatAmount(amount float64, system System) string {
+	if system == Metric {
+		return formatDecimal(amount)
+	}
+
+	whole, fraction := math.Modf(amount)
+
+	// Find the closest fraction, counting 0 and 1 as candidates to round to whole numbers.
+	closestValue, closestText := 0.0, ""
+	if math.Abs(1-fraction) < fraction {
+		closestValue = 1
+	}
+
+	for _, f := range fractions {
+		if math.Abs(f.value-fraction) < math.Abs(closestValue-fraction) {
+			closestValue, closestText = f.value, f.text
+		}
+	}
+
+	if closestValue == 1 {
+		whole++
+		closestText = ""
+	}
+
+	switch {
+	case whole == 0 && closestText == "":
+		// The amount is too small to be written as a fraction.
+		return formatDecimal(amount)
+	case whole == 0:
+		return closestText
+	case closestText == "":
+		return formatDecimal(whole)
+	default:
+		return formatDecimal(whole) + " " + closestText
+	}
+}
+
+// formatDecimal rounds the amount to the precision which is meaningful for its size.
+func formatDecimal(amount float64) string {
+	precision := 2
+
+	switch {
+	case amount >= 100:
+		precision = 0
+	case amount >= 10:
+		precision = 1
+	}
+
+	pow := math.Pow(10, float64(precision))
+	rounded := math.Round(amount*pow) / pow
+
+	text := strconv.FormatFloat(rounded, 'f', precision, 64)
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+
+	return text
+}
@@ -0,0 +1,181 @@
+package units_test
+
+import (
+	"testing"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/units"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		wantUnit units.Unit
+		wantOk   bool
+	}{
+		{name: "tsp", wantUnit: units.Teaspoon, wantOk: true},
+		{name: "Tablespoons", wantUnit: units.Tablespoon, wantOk: true},
+		{name: "T", wantUnit: units.Tablespoon, wantOk: true},
+		{name: "t", wantUnit: units.Teaspoon, wantOk: true},
+		{name: "fl  oz.", wantUnit: units.FluidOunce, wantOk: true},
+		{name: " KG ", wantUnit: units.Kilogram, wantOk: true},
+		{name: "pinch", wantOk: false},
+		{name: "", wantOk: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			unit, ok := units.Parse(tc.name)
+
+			// then
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantUnit, unit)
+		})
+	}
+}
+
+func TestQuantityNormalize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		quantity units.Quantity
+		want     string
+	}{
+		{name: "teaspoons to a cup", quantity: units.Quantity{Amount: 48, Unit: units.Teaspoon}, want: "1 cup"},
+		{name: "grams to kilograms", quantity: units.Quantity{Amount: 1500, Unit: units.Gram}, want: "1.5 kg"},
+		{name: "kilograms to grams", quantity: units.Quantity{Amount: 0.25, Unit: units.Kilogram}, want: "250 g"},
+		{name: "teaspoons to a tablespoon and a third", quantity: units.Quantity{Amount: 4, Unit: units.Teaspoon}, want: "1 1/3 tbsp"},
+		{name: "small amount stays in teaspoons", quantity: units.Quantity{Amount: 0.5, Unit: units.Teaspoon}, want: "1/2 tsp"},
+		{name: "tablespoons to a fraction of a cup", quantity: units.Quantity{Amount: 12, Unit: units.Tablespoon}, want: "3/4 cup"},
+		{name: "tablespoons below a quarter cup", quantity: units.Quantity{Amount: 3, Unit: units.Tablespoon}, want: "3 tbsp"},
+		{name: "fluid ounces to cups", quantity: units.Quantity{Amount: 12, Unit: units.FluidOunce}, want: "1 1/2 cups"},
+		{name: "ounces to pounds", quantity: units.Quantity{Amount: 24, Unit: units.Ounce}, want: "1 1/2 lb"},
+		{name: "deciliters to milliliters", quantity: units.Quantity{Amount: 2.5, Unit: units.Deciliter}, want: "250 ml"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := tc.quantity.Normalize().String()
+
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestQuantityConvertTo(t *testing.T) {
+	testCases := []struct {
+		name     string
+		quantity units.Quantity
+		system   units.System
+		want     string
+	}{
+		{name: "cups to milliliters", quantity: units.Quantity{Amount: 1, Unit: units.Cup}, system: units.Metric, want: "237 ml"},
+		{name: "pounds to kilograms", quantity: units.Quantity{Amount: 2.2046226, Unit: units.Pound}, system: units.Metric, want: "1 kg"},
+		{name: "grams to ounces", quantity: units.Quantity{Amount: 113.4, Unit: units.Gram}, system: units.USCustomary, want: "4 oz"},
+		{name: "liters to quarts", quantity: units.Quantity{Amount: 1, Unit: units.Liter}, system: units.USCustomary, want: "1 qt"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := tc.quantity.ConvertTo(tc.system).String()
+
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	testCases := []struct {
+		amount float64
+		system units.System
+		want   string
+	}{
+		{amount: 1.0 / 3, system: units.USCustomary, want: "1/3"},
+		{amount: 0.75, system: units.USCustomary, want: "3/4"},
+		{amount: 2.5, system: units.USCustomary, want: "2 1/2"},
+		{amount: 1.98, system: units.USCustomary, want: "2"},
+		{amount: 3, system: units.USCustomary, want: "3"},
+		{amount: 0.01, system: units.USCustomary, want: "0.01"},
+		{amount: 1.5, system: units.Metric, want: "1.5"},
+		{amount: 12.34, system: units.Metric, want: "12.3"},
+		{amount: 250.4, system: units.Metric, want: "250"},
+		{amount: 100, system: units.Metric, want: "100"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := units.FormatAmount(tc.amount, tc.system)
+
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTemperatureConvertTo(t *testing.T) {
+	testCases := []struct {
+		name        string
+		temperature units.Temperature
+		scale       units.TemperatureScale
+		want        string
+	}{
+		{name: "oven temperature to fahrenheit", temperature: units.Temperature{Degrees: 180, Scale: units.Celsius}, scale: units.Fahrenheit, want: "350°F"},
+		{name: "oven temperature to celsius", temperature: units.Temperature{Degrees: 425, Scale: units.Fahrenheit}, scale: units.Celsius, want: "220°C"},
+		{name: "boiling point is not rounded", temperature: units.Temperature{Degrees: 100, Scale: units.Celsius}, scale: units.Fahrenheit, want: "212°F"},
+		{name: "same scale", temperature: units.Temperature{Degrees: 175, Scale: units.Celsius}, scale: units.Celsius, want: "175°C"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := tc.temperature.ConvertTo(tc.scale).String()
+
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestConvertText(t *testing.T) {
+	testCases := []struct {
+		name   string
+		text   string
+		system units.System
+		want   string
+	}{
+		{name: "quantity and temperature to metric", text: "Bake 2 cups of flour at 350°F.", system: units.Metric, want: "Bake 473 ml of flour at 180°C."},
+		{name: "quantities to US customary", text: "Melt 200 g butter in 500 ml of milk.", system: units.USCustomary, want: "Melt 7 oz butter in 2 1/8 cups of milk."},
+		{name: "mixed number", text: "Add 1 1/2 cups of sugar.", system: units.Metric, want: "Add 355 ml of sugar."},
+		{name: "gas mark", text: "Preheat the oven to gas mark 4.", system: units.Metric, want: "Preheat the oven to 180°C."},
+		{name: "temperature in degrees", text: "Heat the oil to 180 degrees C.", system: units.USCustomary, want: "Heat the oil to 350°F."},
+		{name: "quantities in the same system are kept", text: "Add 3 tablespoons of oil.", system: units.USCustomary, want: "Add 3 tablespoons of oil."},
+		{name: "ambiguous units are kept", text: "Add 2 t salt and 3 eggs.", system: units.Metric, want: "Add 2 t salt and 3 eggs."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			got := units.ConvertText(tc.text, tc.system)
+
+			// then
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package user
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/webauthn"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type handler struct {
+	logger           *zap.Logger
+	profileService   profileService
+	sessionStorage   sessionStorage
+	twoFactorLimiter twoFactorLimiter
+}
+
+type profileService interface {
+	GetProfile(context.Context, uuid.UUID) (ProfileResponse, error)
+	UpdateProfile(context.Context, uuid.UUID, UpdateProfileRequest) error
+	ResendVerificationEmail(context.Context, uuid.UUID) error
+	EnrollTwoFactor(context.Context, uuid.UUID) (TwoFactorEnrollmentResponse, error)
+	ConfirmTwoFactor(context.Context, uuid.UUID, TwoFactorCodeRequest) (RecoveryCodesResponse, error)
+	DisableTwoFactor(context.Context, uuid.UUID, TwoFactorCodeRequest) error
+	BeginPasskeyRegistration(context.Context, uuid.UUID) (webauthn.CreationOptions, error)
+	FinishPasskeyRegistration(ctx context.Context, userId uuid.UUID, challenge []byte, registerPasskeyRequest RegisterPasskeyRequest) (PasskeyResponse, error)
+	ListPasskeys(context.Context, uuid.UUID) ([]PasskeyResponse, error)
+	DeletePasskey(ctx context.Context, userId, passkeyId uuid.UUID) error
+}
+
+type sessionStorage interface {
+	UpdateAllOfUser(userId uuid.UUID, update func(*session.Session)) error
+	SaveChallenge(challenge []byte, userId uuid.UUID) error
+	TakeChallenge(challenge []byte) (uuid.UUID, error)
+}
+
+// NewHandler returns the handler of the profile. Invalid codes to turn off two-factor authentication
+// are throttled per user by twoFactorLimiter.
+func NewHandler(logger *zap.Logger, profileService profileService, sessionStorage sessionStorage, twoFactorLimiter twoFactorLimiter) *handler {
+	return &handler{
+		logger:           logger,
+		profileService:   profileService,
+		sessionStorage:   sessionStorage,
+		twoFactorLimiter: twoFactorLimiter,
+	}
+}
+
+// GetProfile godoc
+//
+//	@Summary		Get the profile
+//	@Description	Get the profile and settings of the signed-in user.
+//	@Tags			profile
+//
+//	@Produce		json
+//
+//	@Success		200	{object}	shared.DataResponse[user.ProfileResponse]	"Profile fetched successfully."
+//	@Failure		401	{object}	shared.CommonResponse						"User is not signed in."
+//
+//	@Router			/api/v1/me [GET]
+func (h *handler) GetProfile(c echo.Context) error {
+	profile, err := h.profileService.GetProfile(c.Request().Context(), session.FromContext(c).UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[ProfileResponse]{Data: profile})
+}
+
+// UpdateProfile godoc
+//
+//	@Summary		Update the profile
+//	@Description	Update the settings of the signed-in user. The preferred measurement system converts quantities and temperatures of recipes.
+//	@Tags			profile
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			UpdateProfileRequest	body	user.UpdateProfileRequest	true	"Request body with the settings of the user."
+//
+//	@Success		204						"Profile updated successfully."
+//	@Failure		400						{object}	validator.ValidationErrorResponse	"Invalid data provided."
+//	@Failure		401						{object}	shared.CommonResponse				"User is not signed in."
+//
+//	@Router			/api/v1/me [PUT]
+func (h *handler) UpdateProfile(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	var requestBody = UpdateProfileRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	userId := session.FromContext(c).UserID
+
+	if err := h.profileService.UpdateProfile(c.Request().Context(), userId, requestBody); err != nil {
+		return err
+	}
+
+	// Keep the stored sessions in sync, so the new settings apply to the next requests on all devices without signing in again.
+	err := h.sessionStorage.UpdateAllOfUser(userId, func(s *session.Session) {
+		s.MeasurementSystem = requestBody.MeasurementSystem
+	})
+	if err != nil {
+		h.logger.Error("failed to update the sessions of a user", zap.String("userId", userId.String()), zap.Error(err))
+	}
+
+	h.logger.Info("successfully updated a profile", zap.String("userId", userId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ResendVerificationEmail godoc
+//
+//	@Summary		Resend the verification email
+//	@Description	Email a new link to verify the email of the signed-in user. Another email can be requested only after a while.
+//	@Tags			profile
+//
+//	@Produce		json
+//
+//	@Success		202	{object}	shared.CommonResponse	"Verification email sent successfully."
+//	@Failure		401	{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		409	{object}	shared.CommonResponse	"Email is already verified."
+//	@Failure		429	{object}	shared.CommonResponse	"Verification email has been sent recently."
+//
+//	@Router			/api/v1/me/verify-email/resend [POST]
+func (h *handler) ResendVerificationEmail(c echo.Context) error {
+	userId := session.FromContext(c).UserID
+
+	if err := h.profileService.ResendVerificationEmail(c.Request().Context(), userId); err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully sent a verification email", zap.String("userId", userId.String()))
+
+	return c.JSON(http.StatusAccepted, shared.CommonResponse{Message: "a verification email has been sent"})
+}
@@ -0,0 +1,153 @@
+package user_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mock_user "github.com/danielbukowski/recipe-app-backend/gen/_mocks/user"
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/throttle"
+	"github.com/danielbukowski/recipe-app-backend/internal/user"
+	"github.com/danielbukowski/recipe-app-backend/internal/validator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+// twoFactorPolicy lets a user send a few wrong two-factor codes and then locks them out.
+var twoFactorPolicy = throttle.Policy{
+	FreeAttempts:    2,
+	BaseDelay:       time.Second,
+	MaxDelay:        time.Second,
+	LockoutAttempts: 3,
+	LockoutDuration: 15 * time.Minute,
+	Window:          time.Hour,
+}
+
+func TestUpdateProfileHandler(t *testing.T) {
+	signedInSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com", MeasurementSystem: "metric"}
+
+	testCases := []struct {
+		name           string
+		session        *session.Session
+		requestBody    string
+		setupMocks     func(profileService *mock_user.MockProfileService, sessionStorage *mock_user.MockSessionStorage)
+		wantStatusCode int
+	}{
+		{
+			name:           "user is not signed in",
+			session:        &session.Session{},
+			requestBody:    `{"measurement_system": "imperial"}`,
+			setupMocks:     func(*mock_user.MockProfileService, *mock_user.MockSessionStorage) {},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "unknown measurement system",
+			session:        signedInSession,
+			requestBody:    `{"measurement_system": "nautical"}`,
+			setupMocks:     func(*mock_user.MockProfileService, *mock_user.MockSessionStorage) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "new measurement system applies to every session of the user",
+			session:     signedInSession,
+			requestBody: `{"measurement_system": "imperial"}`,
+			setupMocks: func(profileService *mock_user.MockProfileService, sessionStorage *mock_user.MockSessionStorage) {
+				profileService.EXPECT().
+					UpdateProfile(gomock.Any(), signedInSession.UserID, user.UpdateProfileRequest{MeasurementSystem: "imperial"}).
+					Return(nil)
+				sessionStorage.EXPECT().
+					UpdateAllOfUser(signedInSession.UserID, gomock.Any()).
+					DoAndReturn(func(_ uuid.UUID, update func(*session.Session)) error {
+						storedSession := session.Session{UserID: signedInSession.UserID, MeasurementSystem: "metric"}
+						update(&storedSession)
+
+						assert.Equal(t, "imperial", storedSession.MeasurementSystem)
+
+						return nil
+					})
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			e := echo.New()
+			e.Validator = validator.New()
+			e.Use(session.WithSessionForTest(tc.session))
+			server := &http.Server{Handler: e}
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/me", strings.NewReader(tc.requestBody))
+			req.Header.Add("content-type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			ctrl := gomock.NewController(t)
+			profileService := mock_user.NewMockProfileService(ctrl)
+			sessionStorage := mock_user.NewMockSessionStorage(ctrl)
+			tc.setupMocks(profileService, sessionStorage)
+
+			userHandler := user.NewHandler(zap.NewNop(), profileService, sessionStorage, throttle.New(throttle.NewMemory(), "two_factor_disable", twoFactorPolicy))
+			userHandler.RegisterRoutes(e)
+
+			// when
+			server.Handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestDisableTwoFactorHandlerLocksOutUser(t *testing.T) {
+	// given
+	signedInSession := &session.Session{UserID: uuid.Must(uuid.NewV7()), Email: "user@mail.com", MeasurementSystem: "metric"}
+
+	e := echo.New()
+	e.Validator = validator.New()
+	e.Use(session.WithSessionForTest(signedInSession))
+
+	ctrl := gomock.NewController(t)
+	profileService := mock_user.NewMockProfileService(ctrl)
+	sessionStorage := mock_user.NewMockSessionStorage(ctrl)
+
+	profileService.EXPECT().
+		DisableTwoFactor(gomock.Any(), signedInSession.UserID, gomock.Any()).
+		Return(echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the two-factor code is invalid or has already been used"})).
+		Times(int(twoFactorPolicy.LockoutAttempts))
+
+	userHandler := user.NewHandler(zap.NewNop(), profileService, sessionStorage, throttle.New(throttle.NewMemory(), "two_factor_disable", twoFactorPolicy))
+	userHandler.RegisterRoutes(e)
+
+	disableTwoFactor := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/me/2fa/disable", strings.NewReader(`{"code": "123456"}`))
+		req.Header.Add("content-type", "application/json")
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	for range twoFactorPolicy.LockoutAttempts {
+		rec := disableTwoFactor()
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	// when
+	rec := disableTwoFactor()
+
+	// then
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "the code should not be checked once the user is locked out")
+	assert.Equal(t, "900", rec.Header().Get("Retry-After"))
+}
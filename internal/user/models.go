@@ -0,0 +1,61 @@
+package user
+
+import (
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/webauthn"
+	"github.com/google/uuid"
+)
+
+type ProfileResponse struct {
+	UserID uuid.UUID `json:"user_id" example:"0194b341-6797-736a-9a98-474d08025925"`
+	Email  string    `json:"email" example:"user@mail.com"`
+	// MeasurementSystem is empty when the user has not chosen one and recipes are shown as they were written.
+	MeasurementSystem string `json:"measurement_system" example:"metric"`
+	// EmailVerifiedAt is null until the user verifies the email. Users with unverified emails cannot publish recipes or comment.
+	EmailVerifiedAt  *time.Time `json:"email_verified_at" example:"2025-02-05T21:40:12.00635Z"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled" example:"false"`
+	CreatedAt        time.Time  `json:"created_at" example:"2025-02-05T21:35:31.00635Z"`
+}
+
+// UpdateProfileRequest replaces the settings of a user.
+// An empty measurement system shows recipes in the units they were written in.
+type UpdateProfileRequest struct {
+	MeasurementSystem string `json:"measurement_system" validate:"omitempty,oneof=metric imperial" example:"imperial"`
+}
+
+// TwoFactorEnrollmentResponse holds a new TOTP secret, which has to be added to an authenticator app
+// and confirmed with a code before two-factor authentication is turned on.
+type TwoFactorEnrollmentResponse struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	// URI is the otpauth URI of the secret, usually shown as a QR code.
+	URI string `json:"uri" example:"otpauth://totp/Recipe%20App:user@mail.com?algorithm=SHA1&digits=6&issuer=Recipe+App&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+// TwoFactorCodeRequest holds a code from an authenticator app, or a recovery code where it is accepted.
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,max=32" example:"287082"`
+}
+
+// RecoveryCodesResponse holds the recovery codes, which are shown only once. Each of them can be used once instead of a code from an authenticator app.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k7d2m-x9q4t,p3w8n-h5r2c"`
+}
+
+// PasskeyResponse describes a passkey of the user. The keys are never returned.
+type PasskeyResponse struct {
+	PasskeyID uuid.UUID `json:"passkey_id" example:"0197a1c2-5b3e-7d4f-8a90-1b2c3d4e5f60"`
+	Name      string    `json:"name" example:"MacBook"`
+	// Transports are the ways the browser reaches the authenticator, such as internal, usb or hybrid.
+	Transports []string  `json:"transports" example:"internal,hybrid"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-06-20T12:00:00.00635Z"`
+	// LastUsedAt is null until the passkey is used to sign in.
+	LastUsedAt *time.Time `json:"last_used_at" example:"2025-06-21T08:15:42.00635Z"`
+}
+
+// RegisterPasskeyRequest holds a passkey created with the options from /api/v1/me/passkeys/register/begin,
+// serialized by PublicKeyCredential.toJSON, and a name which tells the passkeys of the user apart.
+type RegisterPasskeyRequest struct {
+	Name       string                          `json:"name" validate:"required,max=64" example:"MacBook"`
+	Credential webauthn.RegistrationCredential `json:"credential" validate:"required"`
+}
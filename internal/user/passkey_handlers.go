@@ -0,0 +1,152 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/webauthn"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// BeginPasskeyRegistration godoc
+//
+//	@Summary		Begin registering a passkey
+//	@Description	Get the options of navigator.credentials.create, which the browser reads with PublicKeyCredential.parseCreationOptionsFromJSON.
+//	@Description	The challenge of the options expires after five minutes and can be used once.
+//	@Tags			passkeys
+//
+//	@Produce		json
+//
+//	@Success		200	{object}	shared.DataResponse[webauthn.CreationOptions]	"Options created successfully."
+//	@Failure		401	{object}	shared.CommonResponse							"User is not signed in."
+//
+//	@Router			/api/v1/me/passkeys/register/begin [POST]
+func (h *handler) BeginPasskeyRegistration(c echo.Context) error {
+	userId := session.FromContext(c).UserID
+
+	options, err := h.profileService.BeginPasskeyRegistration(c.Request().Context(), userId)
+	if err != nil {
+		return err
+	}
+
+	if err := h.sessionStorage.SaveChallenge(options.Challenge, userId); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[webauthn.CreationOptions]{Data: options})
+}
+
+// FinishPasskeyRegistration godoc
+//
+//	@Summary		Finish registering a passkey
+//	@Description	Save a passkey created with the options from /api/v1/me/passkeys/register/begin. The passkey can then be used to sign in without a password.
+//	@Tags			passkeys
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			RegisterPasskeyRequest	body		user.RegisterPasskeyRequest					true	"Request body with a name and the passkey serialized by PublicKeyCredential.toJSON."
+//
+//	@Success		201						{object}	shared.DataResponse[user.PasskeyResponse]	"Passkey registered successfully."
+//	@Failure		400						{object}	validator.ValidationErrorResponse			"Invalid data provided, the passkey is not valid or the challenge has expired."
+//	@Failure		401						{object}	shared.CommonResponse						"User is not signed in."
+//	@Failure		409						{object}	shared.CommonResponse						"Passkey is already registered."
+//
+//	@Router			/api/v1/me/passkeys/register/finish [POST]
+func (h *handler) FinishPasskeyRegistration(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	var requestBody = RegisterPasskeyRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	userId := session.FromContext(c).UserID
+
+	challenge, err := webauthn.ParseChallenge(requestBody.Credential.Response.ClientDataJSON)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "passkey is not valid"})
+	}
+
+	// The challenge has to be one created for this user by BeginPasskeyRegistration.
+	challengeUserId, err := h.sessionStorage.TakeChallenge(challenge)
+	if err != nil && !errors.Is(err, session.ErrChallengeNotFound) {
+		return err
+	}
+
+	if err != nil || challengeUserId != userId {
+		return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the registration has expired, start again"})
+	}
+
+	passkey, err := h.profileService.FinishPasskeyRegistration(c.Request().Context(), userId, challenge, requestBody)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully registered a passkey", zap.String("userId", userId.String()), zap.String("passkeyId", passkey.PasskeyID.String()))
+
+	return c.JSON(http.StatusCreated, shared.DataResponse[PasskeyResponse]{Data: passkey})
+}
+
+// ListPasskeys godoc
+//
+//	@Summary		List passkeys
+//	@Description	Get the passkeys of the signed-in user, starting from the oldest ones.
+//	@Tags			passkeys
+//
+//	@Produce		json
+//
+//	@Success		200	{object}	shared.DataResponse[[]user.PasskeyResponse]	"Passkeys fetched successfully."
+//	@Failure		401	{object}	shared.CommonResponse						"User is not signed in."
+//
+//	@Router			/api/v1/me/passkeys [GET]
+func (h *handler) ListPasskeys(c echo.Context) error {
+	passkeys, err := h.profileService.ListPasskeys(c.Request().Context(), session.FromContext(c).UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[[]PasskeyResponse]{Data: passkeys})
+}
+
+// DeletePasskey godoc
+//
+//	@Summary		Remove a passkey
+//	@Description	Remove a passkey of the signed-in user, which can no longer be used to sign in.
+//	@Description	The passkey stays on the authenticator, so the user should delete it there as well.
+//	@Tags			passkeys
+//
+//	@Param			passkeyId	path	string	true	"UUID of a passkey."
+//
+//	@Success		204			"Passkey removed successfully."
+//	@Failure		400			{object}	shared.CommonResponse	"Invalid passkey ID."
+//	@Failure		401			{object}	shared.CommonResponse	"User is not signed in."
+//	@Failure		404			{object}	shared.CommonResponse	"Passkey not found."
+//
+//	@Router			/api/v1/me/passkeys/{passkeyId} [DELETE]
+func (h *handler) DeletePasskey(c echo.Context) error {
+	passkeyId, err := uuid.Parse(c.Param("passkeyId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the received passkey ID is not a valid UUID"})
+	}
+
+	userId := session.FromContext(c).UserID
+
+	if err := h.profileService.DeletePasskey(c.Request().Context(), userId, passkeyId); err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully removed a passkey", zap.String("userId", userId.String()), zap.String("passkeyId", passkeyId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,288 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/auth"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/webauthn"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// BeginPasskeyRegistration returns the options for the browser to create a new passkey for the user.
+// Passkeys the user has registered already are excluded, so an authenticator cannot hold two of them.
+func (s *service) BeginPasskeyRegistration(ctx context.Context, userId uuid.UUID) (webauthn.CreationOptions, error) {
+	var email string
+	var credentialIds [][]byte
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		user, err := q.GetUserById(qCtx, userId)
+		if err != nil {
+			return err
+		}
+
+		email = user.Email
+
+		credentialIds, err = q.ListPasskeyCredentialIdsByUserId(qCtx, userId)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return webauthn.CreationOptions{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "user not found"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return webauthn.CreationOptions{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("beginPasskeyRegistration method got uncaught error", zap.Error(err))
+			return webauthn.CreationOptions{}, err
+		}
+	}
+
+	// The user handle is the ID of the user, so a passkey tells whose it is without revealing the email.
+	return s.relyingParty.NewCreationOptions(userId[:], email, credentialIds)
+}
+
+// FinishPasskeyRegistration verifies a passkey created for the challenge and saves it for the user.
+func (s *service) FinishPasskeyRegistration(ctx context.Context, userId uuid.UUID, challenge []byte, registerPasskeyRequest RegisterPasskeyRequest) (PasskeyResponse, error) {
+	credential, err := s.relyingParty.VerifyRegistration(registerPasskeyRequest.Credential, challenge)
+	if err != nil {
+		if errors.Is(err, webauthn.ErrInvalidCredential) || errors.Is(err, webauthn.ErrUnsupportedKey) {
+			return PasskeyResponse{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "passkey is not valid"})
+		}
+
+		return PasskeyResponse{}, err
+	}
+
+	passkeyId, err := uuid.NewV7()
+	if err != nil {
+		return PasskeyResponse{}, errors.Join(errors.New("failed to generate UUID"), err)
+	}
+
+	transports := credential.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		return q.CreatePasskey(qCtx, sqlc.CreatePasskeyParams{
+			PasskeyID:    passkeyId,
+			UserID:       userId,
+			CredentialID: credential.ID,
+			PublicKey:    credential.PublicKey,
+			SignCount:    int64(credential.SignCount),
+			Aaguid:       credential.AAGUID,
+			Transports:   transports,
+			Name:         registerPasskeyRequest.Name,
+		})
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		switch {
+		case errors.As(err, &pgErr) && pgErr.Code == "23505":
+			return PasskeyResponse{}, echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "passkey is already registered"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return PasskeyResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("finishPasskeyRegistration method got uncaught error", zap.Error(err))
+			return PasskeyResponse{}, err
+		}
+	}
+
+	return PasskeyResponse{
+		PasskeyID:  passkeyId,
+		Name:       registerPasskeyRequest.Name,
+		Transports: transports,
+		CreatedAt:  time.Now().UTC(),
+	}, nil
+}
+
+// ListPasskeys returns the passkeys of the user, starting from the oldest ones.
+func (s *service) ListPasskeys(ctx context.Context, userId uuid.UUID) ([]PasskeyResponse, error) {
+	passkeys := []PasskeyResponse{}
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		rows, err := q.ListPasskeysByUserId(qCtx, userId)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			passkey := PasskeyResponse{
+				PasskeyID:  row.PasskeyID,
+				Name:       row.Name,
+				Transports: row.Transports,
+				CreatedAt:  row.CreatedAt.Time,
+			}
+
+			if row.LastUsedAt.Valid {
+				passkey.LastUsedAt = &row.LastUsedAt.Time
+			}
+
+			passkeys = append(passkeys, passkey)
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("listPasskeys method got uncaught error", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	return passkeys, nil
+}
+
+// DeletePasskey removes a passkey of the user, which can no longer be used to sign in.
+func (s *service) DeletePasskey(ctx context.Context, userId, passkeyId uuid.UUID) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		deletedRows, err := q.DeletePasskey(qCtx, sqlc.DeletePasskeyParams{PasskeyID: passkeyId, UserID: userId})
+		if err != nil {
+			return err
+		}
+
+		if deletedRows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "could not find a passkey with this ID"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("deletePasskey method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BeginPasskeySignIn returns the options for the browser to sign in with any passkey of the app.
+// The user is not known until the authenticator picks a passkey, so no passkeys are listed.
+func (s *service) BeginPasskeySignIn() (webauthn.RequestOptions, error) {
+	return s.relyingParty.NewRequestOptions()
+}
+
+// FinishPasskeySignIn verifies that a registered passkey signed the challenge and returns the session of its user.
+// Passkeys require user verification, so they replace both the password and the second factor.
+func (s *service) FinishPasskeySignIn(ctx context.Context, challenge []byte, credential webauthn.AssertionCredential) (auth.SignInResponse, error) {
+	var signInResponse auth.SignInResponse
+	var passkeyId uuid.UUID
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		passkey, err := q.GetPasskeyByCredentialId(qCtx, credential.RawID)
+		if err != nil {
+			return err
+		}
+
+		passkeyId = passkey.PasskeyID
+
+		signCount, err := s.relyingParty.VerifyAssertion(credential, challenge, webauthn.StoredCredential{
+			PublicKey:  passkey.PublicKey,
+			SignCount:  uint32(passkey.SignCount),
+			UserHandle: passkey.UserID[:],
+		})
+		if err != nil {
+			return err
+		}
+
+		// The counter is compared again while updating, because another sign-in may have used the passkey meanwhile.
+		updatedRows, err := q.UsePasskey(qCtx, sqlc.UsePasskeyParams{PasskeyID: passkey.PasskeyID, SignCount: int64(signCount)})
+		if err != nil {
+			return err
+		}
+
+		if updatedRows == 0 {
+			return webauthn.ErrSignCountRegressed
+		}
+
+		user, err := q.GetUserById(qCtx, passkey.UserID)
+		if err != nil {
+			return err
+		}
+
+		signInResponse = auth.SignInResponse{
+			UserID:            user.UserID,
+			Email:             user.Email,
+			MeasurementSystem: user.MeasurementSystem.String,
+			UnverifiedEmail:   !user.EmailVerifiedAt.Valid,
+			SessionVersion:    user.SessionVersion,
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows), errors.Is(err, webauthn.ErrInvalidCredential), errors.Is(err, webauthn.ErrUnsupportedKey):
+			return auth.SignInResponse{}, echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "passkey is not valid"})
+		case errors.Is(err, webauthn.ErrSignCountRegressed):
+			s.logger.Warn("signature counter of a passkey did not increase, the passkey may have been cloned", zap.String("passkeyId", passkeyId.String()))
+			return auth.SignInResponse{}, echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "passkey is not valid"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return auth.SignInResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("finishPasskeySignIn method got uncaught error", zap.Error(err))
+			return auth.SignInResponse{}, err
+		}
+	}
+
+	return signInResponse, nil
+}
@@ -0,0 +1,165 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/auth"
+	"github.com/danielbukowski/recipe-app-backend/internal/mailer"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// passwordResetPath is the page of the app which reads the token from the link and asks for a new password.
+const passwordResetPath = "/reset-password"
+
+// passwordResetQueueSize is the number of password resets which wait for RunPasswordResets to email them.
+const passwordResetQueueSize = 100
+
+// RequestPasswordReset queues a password reset of the owner of the email and returns at once.
+// The link is emailed by RunPasswordResets, so the response takes as long whether the email has an account or not,
+// and the endpoint cannot be used to find out who has an account.
+func (s *service) RequestPasswordReset(ctx context.Context, forgotPasswordRequest auth.ForgotPasswordRequest) error {
+	select {
+	case s.passwordResets <- forgotPasswordRequest.Email:
+	default:
+		// The response must not depend on whether the email has an account, so a full queue is only logged.
+		s.logger.Error("dropped a password reset because the queue is full")
+	}
+
+	return nil
+}
+
+// RunPasswordResets emails the links of queued password resets until the context is done.
+func (s *service) RunPasswordResets(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case email := <-s.passwordResets:
+			if err := s.sendPasswordReset(ctx, email); err != nil && !errors.Is(err, context.Canceled) {
+				s.logger.Error("failed to send a password reset email", zap.Error(err))
+			}
+		}
+	}
+}
+
+// sendPasswordReset emails a link to reset the password to the owner of the email.
+// Nothing is sent for emails without an account.
+func (s *service) sendPasswordReset(ctx context.Context, email string) error {
+	token, tokenHash, err := shared.GenerateSecretToken()
+	if err != nil {
+		return err
+	}
+
+	tokenId, err := uuid.NewV7()
+	if err != nil {
+		return errors.Join(errors.New("failed to generate UUID"), err)
+	}
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		user, err := q.GetUserByEmail(qCtx, email)
+		if err != nil {
+			return err
+		}
+
+		email = user.Email
+
+		return q.CreatePasswordResetToken(qCtx, sqlc.CreatePasswordResetTokenParams{
+			TokenID:   tokenId,
+			UserID:    user.UserID,
+			TokenHash: tokenHash,
+			ExpiresAt: pgtype.Timestamp{Time: time.Now().Add(s.emailConfig.PasswordResetTokenTTL).UTC(), Valid: true},
+		})
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+
+		return err
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      email,
+		Subject: "Reset your password",
+		Body: "Someone asked to reset the password of your account.\n\n" +
+			"Open the link below to choose a new password. It is valid for " + s.emailConfig.PasswordResetTokenTTL.String() + " and can be used once.\n\n" +
+			s.emailConfig.AppURL + passwordResetPath + "?token=" + url.QueryEscape(token) + "\n\n" +
+			"If it was not you, ignore this email and your password stays the same.\n",
+	})
+	if err != nil {
+		return errors.Join(errors.New("failed to send the email with the token "+tokenId.String()), err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password of the user the token was sent to and returns the ID of the user
+// with the new session version, which signs out the sessions created with the old password.
+// The token is used up, and so are the other unused tokens of the user.
+func (s *service) ResetPassword(ctx context.Context, resetPasswordRequest auth.ResetPasswordRequest) (auth.ResetPasswordResponse, error) {
+	hashedPassword, err := s.passwordHasher.CreateHashFromPassword(resetPasswordRequest.Password)
+	if err != nil {
+		return auth.ResetPasswordResponse{}, errors.Join(errors.New("failed to generate a hash from the password"), err)
+	}
+
+	var resetPasswordResponse auth.ResetPasswordResponse
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		userId, err := q.UsePasswordResetToken(qCtx, shared.HashSecretToken(resetPasswordRequest.Token))
+		if err != nil {
+			return err
+		}
+
+		sessionVersion, err := q.UpdateUserPassword(qCtx, sqlc.UpdateUserPasswordParams{
+			UserID:   userId,
+			Password: hashedPassword,
+		})
+		if err != nil {
+			return err
+		}
+
+		resetPasswordResponse = auth.ResetPasswordResponse{UserID: userId, SessionVersion: sessionVersion}
+
+		return q.InvalidatePasswordResetTokens(qCtx, userId)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return auth.ResetPasswordResponse{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the password reset token is invalid, has expired or has already been used"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return auth.ResetPasswordResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("resetPassword method got uncaught error", zap.Error(err))
+			return auth.ResetPasswordResponse{}, err
+		}
+	}
+
+	return resetPasswordResponse, nil
+}
@@ -0,0 +1,22 @@
+package user
+
+import (
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes sets endpoints for the profile of the signed-in user.
+func (h *handler) RegisterRoutes(e *echo.Echo) {
+	e.GET("api/v1/me", h.GetProfile, session.RequireAuth)
+	e.PUT("api/v1/me", h.UpdateProfile, session.RequireAuth)
+	e.POST("api/v1/me/verify-email/resend", h.ResendVerificationEmail, session.RequireAuth)
+
+	e.POST("api/v1/me/2fa/enroll", h.EnrollTwoFactor, session.RequireAuth)
+	e.POST("api/v1/me/2fa/confirm", h.ConfirmTwoFactor, session.RequireAuth)
+	e.POST("api/v1/me/2fa/disable", h.DisableTwoFactor, session.RequireAuth)
+
+	e.POST("api/v1/me/passkeys/register/begin", h.BeginPasskeyRegistration, session.RequireAuth)
+	e.POST("api/v1/me/passkeys/register/finish", h.FinishPasskeyRegistration, session.RequireAuth)
+	e.GET("api/v1/me/passkeys", h.ListPasskeys, session.RequireAuth)
+	e.DELETE("api/v1/me/passkeys/:passkeyId", h.DeletePasskey, session.RequireAuth)
+}
@@ -4,13 +4,19 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
 	"github.com/danielbukowski/recipe-app-backend/internal/auth"
+	"github.com/danielbukowski/recipe-app-backend/internal/mailer"
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/webauthn"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -23,6 +29,26 @@ type service struct {
 	logger         *zap.Logger
 	dbpool         *pgxpool.Pool
 	passwordHasher passwordHasher
+	mailer         mailer.Mailer
+	emailConfig    EmailConfig
+	relyingParty   webauthn.RelyingParty
+	// passwordResets queues the emails password resets are requested for, see RunPasswordResets.
+	passwordResets chan string
+	// dummyPasswordHash is compared with the passwords of unknown emails,
+	// so signing in takes as long as for an existing account and does not reveal which emails have one.
+	dummyPasswordHash string
+}
+
+// EmailConfig describes the links the service sends to users in emails.
+type EmailConfig struct {
+	// AppURL is the address of the app the links point to.
+	AppURL string
+	// PasswordResetTokenTTL is how long a link to reset a password is valid.
+	PasswordResetTokenTTL time.Duration
+	// VerificationTokenTTL is how long a link to verify an email is valid.
+	VerificationTokenTTL time.Duration
+	// VerificationResendInterval is how long a user has to wait before another verification email is sent.
+	VerificationResendInterval time.Duration
 }
 
 type passwordHasher interface {
@@ -30,12 +56,26 @@ type passwordHasher interface {
 	ComparePasswordAndHash(password, hash string) bool
 }
 
-func NewService(logger *zap.Logger, passwordHasher passwordHasher, dbppol *pgxpool.Pool) *service {
-	return &service{
-		logger:         logger,
-		dbpool:         dbppol,
-		passwordHasher: passwordHasher,
+// NewService returns the service of users. It fails when the dummy password hash cannot be created,
+// because without it sign-ins with unknown emails would answer faster than with wrong passwords.
+func NewService(logger *zap.Logger, passwordHasher passwordHasher, dbppol *pgxpool.Pool, mailer mailer.Mailer, emailConfig EmailConfig, relyingParty webauthn.RelyingParty) (*service, error) {
+	emailConfig.AppURL = strings.TrimSuffix(emailConfig.AppURL, "/")
+
+	dummyPasswordHash, err := passwordHasher.CreateHashFromPassword(uuid.NewString())
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create a dummy password hash"), err)
 	}
+
+	return &service{
+		logger:            logger,
+		dbpool:            dbppol,
+		passwordHasher:    passwordHasher,
+		mailer:            mailer,
+		emailConfig:       emailConfig,
+		relyingParty:      relyingParty,
+		passwordResets:    make(chan string, passwordResetQueueSize),
+		dummyPasswordHash: dummyPasswordHash,
+	}, nil
 }
 
 func (s *service) CreateUser(ctx context.Context, user auth.SignUpRequest) error {
@@ -49,22 +89,42 @@ func (s *service) CreateUser(ctx context.Context, user auth.SignUpRequest) error
 		return errors.Join(errors.New("failed to generate UUID"), err)
 	}
 
+	tokenId, err := uuid.NewV7()
+	if err != nil {
+		return errors.Join(errors.New("failed to generate UUID"), err)
+	}
+
+	token, tokenHash, err := shared.GenerateSecretToken()
+	if err != nil {
+		return err
+	}
+
 	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
 	defer cancelConnCtx()
 
-	err = s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+	err = pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
 		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
 		defer cancelQCtx()
 
-		q := sqlc.New(c)
+		q := sqlc.New(tx)
 
-		return q.CreateUser(qCtx,
+		err := q.CreateUser(qCtx,
 			sqlc.CreateUserParams{
 				UserID:   id,
 				Email:    user.Email,
 				Password: hashedPassword,
 			},
 		)
+		if err != nil {
+			return err
+		}
+
+		return q.CreateEmailVerificationToken(qCtx, sqlc.CreateEmailVerificationTokenParams{
+			TokenID:   tokenId,
+			UserID:    id,
+			TokenHash: tokenHash,
+			ExpiresAt: pgtype.Timestamp{Time: time.Now().Add(s.emailConfig.VerificationTokenTTL).UTC(), Valid: true},
+		})
 	})
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -86,9 +146,16 @@ func (s *service) CreateUser(ctx context.Context, user auth.SignUpRequest) error
 		}
 	}
 
+	// The account has been created, so a failed email is only logged. The user can ask for another one.
+	if err := s.sendVerificationEmail(ctx, user.Email, token); err != nil {
+		s.logger.Error("failed to send a verification email", zap.String("userId", id.String()), zap.Error(err))
+	}
+
 	return nil
 }
 
+// SignIn checks the email and password of a user. A wrong password and an unknown email return the same error,
+// so the response does not reveal which emails have an account.
 func (s *service) SignIn(ctx context.Context, signInRequest auth.SignInRequest) (auth.SignInResponse, error) {
 	var user sqlc.GetUserByEmailRow
 
@@ -112,20 +179,153 @@ func (s *service) SignIn(ctx context.Context, signInRequest auth.SignInRequest)
 	if err != nil {
 		switch {
 		case errors.Is(err, pgx.ErrNoRows):
-			return auth.SignInResponse{}, echo.NewHTTPError(http.StatusNotFound, "email has not been found")
+			// The password is hashed anyway, so an unknown email takes as long as a wrong password.
+			s.passwordHasher.ComparePasswordAndHash(signInRequest.Password, s.dummyPasswordHash)
+			return auth.SignInResponse{}, echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: auth.InvalidCredentialsMessage})
+		case errors.Is(err, context.DeadlineExceeded):
+			return auth.SignInResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
 		default:
+			s.logger.Error("signIn method got uncaught error", zap.Error(err))
 			return auth.SignInResponse{}, err
 		}
 	}
 
 	ok := s.passwordHasher.ComparePasswordAndHash(signInRequest.Password, user.Password)
 	if !ok {
-		return auth.SignInResponse{}, echo.NewHTTPError(http.StatusBadRequest, "password does not match")
+		return auth.SignInResponse{}, echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: auth.InvalidCredentialsMessage})
 	}
 
 	signInResponse := auth.SignInResponse{
-		Email: signInRequest.Email,
+		UserID:            user.UserID,
+		Email:             user.Email,
+		MeasurementSystem: user.MeasurementSystem.String,
+		UnverifiedEmail:   !user.EmailVerifiedAt.Valid,
+		TwoFactorEnabled:  user.TotpEnabledAt.Valid,
+		SessionVersion:    user.SessionVersion,
 	}
 
 	return signInResponse, nil
 }
+
+// SessionVersion returns the current session version of the user, which sessions with an older version are rejected for.
+// It returns session.ErrUserNotFound for users which do not exist anymore.
+func (s *service) SessionVersion(ctx context.Context, userId uuid.UUID) (int32, error) {
+	var sessionVersion int32
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		var err error
+
+		sessionVersion, err = q.GetUserSessionVersion(qCtx, userId)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return 0, session.ErrUserNotFound
+		case errors.Is(err, context.DeadlineExceeded):
+			return 0, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("sessionVersion method got uncaught error", zap.Error(err))
+			return 0, err
+		}
+	}
+
+	return sessionVersion, nil
+}
+
+func (s *service) GetProfile(ctx context.Context, userId uuid.UUID) (ProfileResponse, error) {
+	var profile ProfileResponse
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		user, err := q.GetUserById(qCtx, userId)
+		if err != nil {
+			return err
+		}
+
+		profile = ProfileResponse{
+			UserID:            user.UserID,
+			Email:             user.Email,
+			MeasurementSystem: user.MeasurementSystem.String,
+			CreatedAt:         user.CreatedAt.Time,
+		}
+
+		if user.EmailVerifiedAt.Valid {
+			profile.EmailVerifiedAt = &user.EmailVerifiedAt.Time
+		}
+
+		profile.TwoFactorEnabled = user.TotpEnabledAt.Valid
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return ProfileResponse{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "user not found"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return ProfileResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("getProfile method got uncaught error", zap.Error(err))
+			return ProfileResponse{}, err
+		}
+	}
+
+	return profile, nil
+}
+
+func (s *service) UpdateProfile(ctx context.Context, userId uuid.UUID, updateProfileRequest UpdateProfileRequest) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		updatedRows, err := q.UpdateUserMeasurementSystem(qCtx, sqlc.UpdateUserMeasurementSystemParams{
+			UserID: userId,
+			MeasurementSystem: pgtype.Text{
+				String: updateProfileRequest.MeasurementSystem,
+				Valid:  updateProfileRequest.MeasurementSystem != "",
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if updatedRows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "user not found"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("updateProfile method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/session"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// tooManyTwoFactorCodesMessage is the message of codes rejected by the throttle.
+const tooManyTwoFactorCodesMessage = "too many invalid two-factor codes, try again later"
+
+// twoFactorLimiter delays and locks out the users who send wrong two-factor codes.
+// A code is reserved before it is checked and then fails, is released or resets the user.
+type twoFactorLimiter interface {
+	Reserve(key string) (time.Duration, error)
+	Fail(key string) (time.Duration, error)
+	Release(key string) error
+	Reset(key string) error
+}
+
+// EnrollTwoFactor godoc
+//
+//	@Summary		Enroll in two-factor authentication
+//	@Description	Generate a TOTP secret for an authenticator app. Two-factor authentication is turned on once a code from the app is confirmed.
+//	@Description	Enrolling again before confirming replaces the secret.
+//	@Tags			profile
+//
+//	@Produce		json
+//
+//	@Success		200	{object}	shared.DataResponse[user.TwoFactorEnrollmentResponse]	"Secret generated successfully."
+//	@Failure		401	{object}	shared.CommonResponse									"User is not signed in."
+//	@Failure		409	{object}	shared.CommonResponse									"Two-factor authentication is already enabled."
+//
+//	@Router			/api/v1/me/2fa/enroll [POST]
+func (h *handler) EnrollTwoFactor(c echo.Context) error {
+	enrollment, err := h.profileService.EnrollTwoFactor(c.Request().Context(), session.FromContext(c).UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shared.DataResponse[TwoFactorEnrollmentResponse]{Data: enrollment})
+}
+
+// ConfirmTwoFactor godoc
+//
+//	@Summary		Confirm two-factor authentication
+//	@Description	Turn on two-factor authentication with a code from the authenticator app. The response holds recovery codes,
+//	@Description	which are shown only once. Each of them can be used once to sign in without the app.
+//	@Tags			profile
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			TwoFactorCodeRequest	body		user.TwoFactorCodeRequest						true	"Request body with a code from the authenticator app."
+//
+//	@Success		200						{object}	shared.DataResponse[user.RecoveryCodesResponse]	"Two-factor authentication enabled successfully."
+//	@Failure		400						{object}	validator.ValidationErrorResponse				"Invalid data provided, or the code is invalid."
+//	@Failure		401						{object}	shared.CommonResponse							"User is not signed in."
+//	@Failure		409						{object}	shared.CommonResponse							"Two-factor authentication is already enabled or has not been enrolled."
+//
+//	@Router			/api/v1/me/2fa/confirm [POST]
+func (h *handler) ConfirmTwoFactor(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	var requestBody = TwoFactorCodeRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	userId := session.FromContext(c).UserID
+
+	recoveryCodes, err := h.profileService.ConfirmTwoFactor(c.Request().Context(), userId, requestBody)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("successfully enabled two-factor authentication", zap.String("userId", userId.String()))
+
+	return c.JSON(http.StatusOK, shared.DataResponse[RecoveryCodesResponse]{Data: recoveryCodes})
+}
+
+// DisableTwoFactor godoc
+//
+//	@Summary		Disable two-factor authentication
+//	@Description	Turn off two-factor authentication with a code from the authenticator app or a recovery code. The secret and the recovery codes are deleted.
+//	@Tags			profile
+//
+//	@Accept			json
+//	@Produce		json
+//	@Param			TwoFactorCodeRequest	body	user.TwoFactorCodeRequest	true	"Request body with a code from the authenticator app or a recovery code."
+//
+//	@Success		204						"Two-factor authentication disabled successfully."
+//	@Failure		400						{object}	validator.ValidationErrorResponse	"Invalid data provided, or the code is invalid."
+//	@Failure		401						{object}	shared.CommonResponse				"User is not signed in."
+//	@Failure		409						{object}	shared.CommonResponse				"Two-factor authentication is not enabled."
+//	@Failure		429						{object}	shared.CommonResponse				"Too many invalid codes, the Retry-After header tells how long to wait."
+//
+//	@Router			/api/v1/me/2fa/disable [POST]
+func (h *handler) DisableTwoFactor(c echo.Context) error {
+	if err := shared.ValidateJSONContentType(c); err != nil {
+		return err
+	}
+
+	var requestBody = TwoFactorCodeRequest{}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, shared.CommonResponse{Message: "missing a valid JSON request body"})
+	}
+
+	if err := c.Validate(&requestBody); err != nil {
+		return err
+	}
+
+	userId := session.FromContext(c).UserID
+
+	wait, err := h.twoFactorLimiter.Reserve(userId.String())
+	if err != nil {
+		return err
+	}
+
+	if wait > 0 {
+		return shared.TooManyRequests(c, wait, tooManyTwoFactorCodesMessage)
+	}
+
+	if err := h.profileService.DisableTwoFactor(c.Request().Context(), userId, requestBody); err != nil {
+		h.failTwoFactorCode(userId, err)
+		return err
+	}
+
+	if err := h.twoFactorLimiter.Reset(userId.String()); err != nil {
+		h.logger.Error("failed to reset invalid two-factor codes of a user", zap.Error(err))
+	}
+
+	h.logger.Info("successfully disabled two-factor authentication", zap.String("userId", userId.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// failTwoFactorCode delays the next codes of the user when the reserved code was invalid,
+// and takes the code back when it could not be checked for another reason.
+// The request has failed anyway, so errors are only logged.
+func (h *handler) failTwoFactorCode(userId uuid.UUID, err error) {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Code == http.StatusBadRequest {
+		if _, err := h.twoFactorLimiter.Fail(userId.String()); err != nil {
+			h.logger.Error("failed to count an invalid two-factor code of a user", zap.Error(err))
+		}
+
+		return
+	}
+
+	if err := h.twoFactorLimiter.Release(userId.String()); err != nil {
+		h.logger.Error("failed to release a two-factor code of a user", zap.Error(err))
+	}
+}
@@ -0,0 +1,322 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/danielbukowski/recipe-app-backend/internal/totp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	// totpIssuer is the name authenticator apps show next to the codes of the app.
+	totpIssuer = "Recipe App"
+	// recoveryCodeCount is the number of recovery codes generated when two-factor authentication is turned on.
+	recoveryCodeCount = 10
+	// recoveryCodeLength is the number of characters of a recovery code, without the separator in the middle.
+	recoveryCodeLength = 10
+)
+
+var (
+	// errTwoFactorEnabled is returned when two-factor authentication is turned on already.
+	errTwoFactorEnabled = errors.New("two-factor authentication is already enabled")
+	// errTwoFactorNotEnrolled is returned when there is no secret to confirm or no two-factor authentication to use.
+	errTwoFactorNotEnrolled = errors.New("two-factor authentication is not enrolled")
+	// errInvalidTwoFactorCode is returned for codes which are wrong or have been used already.
+	errInvalidTwoFactorCode = errors.New("two-factor code is invalid")
+)
+
+// recoveryCodeEncoding turns random bytes into recovery codes which are easy to type.
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijkmnpqrstuvwxyz23456789").WithPadding(base32.NoPadding)
+
+// EnrollTwoFactor generates a new TOTP secret for the user. Two-factor authentication is turned on
+// only after ConfirmTwoFactor, so enrolling again replaces a secret which has not been confirmed.
+func (s *service) EnrollTwoFactor(ctx context.Context, userId uuid.UUID) (TwoFactorEnrollmentResponse, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return TwoFactorEnrollmentResponse{}, err
+	}
+
+	var email string
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(c)
+
+		user, err := q.GetUserById(qCtx, userId)
+		if err != nil {
+			return err
+		}
+
+		email = user.Email
+
+		updatedRows, err := q.SetUserTotpSecret(qCtx, sqlc.SetUserTotpSecretParams{
+			UserID:     userId,
+			TotpSecret: pgtype.Text{String: secret, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if updatedRows == 0 {
+			return errTwoFactorEnabled
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return TwoFactorEnrollmentResponse{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "user not found"})
+		case errors.Is(err, errTwoFactorEnabled):
+			return TwoFactorEnrollmentResponse{}, echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "two-factor authentication is already enabled"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return TwoFactorEnrollmentResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("enrollTwoFactor method got uncaught error", zap.Error(err))
+			return TwoFactorEnrollmentResponse{}, err
+		}
+	}
+
+	return TwoFactorEnrollmentResponse{
+		Secret: secret,
+		URI:    totp.URI(totpIssuer, email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor turns on two-factor authentication once the code proves the secret has been added
+// to an authenticator app. It returns new recovery codes, which replace the previous ones.
+func (s *service) ConfirmTwoFactor(ctx context.Context, userId uuid.UUID, codeRequest TwoFactorCodeRequest) (RecoveryCodesResponse, error) {
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return RecoveryCodesResponse{}, err
+	}
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		userTotp, err := q.GetUserTotp(qCtx, userId)
+		if err != nil {
+			return err
+		}
+
+		if userTotp.TotpEnabledAt.Valid {
+			return errTwoFactorEnabled
+		}
+
+		if !userTotp.TotpSecret.Valid {
+			return errTwoFactorNotEnrolled
+		}
+
+		counter, err := totp.Validate(userTotp.TotpSecret.String, codeRequest.Code, time.Now())
+		if err != nil {
+			return errInvalidTwoFactorCode
+		}
+
+		updatedRows, err := q.EnableUserTotp(qCtx, sqlc.EnableUserTotpParams{
+			UserID:          userId,
+			TotpLastCounter: pgtype.Int8{Int64: counter, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Another request has confirmed the secret in the meantime.
+		if updatedRows == 0 {
+			return errTwoFactorEnabled
+		}
+
+		if err := q.DeleteTwoFactorRecoveryCodes(qCtx, userId); err != nil {
+			return err
+		}
+
+		return q.CreateTwoFactorRecoveryCodes(qCtx, sqlc.CreateTwoFactorRecoveryCodesParams{
+			UserID:     userId,
+			CodeHashes: recoveryCodeHashes,
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return RecoveryCodesResponse{}, echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "user not found"})
+		case errors.Is(err, errTwoFactorEnabled):
+			return RecoveryCodesResponse{}, echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "two-factor authentication is already enabled"})
+		case errors.Is(err, errTwoFactorNotEnrolled):
+			return RecoveryCodesResponse{}, echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "enroll in two-factor authentication before confirming it"})
+		case errors.Is(err, errInvalidTwoFactorCode):
+			return RecoveryCodesResponse{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the two-factor code is invalid"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return RecoveryCodesResponse{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("confirmTwoFactor method got uncaught error", zap.Error(err))
+			return RecoveryCodesResponse{}, err
+		}
+	}
+
+	return RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableTwoFactor turns off two-factor authentication and deletes the secret and the recovery codes.
+// It takes a code from an authenticator app or a recovery code, so a stolen session alone cannot turn it off.
+func (s *service) DisableTwoFactor(ctx context.Context, userId uuid.UUID, codeRequest TwoFactorCodeRequest) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		if err := checkSecondFactor(qCtx, q, userId, codeRequest.Code); err != nil {
+			return err
+		}
+
+		if err := q.DisableUserTotp(qCtx, userId); err != nil {
+			return err
+		}
+
+		return q.DeleteTwoFactorRecoveryCodes(qCtx, userId)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows), errors.Is(err, errTwoFactorNotEnrolled):
+			return echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "two-factor authentication is not enabled"})
+		case errors.Is(err, errInvalidTwoFactorCode):
+			return echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the two-factor code is invalid or has already been used"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("disableTwoFactor method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifySecondFactor checks a code from an authenticator app or a recovery code of the user at sign-in.
+// Every code can be used only once.
+func (s *service) VerifySecondFactor(ctx context.Context, userId uuid.UUID, code string) error {
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := s.dbpool.AcquireFunc(connCtx, func(c *pgxpool.Conn) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		return checkSecondFactor(qCtx, sqlc.New(c), userId, code)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows), errors.Is(err, errTwoFactorNotEnrolled), errors.Is(err, errInvalidTwoFactorCode):
+			return echo.NewHTTPError(http.StatusUnauthorized, shared.CommonResponse{Message: "the two-factor code is invalid or has already been used"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("verifySecondFactor method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkSecondFactor uses up a code from an authenticator app or a recovery code of a user with two-factor authentication.
+// A code from an app is accepted only for a later period than the last used one, so it cannot be replayed
+// while it is still valid, and neither can the codes of the earlier periods.
+func checkSecondFactor(ctx context.Context, q *sqlc.Queries, userId uuid.UUID, code string) error {
+	userTotp, err := q.GetUserTotp(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if !userTotp.TotpEnabledAt.Valid {
+		return errTwoFactorNotEnrolled
+	}
+
+	code = strings.Join(strings.Fields(code), "")
+
+	if len(code) == totp.Digits {
+		counter, err := totp.Validate(userTotp.TotpSecret.String, code, time.Now())
+		if err != nil {
+			return errInvalidTwoFactorCode
+		}
+
+		updatedRows, err := q.UseUserTotpCounter(ctx, sqlc.UseUserTotpCounterParams{
+			UserID:          userId,
+			TotpLastCounter: pgtype.Int8{Int64: counter, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if updatedRows == 0 {
+			return errInvalidTwoFactorCode
+		}
+
+		return nil
+	}
+
+	updatedRows, err := q.UseTwoFactorRecoveryCode(ctx, sqlc.UseTwoFactorRecoveryCodeParams{
+		UserID:   userId,
+		CodeHash: hashRecoveryCode(code),
+	})
+	if err != nil {
+		return err
+	}
+
+	if updatedRows == 0 {
+		return errInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+// generateRecoveryCodes returns new recovery codes formatted like "k7d2m-x9q4t" and their hashes.
+func generateRecoveryCodes() ([]string, [][]byte, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([][]byte, 0, recoveryCodeCount)
+
+	for range recoveryCodeCount {
+		random := make([]byte, recoveryCodeLength*5/8)
+		if _, err := rand.Read(random); err != nil {
+			return nil, nil, err
+		}
+
+		code := recoveryCodeEncoding.EncodeToString(random)
+
+		codes = append(codes, code[:recoveryCodeLength/2]+"-"+code[recoveryCodeLength/2:])
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode returns the hash of a recovery code, which is the same with or without the separator and in any case.
+func hashRecoveryCode(code string) []byte {
+	return shared.HashSecretToken(strings.ToLower(strings.ReplaceAll(code, "-", "")))
+}
@@ -0,0 +1,100 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/totp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// totpUserDB stands in for the database of a user with two-factor authentication,
+// answering the queries of checkSecondFactor like Postgres does.
+type totpUserDB struct {
+	secret      string
+	lastCounter pgtype.Int8
+}
+
+func (db *totpUserDB) Exec(_ context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	if !strings.HasPrefix(query, "-- name: UseUserTotpCounter ") {
+		return pgconn.CommandTag{}, pgx.ErrNoRows
+	}
+
+	counter := args[1].(pgtype.Int8)
+
+	if db.lastCounter.Valid && db.lastCounter.Int64 >= counter.Int64 {
+		return pgconn.NewCommandTag("UPDATE 0"), nil
+	}
+
+	db.lastCounter = counter
+
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (db *totpUserDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, pgx.ErrNoRows
+}
+
+func (db *totpUserDB) QueryRow(_ context.Context, query string, _ ...interface{}) pgx.Row {
+	return totpRow{db: db, query: query}
+}
+
+type totpRow struct {
+	db    *totpUserDB
+	query string
+}
+
+func (r totpRow) Scan(dest ...any) error {
+	if !strings.HasPrefix(r.query, "-- name: GetUserTotp ") {
+		return pgx.ErrNoRows
+	}
+
+	*dest[0].(*pgtype.Text) = pgtype.Text{String: r.db.secret, Valid: true}
+	*dest[1].(*pgtype.Timestamp) = pgtype.Timestamp{Time: time.Now(), Valid: true}
+
+	return nil
+}
+
+func TestCheckSecondFactorRejectsReplayedCodes(t *testing.T) {
+	// given
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	db := &totpUserDB{secret: secret}
+	q := sqlc.New(db)
+	userId := uuid.Must(uuid.NewV7())
+
+	counter := totp.Counter(time.Now())
+
+	code, err := totp.Code(secret, counter)
+	require.NoError(t, err)
+
+	previousCode, err := totp.Code(secret, counter-1)
+	require.NoError(t, err)
+
+	// when
+	err = checkSecondFactor(context.Background(), q, userId, code)
+
+	// then
+	require.NoError(t, err)
+
+	// when
+	err = checkSecondFactor(context.Background(), q, userId, code)
+
+	// then
+	assert.ErrorIs(t, err, errInvalidTwoFactorCode, "a used code should not be accepted again")
+
+	// when
+	err = checkSecondFactor(context.Background(), q, userId, previousCode)
+
+	// then
+	assert.ErrorIs(t, err, errInvalidTwoFactorCode, "a code of an earlier period should not be accepted after a later one")
+}
@@ -0,0 +1,171 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/danielbukowski/recipe-app-backend/gen/sqlc"
+	"github.com/danielbukowski/recipe-app-backend/internal/auth"
+	"github.com/danielbukowski/recipe-app-backend/internal/mailer"
+	"github.com/danielbukowski/recipe-app-backend/internal/shared"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// emailVerificationPath is the page of the app which reads the token from the link and verifies the email.
+const emailVerificationPath = "/verify-email"
+
+var (
+	// errEmailAlreadyVerified is returned when a verification email is requested for a verified email.
+	errEmailAlreadyVerified = errors.New("email is already verified")
+	// errVerificationEmailTooSoon is returned when the previous verification email was sent too recently.
+	errVerificationEmailTooSoon = errors.New("verification email was sent too recently")
+)
+
+// VerifyEmail marks the email of the user the token was sent to as verified and returns the ID of the user.
+// The token is used up, and so are the other unused tokens of the user.
+func (s *service) VerifyEmail(ctx context.Context, verifyEmailRequest auth.VerifyEmailRequest) (uuid.UUID, error) {
+	var userId uuid.UUID
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err := pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		var err error
+
+		userId, err = q.UseEmailVerificationToken(qCtx, shared.HashSecretToken(verifyEmailRequest.Token))
+		if err != nil {
+			return err
+		}
+
+		updatedRows, err := q.VerifyUserEmail(qCtx, userId)
+		if err != nil {
+			return err
+		}
+
+		if updatedRows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return q.InvalidateEmailVerificationTokens(qCtx, userId)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusBadRequest, shared.CommonResponse{Message: "the verification token is invalid, has expired or has already been used"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return uuid.UUID{}, echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("verifyEmail method got uncaught error", zap.Error(err))
+			return uuid.UUID{}, err
+		}
+	}
+
+	return userId, nil
+}
+
+// ResendVerificationEmail emails a new verification link to the user. Only one email is sent
+// per resend interval, so the endpoint cannot be used to flood a mailbox.
+func (s *service) ResendVerificationEmail(ctx context.Context, userId uuid.UUID) error {
+	token, tokenHash, err := shared.GenerateSecretToken()
+	if err != nil {
+		return err
+	}
+
+	tokenId, err := uuid.NewV7()
+	if err != nil {
+		return errors.Join(errors.New("failed to generate UUID"), err)
+	}
+
+	var email string
+
+	connCtx, cancelConnCtx := context.WithTimeout(ctx, acquireConnectionTimeout)
+	defer cancelConnCtx()
+
+	err = pgx.BeginFunc(connCtx, s.dbpool, func(tx pgx.Tx) error {
+		qCtx, cancelQCtx := context.WithTimeout(ctx, queryExecutionTimeout)
+		defer cancelQCtx()
+
+		q := sqlc.New(tx)
+
+		// Lock the user, so concurrent resends cannot both find no recent token and send two emails.
+		if _, err := q.LockUser(qCtx, userId); err != nil {
+			return err
+		}
+
+		user, err := q.GetUserById(qCtx, userId)
+		if err != nil {
+			return err
+		}
+
+		if user.EmailVerifiedAt.Valid {
+			return errEmailAlreadyVerified
+		}
+
+		email = user.Email
+		now := time.Now()
+
+		createdRows, err := q.CreateEmailVerificationTokenIfIdle(qCtx, sqlc.CreateEmailVerificationTokenIfIdleParams{
+			TokenID:      tokenId,
+			UserID:       userId,
+			TokenHash:    tokenHash,
+			ExpiresAt:    pgtype.Timestamp{Time: now.Add(s.emailConfig.VerificationTokenTTL).UTC(), Valid: true},
+			CreatedAfter: pgtype.Timestamp{Time: now.Add(-s.emailConfig.VerificationResendInterval).UTC(), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if createdRows == 0 {
+			return errVerificationEmailTooSoon
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return echo.NewHTTPError(http.StatusNotFound, shared.CommonResponse{Message: "user not found"})
+		case errors.Is(err, errEmailAlreadyVerified):
+			return echo.NewHTTPError(http.StatusConflict, shared.CommonResponse{Message: "the email is already verified"})
+		case errors.Is(err, errVerificationEmailTooSoon):
+			return echo.NewHTTPError(http.StatusTooManyRequests, shared.CommonResponse{Message: "a verification email has been sent recently, try again later"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return echo.NewHTTPError(http.StatusRequestTimeout)
+		default:
+			s.logger.Error("resendVerificationEmail method got uncaught error", zap.Error(err))
+			return err
+		}
+	}
+
+	if err := s.sendVerificationEmail(ctx, email, token); err != nil {
+		s.logger.Error("failed to send a verification email", zap.String("userId", userId.String()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// sendVerificationEmail emails the link with the verification token to the user.
+func (s *service) sendVerificationEmail(ctx context.Context, email, token string) error {
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      email,
+		Subject: "Verify your email",
+		Body: "Welcome to the recipe app!\n\n" +
+			"Open the link below to verify your email. Until then you cannot publish recipes or comment. " +
+			"The link is valid for " + s.emailConfig.VerificationTokenTTL.String() + ".\n\n" +
+			s.emailConfig.AppURL + emailVerificationPath + "?token=" + url.QueryEscape(token) + "\n\n" +
+			"If you have not created an account, ignore this email.\n",
+	})
+}
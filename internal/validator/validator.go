@@ -3,6 +3,8 @@ package validator
 import (
 	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -40,17 +42,52 @@ func (v *Validator) Validate(i interface{}) error {
 			case "eqfield":
 				message = fmt.Sprintf("must be the same as the %s field", err.Param())
 			case "min":
-				message = fmt.Sprintf("must be at least %v characters long", err.Param())
+				message = fmt.Sprintf("must be at least %v%s", err.Param(), lengthUnit(err.Kind()))
 			case "max":
-				message = fmt.Sprintf("cannot be more than %v characters long", err.Param())
+				message = fmt.Sprintf("cannot be more than %v%s", err.Param(), lengthUnit(err.Kind()))
+			case "required_with":
+				message = fmt.Sprintf("cannot be blank when the %s field is set", err.Param())
+			case "excluded_without":
+				message = fmt.Sprintf("must be blank when the %s field is not set", err.Param())
+			case "gt":
+				message = fmt.Sprintf("must be greater than %v", err.Param())
+			case "oneof":
+				message = fmt.Sprintf("must be one of: %s", strings.Join(strings.Fields(err.Param()), ", "))
 			default:
 				message = fmt.Sprintf("Field '%s': '%v' must satisfy '%s' '%v' criteria", err.Field(), err.Value(), err.Tag(), err.Param())
 			}
 
-			vErr.Fields[err.Field()] = message
+			vErr.Fields[fieldName(err)] = message
 		}
 
 		return echo.NewHTTPError(http.StatusBadRequest, vErr)
 	}
 	return nil
 }
+
+// lengthUnit returns the unit which min and max rules are measured in for the kind of a field.
+func lengthUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters long"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
+
+// fieldName returns the name of the invalid field.
+// Fields of nested collections also include the path to it, e.g. "Ingredients[0].Name".
+func fieldName(err validator.FieldError) string {
+	namespace := err.Namespace()
+
+	if !strings.Contains(namespace, "[") {
+		return err.Field()
+	}
+
+	// Trim the name of the top-level struct from the namespace.
+	_, path, _ := strings.Cut(namespace, ".")
+
+	return path
+}
@@ -0,0 +1,53 @@
+package webauthn
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzParseAuthenticatorData(f *testing.F) {
+	rpIDHash := bytes.Repeat([]byte{0x01}, 32)
+	signCount := binary.BigEndian.AppendUint32(nil, 7)
+
+	// Authenticator data of an assertion.
+	f.Add(append(append(append([]byte{}, rpIDHash...), flagUserPresent|flagUserVerified), signCount...))
+
+	// Authenticator data of a registration with a credential ID and a public key {1: 2},
+	// followed by extensions {"credProtect": 2}.
+	registration := append(append([]byte{}, rpIDHash...), flagUserPresent|flagUserVerified|flagAttestedCredential|flagExtensionDataIncluded)
+	registration = append(registration, signCount...)
+	registration = append(registration, bytes.Repeat([]byte{0x02}, 16)...)
+	registration = append(registration, 0x00, 0x04, 0x0a, 0x0b, 0x0c, 0x0d)
+	registration = append(registration, 0xa1, 0x01, 0x02)
+	registration = append(registration, 0xa1, 0x6b, 'c', 'r', 'e', 'd', 'P', 'r', 'o', 't', 'e', 'c', 't', 0x02)
+	f.Add(registration)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		authData, err := parseAuthenticatorData(data)
+		if err != nil {
+			assert.ErrorIs(t, err, ErrInvalidCredential)
+			return
+		}
+
+		assert.Equal(t, data[:32], authData.rpIDHash)
+		assert.Equal(t, data[32], authData.flags)
+
+		if authData.flags&flagAttestedCredential == 0 {
+			assert.Nil(t, authData.credentialID)
+			assert.Nil(t, authData.credentialPublicKey)
+			return
+		}
+
+		assert.Len(t, authData.aaguid, 16)
+		assert.NotEmpty(t, authData.credentialID)
+
+		// The public key is exactly one CBOR item.
+		_, n, err := decodeCBOR(authData.credentialPublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, len(authData.credentialPublicKey), n)
+	})
+}
@@ -0,0 +1,210 @@
+package webauthn_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/webauthn"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	flagUserPresent        byte = 0x01
+	flagUserVerified       byte = 0x04
+	flagAttestedCredential byte = 0x40
+)
+
+// softAuthenticator is an authenticator implemented in software, which creates passkeys and signs challenges
+// like a platform authenticator would, so the ceremonies can be tested without a browser.
+// Its fields can be changed to make it misbehave.
+type softAuthenticator struct {
+	origin       string
+	rpID         string
+	flags        byte
+	signCount    uint32
+	credentialID []byte
+	userHandle   []byte
+	coseKey      []byte
+	sign         func(data []byte) []byte
+}
+
+func newES256Authenticator(t *testing.T) *softAuthenticator {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	a := newSoftAuthenticator(t)
+	a.coseKey = encodeCBOR(cborMap{
+		{int64(1), int64(2)},
+		{int64(3), webauthn.AlgES256},
+		{int64(-1), int64(1)},
+		{int64(-2), key.X.FillBytes(make([]byte, 32))},
+		{int64(-3), key.Y.FillBytes(make([]byte, 32))},
+	})
+	a.sign = func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+
+		signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+
+		return signature
+	}
+
+	return a
+}
+
+func newEdDSAAuthenticator(t *testing.T) *softAuthenticator {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	a := newSoftAuthenticator(t)
+	a.coseKey = encodeCBOR(cborMap{
+		{int64(1), int64(1)},
+		{int64(3), webauthn.AlgEdDSA},
+		{int64(-1), int64(6)},
+		{int64(-2), []byte(publicKey)},
+	})
+	a.sign = func(data []byte) []byte {
+		return ed25519.Sign(privateKey, data)
+	}
+
+	return a
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	t.Helper()
+
+	credentialID := make([]byte, 16)
+	_, err := rand.Read(credentialID)
+	require.NoError(t, err)
+
+	return &softAuthenticator{
+		origin:       testOrigin,
+		rpID:         testRelyingParty.ID,
+		flags:        flagUserPresent | flagUserVerified,
+		credentialID: credentialID,
+	}
+}
+
+// create registers a passkey like navigator.credentials.create.
+func (a *softAuthenticator) create(t *testing.T, options webauthn.CreationOptions) webauthn.RegistrationCredential {
+	t.Helper()
+
+	a.userHandle = options.User.ID
+
+	authData := a.authenticatorData(a.flags | flagAttestedCredential)
+	authData = append(authData, make([]byte, 16)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID)))
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, a.coseKey...)
+
+	return webauthn.RegistrationCredential{
+		ID:    base64.RawURLEncoding.EncodeToString(a.credentialID),
+		RawID: a.credentialID,
+		Type:  "public-key",
+		Response: webauthn.AttestationResponse{
+			ClientDataJSON: a.clientDataJSON(t, "webauthn.create", options.Challenge),
+			AttestationObject: encodeCBOR(cborMap{
+				{"fmt", "none"},
+				{"attStmt", cborMap{}},
+				{"authData", authData},
+			}),
+			Transports: []string{"internal"},
+		},
+	}
+}
+
+// get signs the challenge like navigator.credentials.get. The signature counter is not incremented,
+// so tests can choose the counter the authenticator returns.
+func (a *softAuthenticator) get(t *testing.T, options webauthn.RequestOptions) webauthn.AssertionCredential {
+	t.Helper()
+
+	clientDataJSON := a.clientDataJSON(t, "webauthn.get", options.Challenge)
+	authData := a.authenticatorData(a.flags)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+
+	return webauthn.AssertionCredential{
+		ID:    base64.RawURLEncoding.EncodeToString(a.credentialID),
+		RawID: a.credentialID,
+		Type:  "public-key",
+		Response: webauthn.AssertionResponse{
+			ClientDataJSON:    clientDataJSON,
+			AuthenticatorData: authData,
+			Signature:         a.sign(append(authData, clientDataHash[:]...)),
+			UserHandle:        a.userHandle,
+		},
+	}
+}
+
+func (a *softAuthenticator) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+
+	authData := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(authData, a.signCount)
+}
+
+func (a *softAuthenticator) clientDataJSON(t *testing.T, ceremonyType string, challenge []byte) []byte {
+	t.Helper()
+
+	clientDataJSON, err := json.Marshal(map[string]any{
+		"type":        ceremonyType,
+		"challenge":   base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":      a.origin,
+		"crossOrigin": false,
+	})
+	require.NoError(t, err)
+
+	return clientDataJSON
+}
+
+// cborMap is a CBOR map whose entries are encoded in order.
+type cborMap [][2]any
+
+// encodeCBOR encodes the values authenticators use, which are integers, byte and text strings and maps.
+func encodeCBOR(value any) []byte {
+	switch v := value.(type) {
+	case int64:
+		if v < 0 {
+			return cborHead(1, uint64(-1-v))
+		}
+
+		return cborHead(0, uint64(v))
+	case []byte:
+		return append(cborHead(2, uint64(len(v))), v...)
+	case string:
+		return append(cborHead(3, uint64(len(v))), v...)
+	case cborMap:
+		encoded := cborHead(5, uint64(len(v)))
+		for _, entry := range v {
+			encoded = append(encoded, encodeCBOR(entry[0])...)
+			encoded = append(encoded, encodeCBOR(entry[1])...)
+		}
+
+		return encoded
+	}
+
+	panic("unsupported CBOR value")
+}
+
+func cborHead(majorType byte, argument uint64) []byte {
+	switch {
+	case argument < 24:
+		return []byte{majorType<<5 | byte(argument)}
+	case argument <= 0xff:
+		return []byte{majorType<<5 | 24, byte(argument)}
+	case argument <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{majorType<<5 | 25}, uint16(argument))
+	default:
+		return binary.BigEndian.AppendUint32([]byte{majorType<<5 | 26}, uint32(argument))
+	}
+}
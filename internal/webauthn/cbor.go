@@ -0,0 +1,180 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// errInvalidCBOR is returned for data which is not the subset of CBOR (RFC 8949) authenticators use.
+var errInvalidCBOR = errors.New("webauthn: invalid CBOR")
+
+// maxCBORDepth limits the nesting of arrays and maps, so a crafted payload cannot exhaust the stack.
+const maxCBORDepth = 16
+
+// decodeCBOR decodes the first CBOR item of the data and returns it with the number of bytes it takes.
+// Integers are returned as int64, byte strings as []byte, text strings as string, arrays as []any
+// and maps as map[any]any. Indefinite lengths, tags and floats are not used by authenticators and are rejected.
+func decodeCBOR(data []byte) (any, int, error) {
+	d := cborDecoder{data: data}
+
+	value, err := d.decode(0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return value, d.offset, nil
+}
+
+type cborDecoder struct {
+	data   []byte
+	offset int
+}
+
+func (d *cborDecoder) decode(depth int) (any, error) {
+	if depth > maxCBORDepth {
+		return nil, errInvalidCBOR
+	}
+
+	majorType, argument, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+
+	switch majorType {
+	case 0:
+		if argument > math.MaxInt64 {
+			return nil, errInvalidCBOR
+		}
+
+		return int64(argument), nil
+	case 1:
+		if argument > math.MaxInt64 {
+			return nil, errInvalidCBOR
+		}
+
+		return -1 - int64(argument), nil
+	case 2:
+		return d.readBytes(argument)
+	case 3:
+		text, err := d.readBytes(argument)
+		if err != nil {
+			return nil, err
+		}
+
+		return string(text), nil
+	case 4:
+		// Every item takes at least one byte, so a longer array cannot fit in the remaining data.
+		if argument > uint64(len(d.data)-d.offset) {
+			return nil, errInvalidCBOR
+		}
+
+		items := make([]any, 0, argument)
+		for range argument {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, nil
+	case 5:
+		if argument > uint64(len(d.data)-d.offset)/2 {
+			return nil, errInvalidCBOR
+		}
+
+		entries := make(map[any]any, argument)
+		for range argument {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, errInvalidCBOR
+			}
+
+			if _, ok := entries[key]; ok {
+				return nil, errInvalidCBOR
+			}
+
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+
+			entries[key] = value
+		}
+
+		return entries, nil
+	case 7:
+		switch argument {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22:
+			return nil, nil
+		}
+	}
+
+	return nil, errInvalidCBOR
+}
+
+// readHead reads the major type and the argument of the next item.
+func (d *cborDecoder) readHead() (byte, uint64, error) {
+	if d.offset >= len(d.data) {
+		return 0, 0, errInvalidCBOR
+	}
+
+	initial := d.data[d.offset]
+	d.offset++
+
+	majorType := initial >> 5
+	additional := initial & 0x1f
+
+	if additional < 24 {
+		return majorType, uint64(additional), nil
+	}
+
+	var size int
+
+	switch additional {
+	case 24:
+		size = 1
+	case 25:
+		size = 2
+	case 26:
+		size = 4
+	case 27:
+		size = 8
+	default:
+		return 0, 0, errInvalidCBOR
+	}
+
+	if len(d.data)-d.offset < size {
+		return 0, 0, errInvalidCBOR
+	}
+
+	buf := make([]byte, 8)
+	copy(buf[8-size:], d.data[d.offset:d.offset+size])
+	d.offset += size
+
+	return majorType, binary.BigEndian.Uint64(buf), nil
+}
+
+// readBytes reads the content of a byte or text string.
+func (d *cborDecoder) readBytes(length uint64) ([]byte, error) {
+	if length > uint64(len(d.data)-d.offset) {
+		return nil, errInvalidCBOR
+	}
+
+	content := d.data[d.offset : d.offset+int(length)]
+	d.offset += int(length)
+
+	return content, nil
+}
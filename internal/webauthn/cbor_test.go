@@ -0,0 +1,84 @@
+package webauthn
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCBOR(t *testing.T) {
+	// given
+	// {1: 2, 3: -7, "fmt": "none", "authData": h'0102'} followed by one byte which is not a part of the map.
+	data := []byte{0xa4, 0x01, 0x02, 0x03, 0x26, 0x63, 'f', 'm', 't', 0x64, 'n', 'o', 'n', 'e', 0x68, 'a', 'u', 't', 'h', 'D', 'a', 't', 'a', 0x42, 0x01, 0x02, 0xff}
+
+	// when
+	value, n, err := decodeCBOR(data)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, len(data)-1, n)
+	assert.Equal(t, map[any]any{
+		int64(1):   int64(2),
+		int64(3):   int64(-7),
+		"fmt":      "none",
+		"authData": []byte{0x01, 0x02},
+	}, value)
+}
+
+func TestDecodeInvalidCBOR(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty data", data: []byte{}},
+		{name: "truncated byte string", data: []byte{0x45, 0x01, 0x02}},
+		{name: "truncated length", data: []byte{0x59, 0x01}},
+		{name: "array longer than the data", data: []byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "map with duplicate keys", data: []byte{0xa2, 0x01, 0x02, 0x01, 0x03}},
+		{name: "map with an array key", data: []byte{0xa1, 0x80, 0x01}},
+		{name: "indefinite length", data: []byte{0x5f, 0x41, 0x01, 0xff}},
+		{name: "tag", data: []byte{0xc2, 0x41, 0x01}},
+		{name: "float", data: []byte{0xf9, 0x3c, 0x00}},
+		{name: "too deeply nested", data: append(bytes.Repeat([]byte{0x81}, maxCBORDepth+2), 0x00)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			_, _, err := decodeCBOR(tc.data)
+
+			// then
+			assert.ErrorIs(t, err, errInvalidCBOR)
+		})
+	}
+}
+
+func FuzzDecodeCBOR(f *testing.F) {
+	f.Add([]byte{0xa4, 0x01, 0x02, 0x03, 0x26, 0x63, 'f', 'm', 't', 0x64, 'n', 'o', 'n', 'e', 0x68, 'a', 'u', 't', 'h', 'D', 'a', 't', 'a', 0x42, 0x01, 0x02})
+	f.Add([]byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{0xa2, 0x01, 0x02, 0x01, 0x03})
+	f.Add([]byte{0x5f, 0x41, 0x01, 0xff})
+	f.Add(append(bytes.Repeat([]byte{0x81}, maxCBORDepth), 0x00))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		value, n, err := decodeCBOR(data)
+		if err != nil {
+			assert.ErrorIs(t, err, errInvalidCBOR)
+			return
+		}
+
+		// A decoded item takes at least one byte and never more than the data has.
+		require.Positive(t, n)
+		require.LessOrEqual(t, n, len(data))
+
+		// The bytes after the item do not change how it is decoded.
+		prefixValue, prefixN, err := decodeCBOR(data[:n])
+		require.NoError(t, err)
+		assert.Equal(t, n, prefixN)
+		assert.Equal(t, value, prefixValue)
+	})
+}
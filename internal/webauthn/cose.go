@@ -0,0 +1,139 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers (RFC 9053) of the supported public keys, in the order of preference.
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// COSE key parameters (RFC 9052) and key types used by authenticators.
+const (
+	coseKeyType   int64 = 1
+	coseAlgorithm int64 = 3
+	coseCurve     int64 = -1
+	coseX         int64 = -2
+	coseY         int64 = -3
+	coseModulus   int64 = -1
+	coseExponent  int64 = -2
+
+	coseKeyTypeOKP int64 = 1
+	coseKeyTypeEC2 int64 = 2
+	coseKeyTypeRSA int64 = 3
+
+	coseCurveP256    int64 = 1
+	coseCurveEd25519 int64 = 6
+)
+
+// minRSAKeyBits is the smallest RSA modulus which is accepted.
+const minRSAKeyBits = 2048
+
+// ErrUnsupportedKey is returned for public keys whose algorithm is not supported.
+var ErrUnsupportedKey = errors.New("webauthn: unsupported public key")
+
+// publicKey is a credential public key which verifies signatures of authenticator data and client data.
+type publicKey interface {
+	verify(data, signature []byte) bool
+}
+
+type es256Key struct{ key *ecdsa.PublicKey }
+
+func (k es256Key) verify(data, signature []byte) bool {
+	digest := sha256.Sum256(data)
+	return ecdsa.VerifyASN1(k.key, digest[:], signature)
+}
+
+type eddsaKey struct{ key ed25519.PublicKey }
+
+func (k eddsaKey) verify(data, signature []byte) bool {
+	return ed25519.Verify(k.key, data, signature)
+}
+
+type rs256Key struct{ key *rsa.PublicKey }
+
+func (k rs256Key) verify(data, signature []byte) bool {
+	digest := sha256.Sum256(data)
+	return rsa.VerifyPKCS1v15(k.key, crypto.SHA256, digest[:], signature) == nil
+}
+
+// parsePublicKey parses a public key encoded as a COSE key.
+func parsePublicKey(coseKey []byte) (publicKey, error) {
+	value, n, err := decodeCBOR(coseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if n != len(coseKey) {
+		return nil, errInvalidCBOR
+	}
+
+	params, ok := value.(map[any]any)
+	if !ok {
+		return nil, errInvalidCBOR
+	}
+
+	keyType, _ := params[coseKeyType].(int64)
+	algorithm, _ := params[coseAlgorithm].(int64)
+
+	switch {
+	case keyType == coseKeyTypeEC2 && algorithm == AlgES256:
+		curve, _ := params[coseCurve].(int64)
+		x, _ := params[coseX].([]byte)
+		y, _ := params[coseY].([]byte)
+
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("%w: invalid P-256 key", ErrUnsupportedKey)
+		}
+
+		// crypto/ecdh rejects points which are not on the curve.
+		point := append(append([]byte{4}, x...), y...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("%w: invalid P-256 key", ErrUnsupportedKey)
+		}
+
+		return es256Key{key: &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}}, nil
+	case keyType == coseKeyTypeOKP && algorithm == AlgEdDSA:
+		curve, _ := params[coseCurve].(int64)
+		x, _ := params[coseX].([]byte)
+
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid Ed25519 key", ErrUnsupportedKey)
+		}
+
+		return eddsaKey{key: ed25519.PublicKey(x)}, nil
+	case keyType == coseKeyTypeRSA && algorithm == AlgRS256:
+		modulus, _ := params[coseModulus].([]byte)
+		exponent, _ := params[coseExponent].([]byte)
+
+		e := new(big.Int).SetBytes(exponent)
+		if len(exponent) == 0 || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("%w: invalid RSA key", ErrUnsupportedKey)
+		}
+
+		n := new(big.Int).SetBytes(modulus)
+		if n.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("%w: RSA key is shorter than %d bits", ErrUnsupportedKey, minRSAKeyBits)
+		}
+
+		return rs256Key{key: &rsa.PublicKey{N: n, E: int(e.Int64())}}, nil
+	}
+
+	return nil, ErrUnsupportedKey
+}
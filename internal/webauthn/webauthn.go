@@ -0,0 +1,479 @@
+// Package webauthn implements the server side of the Web Authentication ceremonies (W3C WebAuthn Level 2)
+// which register passkeys and sign in with them. Attestation statements are not verified, because
+// the relying party asks for no attestation and accepts passkeys from any authenticator.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// Timeout is how long the browser waits for the user to complete a ceremony.
+	Timeout = 5 * time.Minute
+	// challengeLength is the number of random bytes of a challenge.
+	challengeLength = 32
+	// credentialType is the only type of credentials defined by WebAuthn.
+	credentialType = "public-key"
+)
+
+// Flags of the authenticator data.
+const (
+	flagUserPresent           byte = 0x01
+	flagUserVerified          byte = 0x04
+	flagAttestedCredential    byte = 0x40
+	flagExtensionDataIncluded byte = 0x80
+)
+
+var (
+	// ErrInvalidCredential is returned for credentials which fail the verification of a ceremony.
+	ErrInvalidCredential = errors.New("webauthn: invalid credential")
+	// ErrSignCountRegressed is returned when the signature counter of an authenticator did not increase,
+	// which means the credential may have been cloned.
+	ErrSignCountRegressed = errors.New("webauthn: signature counter did not increase")
+)
+
+// RelyingParty is the website which passkeys are registered for.
+type RelyingParty struct {
+	// ID is the domain of the website, which passkeys are scoped to.
+	ID string
+	// Name is the name of the website shown by authenticators.
+	Name string
+	// Origins are the origins the ceremonies are allowed to run on.
+	Origins []string
+}
+
+// URLEncodedBytes is a byte slice which is encoded in JSON as unpadded base64url, like the binary fields of WebAuthn.
+type URLEncodedBytes []byte
+
+func (b URLEncodedBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *URLEncodedBytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var encoded string
+
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return fmt.Errorf("webauthn: invalid base64url: %w", err)
+	}
+
+	*b = decoded
+
+	return nil
+}
+
+// RelyingPartyEntity describes the relying party to the authenticator.
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity describes the account a passkey is registered for.
+type UserEntity struct {
+	ID          URLEncodedBytes `json:"id" swaggertype:"string" format:"base64url"`
+	Name        string          `json:"name"`
+	DisplayName string          `json:"displayName"`
+}
+
+// CredentialParameter is a type of public key the relying party accepts.
+type CredentialParameter struct {
+	Type      string `json:"type"`
+	Algorithm int64  `json:"alg"`
+}
+
+// CredentialDescriptor identifies a registered credential.
+type CredentialDescriptor struct {
+	Type       string          `json:"type"`
+	ID         URLEncodedBytes `json:"id" swaggertype:"string" format:"base64url"`
+	Transports []string        `json:"transports,omitempty"`
+}
+
+// AuthenticatorSelection states the requirements for the authenticator of a new passkey.
+type AuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+// CreationOptions are the options of navigator.credentials.create which register a passkey.
+type CreationOptions struct {
+	Challenge              URLEncodedBytes        `json:"challenge" swaggertype:"string" format:"base64url"`
+	RelyingParty           RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are the options of navigator.credentials.get which sign in with a passkey.
+type RequestOptions struct {
+	Challenge        URLEncodedBytes        `json:"challenge" swaggertype:"string" format:"base64url"`
+	Timeout          int64                  `json:"timeout"`
+	RelyingPartyID   string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// AttestationResponse is the response of an authenticator to navigator.credentials.create.
+type AttestationResponse struct {
+	ClientDataJSON    URLEncodedBytes `json:"clientDataJSON" validate:"required" swaggertype:"string" format:"base64url"`
+	AttestationObject URLEncodedBytes `json:"attestationObject" validate:"required" swaggertype:"string" format:"base64url"`
+	Transports        []string        `json:"transports"`
+}
+
+// RegistrationCredential is a new passkey as serialized by PublicKeyCredential.toJSON.
+type RegistrationCredential struct {
+	ID       string              `json:"id" validate:"required"`
+	RawID    URLEncodedBytes     `json:"rawId" validate:"required" swaggertype:"string" format:"base64url"`
+	Type     string              `json:"type" validate:"required"`
+	Response AttestationResponse `json:"response" validate:"required"`
+}
+
+// AssertionResponse is the response of an authenticator to navigator.credentials.get.
+type AssertionResponse struct {
+	ClientDataJSON    URLEncodedBytes `json:"clientDataJSON" validate:"required" swaggertype:"string" format:"base64url"`
+	AuthenticatorData URLEncodedBytes `json:"authenticatorData" validate:"required" swaggertype:"string" format:"base64url"`
+	Signature         URLEncodedBytes `json:"signature" validate:"required" swaggertype:"string" format:"base64url"`
+	UserHandle        URLEncodedBytes `json:"userHandle" swaggertype:"string" format:"base64url"`
+}
+
+// AssertionCredential is a signed challenge of a passkey as serialized by PublicKeyCredential.toJSON.
+type AssertionCredential struct {
+	ID       string            `json:"id" validate:"required"`
+	RawID    URLEncodedBytes   `json:"rawId" validate:"required" swaggertype:"string" format:"base64url"`
+	Type     string            `json:"type" validate:"required"`
+	Response AssertionResponse `json:"response" validate:"required"`
+}
+
+// Credential is a verified passkey which should be stored by the relying party.
+type Credential struct {
+	ID         []byte
+	PublicKey  []byte
+	SignCount  uint32
+	AAGUID     []byte
+	Transports []string
+}
+
+// StoredCredential is a passkey which was registered earlier.
+type StoredCredential struct {
+	PublicKey  []byte
+	SignCount  uint32
+	UserHandle []byte
+}
+
+// clientData is the data the browser signs together with the authenticator data.
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// authenticatorData is the data signed by the authenticator.
+type authenticatorData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+	// The fields below are only set during registration.
+	aaguid              []byte
+	credentialID        []byte
+	credentialPublicKey []byte
+}
+
+// NewCreationOptions returns the options which register a passkey for an account with a new random challenge.
+// The credentials in exclude are already registered, so authenticators holding one of them refuse to create another.
+func (rp RelyingParty) NewCreationOptions(userHandle []byte, userName string, exclude [][]byte) (CreationOptions, error) {
+	challenge, err := newChallenge()
+	if err != nil {
+		return CreationOptions{}, err
+	}
+
+	excludeCredentials := make([]CredentialDescriptor, 0, len(exclude))
+	for _, id := range exclude {
+		excludeCredentials = append(excludeCredentials, CredentialDescriptor{Type: credentialType, ID: id})
+	}
+
+	return CreationOptions{
+		Challenge:    challenge,
+		RelyingParty: RelyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User: UserEntity{
+			ID:          userHandle,
+			Name:        userName,
+			DisplayName: userName,
+		},
+		PubKeyCredParams: []CredentialParameter{
+			{Type: credentialType, Algorithm: AlgES256},
+			{Type: credentialType, Algorithm: AlgEdDSA},
+			{Type: credentialType, Algorithm: AlgRS256},
+		},
+		Timeout:            Timeout.Milliseconds(),
+		ExcludeCredentials: excludeCredentials,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:        "required",
+			RequireResidentKey: true,
+			UserVerification:   "required",
+		},
+		Attestation: "none",
+	}, nil
+}
+
+// NewRequestOptions returns the options which sign in with any passkey of the relying party with a new random challenge.
+func (rp RelyingParty) NewRequestOptions() (RequestOptions, error) {
+	challenge, err := newChallenge()
+	if err != nil {
+		return RequestOptions{}, err
+	}
+
+	return RequestOptions{
+		Challenge:        challenge,
+		Timeout:          Timeout.Milliseconds(),
+		RelyingPartyID:   rp.ID,
+		AllowCredentials: []CredentialDescriptor{},
+		UserVerification: "required",
+	}, nil
+}
+
+// ParseChallenge returns the challenge which the client data was created for,
+// so the relying party can look up the state of the ceremony before verifying it.
+func ParseChallenge(clientDataJSON []byte) ([]byte, error) {
+	var data clientData
+
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil {
+		return nil, fmt.Errorf("%w: invalid client data", ErrInvalidCredential)
+	}
+
+	challenge, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(data.Challenge, "="))
+	if err != nil || len(challenge) == 0 {
+		return nil, fmt.Errorf("%w: invalid challenge", ErrInvalidCredential)
+	}
+
+	return challenge, nil
+}
+
+// VerifyRegistration verifies a new passkey created for the challenge and returns the credential to store.
+func (rp RelyingParty) VerifyRegistration(credential RegistrationCredential, challenge []byte) (Credential, error) {
+	if credential.Type != credentialType {
+		return Credential{}, fmt.Errorf("%w: unsupported credential type", ErrInvalidCredential)
+	}
+
+	if err := rp.verifyClientData(credential.Response.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return Credential{}, err
+	}
+
+	attestation, n, err := decodeCBOR(credential.Response.AttestationObject)
+	if err != nil || n != len(credential.Response.AttestationObject) {
+		return Credential{}, fmt.Errorf("%w: invalid attestation object", ErrInvalidCredential)
+	}
+
+	attestationObject, _ := attestation.(map[any]any)
+	rawAuthData, _ := attestationObject["authData"].([]byte)
+
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	if err := rp.verifyAuthenticatorData(authData); err != nil {
+		return Credential{}, err
+	}
+
+	if authData.credentialID == nil {
+		return Credential{}, fmt.Errorf("%w: missing attested credential data", ErrInvalidCredential)
+	}
+
+	if !bytes.Equal(authData.credentialID, credential.RawID) {
+		return Credential{}, fmt.Errorf("%w: credential ID does not match", ErrInvalidCredential)
+	}
+
+	if _, err := parsePublicKey(authData.credentialPublicKey); err != nil {
+		return Credential{}, err
+	}
+
+	return Credential{
+		ID:         authData.credentialID,
+		PublicKey:  authData.credentialPublicKey,
+		SignCount:  authData.signCount,
+		AAGUID:     authData.aaguid,
+		Transports: credential.Response.Transports,
+	}, nil
+}
+
+// VerifyAssertion verifies that a registered passkey signed the challenge and returns its new signature counter.
+// ErrSignCountRegressed is returned when the counter shows the passkey may have been cloned.
+func (rp RelyingParty) VerifyAssertion(credential AssertionCredential, challenge []byte, stored StoredCredential) (uint32, error) {
+	if credential.Type != credentialType {
+		return 0, fmt.Errorf("%w: unsupported credential type", ErrInvalidCredential)
+	}
+
+	if len(credential.Response.UserHandle) != 0 && !bytes.Equal(credential.Response.UserHandle, stored.UserHandle) {
+		return 0, fmt.Errorf("%w: user handle does not match", ErrInvalidCredential)
+	}
+
+	if err := rp.verifyClientData(credential.Response.ClientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+
+	authData, err := parseAuthenticatorData(credential.Response.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := rp.verifyAuthenticatorData(authData); err != nil {
+		return 0, err
+	}
+
+	key, err := parsePublicKey(stored.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(credential.Response.ClientDataJSON)
+	signedData := append(slices.Clip(credential.Response.AuthenticatorData), clientDataHash[:]...)
+
+	if !key.verify(signedData, credential.Response.Signature) {
+		return 0, fmt.Errorf("%w: invalid signature", ErrInvalidCredential)
+	}
+
+	// Authenticators which do not count signatures always return zero.
+	if (authData.signCount != 0 || stored.SignCount != 0) && authData.signCount <= stored.SignCount {
+		return 0, ErrSignCountRegressed
+	}
+
+	return authData.signCount, nil
+}
+
+// verifyClientData checks that the client data was created by a ceremony of the given type
+// for the challenge on one of the origins of the relying party.
+func (rp RelyingParty) verifyClientData(clientDataJSON []byte, ceremonyType string, challenge []byte) error {
+	var data clientData
+
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil {
+		return fmt.Errorf("%w: invalid client data", ErrInvalidCredential)
+	}
+
+	if data.Type != ceremonyType {
+		return fmt.Errorf("%w: unexpected ceremony type", ErrInvalidCredential)
+	}
+
+	signedChallenge, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(data.Challenge, "="))
+	if err != nil || subtle.ConstantTimeCompare(signedChallenge, challenge) != 1 {
+		return fmt.Errorf("%w: challenge does not match", ErrInvalidCredential)
+	}
+
+	if !slices.Contains(rp.Origins, data.Origin) || data.CrossOrigin {
+		return fmt.Errorf("%w: origin %q is not allowed", ErrInvalidCredential, data.Origin)
+	}
+
+	return nil
+}
+
+// verifyAuthenticatorData checks that the authenticator data belongs to the relying party
+// and that the user was present and verified.
+func (rp RelyingParty) verifyAuthenticatorData(authData authenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+
+	if subtle.ConstantTimeCompare(authData.rpIDHash, rpIDHash[:]) != 1 {
+		return fmt.Errorf("%w: relying party ID does not match", ErrInvalidCredential)
+	}
+
+	if authData.flags&flagUserPresent == 0 {
+		return fmt.Errorf("%w: user was not present", ErrInvalidCredential)
+	}
+
+	// A passkey replaces both the password and the second factor, so the user has to be verified.
+	if authData.flags&flagUserVerified == 0 {
+		return fmt.Errorf("%w: user was not verified", ErrInvalidCredential)
+	}
+
+	return nil
+}
+
+// parseAuthenticatorData parses the binary authenticator data.
+func parseAuthenticatorData(data []byte) (authenticatorData, error) {
+	invalid := fmt.Errorf("%w: invalid authenticator data", ErrInvalidCredential)
+
+	// rpIdHash (32 bytes), flags (1 byte) and signCount (4 bytes).
+	if len(data) < 37 {
+		return authenticatorData{}, invalid
+	}
+
+	authData := authenticatorData{
+		rpIDHash:  data[:32],
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[37:]
+
+	if authData.flags&flagAttestedCredential != 0 {
+		// aaguid (16 bytes) and the length of the credential ID (2 bytes).
+		if len(rest) < 18 {
+			return authenticatorData{}, invalid
+		}
+
+		authData.aaguid = rest[:16]
+		credentialIDLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+
+		if credentialIDLength == 0 || len(rest) < credentialIDLength {
+			return authenticatorData{}, invalid
+		}
+
+		authData.credentialID = rest[:credentialIDLength]
+		rest = rest[credentialIDLength:]
+
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return authenticatorData{}, invalid
+		}
+
+		authData.credentialPublicKey = rest[:n]
+		rest = rest[n:]
+	}
+
+	if authData.flags&flagExtensionDataIncluded != 0 {
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return authenticatorData{}, invalid
+		}
+
+		rest = rest[n:]
+	}
+
+	if len(rest) != 0 {
+		return authenticatorData{}, invalid
+	}
+
+	return authData, nil
+}
+
+// newChallenge returns a new random challenge.
+func newChallenge() ([]byte, error) {
+	challenge := make([]byte, challengeLength)
+
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
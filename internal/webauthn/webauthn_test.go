@@ -0,0 +1,263 @@
+package webauthn_test
+
+import (
+	"testing"
+
+	"github.com/danielbukowski/recipe-app-backend/internal/webauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOrigin = "https://recipes.example.com"
+
+var testRelyingParty = webauthn.RelyingParty{
+	ID:      "recipes.example.com",
+	Name:    "Recipe App",
+	Origins: []string{testOrigin},
+}
+
+func TestRegisterAndSignIn(t *testing.T) {
+	testCases := []struct {
+		name          string
+		authenticator func(t *testing.T) *softAuthenticator
+	}{
+		{name: "ES256 passkey", authenticator: newES256Authenticator},
+		{name: "EdDSA passkey", authenticator: newEdDSAAuthenticator},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			authenticator := tc.authenticator(t)
+			userHandle := []byte("0123456789abcdef")
+
+			creationOptions, err := testRelyingParty.NewCreationOptions(userHandle, "user@mail.com", nil)
+			require.NoError(t, err)
+
+			// when
+			credential, err := testRelyingParty.VerifyRegistration(authenticator.create(t, creationOptions), creationOptions.Challenge)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, authenticator.credentialID, credential.ID)
+			assert.Equal(t, authenticator.coseKey, credential.PublicKey)
+			assert.Equal(t, uint32(0), credential.SignCount)
+			assert.Equal(t, []string{"internal"}, credential.Transports)
+
+			// given
+			authenticator.signCount = 1
+
+			requestOptions, err := testRelyingParty.NewRequestOptions()
+			require.NoError(t, err)
+
+			stored := webauthn.StoredCredential{PublicKey: credential.PublicKey, SignCount: credential.SignCount, UserHandle: userHandle}
+
+			// when
+			signCount, err := testRelyingParty.VerifyAssertion(authenticator.get(t, requestOptions), requestOptions.Challenge, stored)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, uint32(1), signCount)
+		})
+	}
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	testCases := []struct {
+		name   string
+		setup  func(a *softAuthenticator)
+		tamper func(t *testing.T, a *softAuthenticator, credential *webauthn.RegistrationCredential)
+	}{
+		{
+			name: "challenge of another ceremony",
+			tamper: func(t *testing.T, a *softAuthenticator, credential *webauthn.RegistrationCredential) {
+				credential.Response.ClientDataJSON = a.clientDataJSON(t, "webauthn.create", []byte("another challenge"))
+			},
+		},
+		{
+			name: "client data of a sign-in",
+			tamper: func(t *testing.T, a *softAuthenticator, credential *webauthn.RegistrationCredential) {
+				challenge, err := webauthn.ParseChallenge(credential.Response.ClientDataJSON)
+				require.NoError(t, err)
+
+				credential.Response.ClientDataJSON = a.clientDataJSON(t, "webauthn.get", challenge)
+			},
+		},
+		{
+			name: "origin is not allowed",
+			setup: func(a *softAuthenticator) {
+				a.origin = "https://phishing.example.com"
+			},
+		},
+		{
+			name: "passkey of another relying party",
+			setup: func(a *softAuthenticator) {
+				a.rpID = "example.com"
+			},
+		},
+		{
+			name: "user was not verified",
+			setup: func(a *softAuthenticator) {
+				a.flags = flagUserPresent
+			},
+		},
+		{
+			name: "credential ID does not match the attested one",
+			tamper: func(_ *testing.T, _ *softAuthenticator, credential *webauthn.RegistrationCredential) {
+				credential.RawID = []byte("another credential")
+			},
+		},
+		{
+			name: "attestation object is not CBOR",
+			tamper: func(_ *testing.T, _ *softAuthenticator, credential *webauthn.RegistrationCredential) {
+				credential.Response.AttestationObject = []byte("{}")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			authenticator := newES256Authenticator(t)
+			if tc.setup != nil {
+				tc.setup(authenticator)
+			}
+
+			options, err := testRelyingParty.NewCreationOptions([]byte("0123456789abcdef"), "user@mail.com", nil)
+			require.NoError(t, err)
+
+			credential := authenticator.create(t, options)
+			if tc.tamper != nil {
+				tc.tamper(t, authenticator, &credential)
+			}
+
+			// when
+			_, err = testRelyingParty.VerifyRegistration(credential, options.Challenge)
+
+			// then
+			assert.ErrorIs(t, err, webauthn.ErrInvalidCredential)
+		})
+	}
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	userHandle := []byte("0123456789abcdef")
+
+	testCases := []struct {
+		name              string
+		storedSignCount   uint32
+		assertedSignCount uint32
+		setup             func(a *softAuthenticator)
+		tamper            func(credential *webauthn.AssertionCredential)
+		wantErr           error
+	}{
+		{
+			name:              "signature counter increased",
+			storedSignCount:   5,
+			assertedSignCount: 6,
+		},
+		{
+			name:              "authenticator which does not count signatures",
+			storedSignCount:   0,
+			assertedSignCount: 0,
+		},
+		{
+			name:              "signature counter did not increase",
+			storedSignCount:   5,
+			assertedSignCount: 5,
+			wantErr:           webauthn.ErrSignCountRegressed,
+		},
+		{
+			name:              "signature counter went back",
+			storedSignCount:   5,
+			assertedSignCount: 0,
+			wantErr:           webauthn.ErrSignCountRegressed,
+		},
+		{
+			name:              "signature of other data",
+			assertedSignCount: 1,
+			tamper: func(credential *webauthn.AssertionCredential) {
+				credential.Response.ClientDataJSON = append(credential.Response.ClientDataJSON, ' ')
+			},
+			wantErr: webauthn.ErrInvalidCredential,
+		},
+		{
+			name:              "passkey of another user",
+			assertedSignCount: 1,
+			tamper: func(credential *webauthn.AssertionCredential) {
+				credential.Response.UserHandle = []byte("fedcba9876543210")
+			},
+			wantErr: webauthn.ErrInvalidCredential,
+		},
+		{
+			name:              "user was not present",
+			assertedSignCount: 1,
+			setup: func(a *softAuthenticator) {
+				a.flags = flagUserVerified
+			},
+			wantErr: webauthn.ErrInvalidCredential,
+		},
+		{
+			name:              "origin is not allowed",
+			assertedSignCount: 1,
+			setup: func(a *softAuthenticator) {
+				a.origin = "https://phishing.example.com"
+			},
+			wantErr: webauthn.ErrInvalidCredential,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			authenticator := newES256Authenticator(t)
+			authenticator.userHandle = userHandle
+			authenticator.signCount = tc.assertedSignCount
+			if tc.setup != nil {
+				tc.setup(authenticator)
+			}
+
+			options, err := testRelyingParty.NewRequestOptions()
+			require.NoError(t, err)
+
+			credential := authenticator.get(t, options)
+			if tc.tamper != nil {
+				tc.tamper(&credential)
+			}
+
+			stored := webauthn.StoredCredential{PublicKey: authenticator.coseKey, SignCount: tc.storedSignCount, UserHandle: userHandle}
+
+			// when
+			signCount, err := testRelyingParty.VerifyAssertion(credential, options.Challenge, stored)
+
+			// then
+			assert.ErrorIs(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				assert.Equal(t, tc.assertedSignCount, signCount)
+			}
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	// given
+	authenticator := newES256Authenticator(t)
+
+	options, err := testRelyingParty.NewRequestOptions()
+	require.NoError(t, err)
+
+	credential := authenticator.get(t, options)
+
+	// when
+	challenge, err := webauthn.ParseChallenge(credential.Response.ClientDataJSON)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []byte(options.Challenge), challenge)
+}